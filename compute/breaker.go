@@ -0,0 +1,92 @@
+//  Copyright 2020 Google Inc. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package compute
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned without contacting the API while the client's
+// circuit breaker is open. It is not retried.
+var ErrCircuitOpen = errors.New("compute API circuit breaker is open after repeated failures; failing fast")
+
+// RetryPolicy configures the client's circuit breaker. After FailureThreshold
+// consecutive retryable failures (5xx, 429 or transport errors) within
+// FailureWindow, all requests fail with ErrCircuitOpen until Cooldown has
+// passed.
+type RetryPolicy struct {
+	FailureThreshold int
+	FailureWindow    time.Duration
+	Cooldown         time.Duration
+}
+
+// WithRetryPolicy enables a circuit breaker configured by p. A
+// FailureThreshold of zero or less disables it.
+func WithRetryPolicy(p RetryPolicy) Option {
+	return func(c *client) {
+		c.retryPolicy = p
+	}
+}
+
+// circuitBreaker short-circuits requests after repeated retryable failures.
+type circuitBreaker struct {
+	base   http.RoundTripper
+	policy RetryPolicy
+	now    func() time.Time
+
+	mu           sync.Mutex
+	failures     int
+	firstFailure time.Time
+	openUntil    time.Time
+}
+
+func (b *circuitBreaker) RoundTrip(req *http.Request) (*http.Response, error) {
+	b.mu.Lock()
+	if b.now().Before(b.openUntil) {
+		b.mu.Unlock()
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return nil, ErrCircuitOpen
+	}
+	b.mu.Unlock()
+
+	resp, err := b.base.RoundTrip(req)
+	b.record(err != nil || resp.StatusCode >= 500 || resp.StatusCode == 429)
+	return resp, err
+}
+
+// record updates the breaker with the outcome of a request.
+func (b *circuitBreaker) record(failed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !failed {
+		b.failures = 0
+		return
+	}
+	now := b.now()
+	if b.failures == 0 || now.Sub(b.firstFailure) > b.policy.FailureWindow {
+		b.failures = 0
+		b.firstFailure = now
+	}
+	b.failures++
+	if b.failures >= b.policy.FailureThreshold {
+		b.openUntil = now.Add(b.policy.Cooldown)
+		b.failures = 0
+	}
+}
@@ -0,0 +1,136 @@
+//  Copyright 2020 Google Inc. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package compute
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"google.golang.org/api/option"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	status := http.StatusServiceUnavailable
+	requests := 0
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(status)
+	}))
+	defer svr.Close()
+
+	now := time.Unix(0, 0)
+	b := &circuitBreaker{
+		base:   http.DefaultTransport,
+		policy: RetryPolicy{FailureThreshold: 3, FailureWindow: time.Minute, Cooldown: time.Minute},
+		now:    func() time.Time { return now },
+	}
+	hc := &http.Client{Transport: b}
+	get := func() error {
+		resp, err := hc.Get(svr.URL)
+		if err == nil {
+			resp.Body.Close()
+		}
+		return err
+	}
+
+	for i := 0; i < 3; i++ {
+		if err := get(); err != nil {
+			t.Fatalf("request %d: unexpected error: %v", i, err)
+		}
+	}
+	if err := get(); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("want ErrCircuitOpen after 3 failures, got %v", err)
+	}
+	if requests != 3 {
+		t.Errorf("want 3 requests to reach the server, got %d", requests)
+	}
+
+	// After the cool-down, requests go through again and a success resets
+	// the failure count.
+	now = now.Add(2 * time.Minute)
+	status = http.StatusOK
+	if err := get(); err != nil {
+		t.Fatalf("unexpected error after cool-down: %v", err)
+	}
+	status = http.StatusServiceUnavailable
+	for i := 0; i < 2; i++ {
+		if err := get(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	// Failures spread over more than the window do not open the breaker.
+	now = now.Add(2 * time.Minute)
+	if err := get(); err != nil {
+		t.Errorf("unexpected error for failure outside the window: %v", err)
+	}
+}
+
+func TestWithRetryPolicy(t *testing.T) {
+	requests := 0
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer svr.Close()
+
+	c, err := NewClientWithOptions(context.Background(), []option.ClientOption{option.WithEndpoint(svr.URL), option.WithHTTPClient(http.DefaultClient)},
+		WithRetryPolicy(RetryPolicy{FailureThreshold: 1, FailureWindow: time.Minute, Cooldown: time.Hour}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetProject("p"); err == nil {
+		t.Fatal("want error from failing server")
+	}
+	if _, err := c.GetProject("p"); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("want ErrCircuitOpen, got %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("want 1 request to reach the server, got %d", requests)
+	}
+}
+
+func TestRetryPolicyUsesClientClock(t *testing.T) {
+	status := http.StatusServiceUnavailable
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		w.Write([]byte("{}"))
+	}))
+	defer svr.Close()
+
+	clk := NewFakeClock(time.Now())
+	c, err := NewClientWithOptions(context.Background(), []option.ClientOption{option.WithEndpoint(svr.URL), option.WithHTTPClient(http.DefaultClient)},
+		WithClock(clk), WithRetryPolicy(RetryPolicy{FailureThreshold: 1, FailureWindow: time.Minute, Cooldown: time.Hour}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetProject("p"); err == nil {
+		t.Fatal("want error from failing server")
+	}
+	if _, err := c.GetProject("p"); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("want ErrCircuitOpen, got %v", err)
+	}
+
+	// Advancing the client clock past the cool-down closes the breaker.
+	clk.Sleep(2 * time.Hour)
+	status = http.StatusOK
+	if _, err := c.GetProject("p"); err != nil {
+		t.Errorf("unexpected error after the cool-down: %v", err)
+	}
+}
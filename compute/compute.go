@@ -17,10 +17,19 @@ package compute
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"math/rand"
 	"net/http"
+	"path"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"golang.org/x/oauth2"
@@ -33,74 +42,162 @@ import (
 )
 
 // Client is a client for interacting with Google Cloud Compute.
+//
+// Code that uses the compute API should depend on Client rather than on a
+// concrete type, so that tests can inject their own implementation. A fake
+// can embed Client and override only the methods it needs; NewTestClient
+// provides one backed by an httptest server.
 type Client interface {
 	AttachDisk(project, zone, instance string, d *compute.AttachedDisk) error
 	DetachDisk(project, zone, instance, disk string) error
+	CreateDiskFromImageAndAttach(project, zone, instance, image string, d *compute.Disk) error
+	CreateDiskFromSnapshot(project, zone, diskName, snapshotSelfLink string, sizeGb int64, diskType string) error
+	CloneDiskReencrypt(project, zone, sourceDisk, diskName string, key *compute.CustomerEncryptionKey) error
 	CreateDisk(project, zone string, d *compute.Disk) error
 	CreateDiskAlpha(project, zone string, d *computeAlpha.Disk) error
 	CreateDiskBeta(project, zone string, d *computeBeta.Disk) error
 	CreateForwardingRule(project, region string, fr *compute.ForwardingRule) error
 	CreateFirewallRule(project string, i *compute.Firewall) error
+	CreateFirewallRuleBeta(project string, fw *computeBeta.Firewall) error
+	PatchFirewallRuleBeta(project, name string, fw *computeBeta.Firewall) error
 	CreateImage(project string, i *compute.Image) error
 	CreateImageAlpha(project string, i *computeAlpha.Image) error
 	CreateImageBeta(project string, i *computeBeta.Image) error
+	CreateImageFromEncryptedDisk(project string, im *compute.Image, diskKey *compute.CustomerEncryptionKey) error
+	CopyImageToLocation(srcProject, srcImage, dstProject, dstImage string, storageLocations []string) error
 	CreateInstance(project, zone string, i *compute.Instance) error
 	CreateInstanceAlpha(project, zone string, i *computeAlpha.Instance) error
 	CreateInstanceBeta(project, zone string, i *computeBeta.Instance) error
+	CreateInstanceInZones(project string, zones []string, i *compute.Instance) (string, error)
+	CreateInstanceIfNotExists(project, zone string, i *compute.Instance) (bool, error)
+	CreateInstanceAndGet(project, zone string, i *compute.Instance) (*compute.Instance, error)
+	CreateInstanceFromMachineImage(project, zone, machineImage string, i *compute.Instance) error
+	BulkInsertInstance(project, zone string, r *compute.BulkInsertInstanceResource) error
+	GetBulkInsertInstanceResult(project, zone string, r *compute.BulkInsertInstanceResource) (running, failed []string, err error)
 	CreateNetwork(project string, n *compute.Network) error
 	CreateSnapshot(project, zone, disk string, s *compute.Snapshot) error
 	CreateSubnetwork(project, region string, n *compute.Subnetwork) error
 	CreateTargetInstance(project, zone string, ti *compute.TargetInstance) error
 	DeleteDisk(project, zone, name string) error
+	DeleteRegionDisk(project, region, name string) error
 	DeleteForwardingRule(project, region, name string) error
 	DeleteFirewallRule(project, name string) error
 	DeleteImage(project, name string) error
 	DeleteInstance(project, zone, name string) error
+	DeleteInstancesByFilter(project, zone, filter string) error
+	TeardownByLabel(project, labelKey, labelValue string) error
+	SetDeletionProtection(project, zone, instance string, enabled bool) error
+	UpdateNetworkInterface(project, zone, instance, networkInterface string, ni *compute.NetworkInterface) error
+	GetShieldedInstanceIdentity(project, zone, instance string) (*compute.ShieldedInstanceIdentity, error)
+	GetEffectiveFirewalls(project, zone, instance, networkInterface string) (*compute.InstancesGetEffectiveFirewallsResponse, error)
+	GetScreenshot(project, zone, instance string) (*compute.Screenshot, error)
+	GetInstanceIamPolicy(project, zone, instance string) (*compute.Policy, error)
+	SetInstanceIamPolicy(project, zone, instance string, policy *compute.Policy) error
+	TestInstanceIamPermissions(project, zone, instance string, permissions []string) ([]string, error)
+	SetShieldedInstanceIntegrityPolicy(project, zone, instance string, p *compute.ShieldedInstanceIntegrityPolicy) error
 	StartInstance(project, zone, name string) error
 	StopInstance(project, zone, name string) error
+	StopInstanceWithOptions(project, zone, name string, discardLocalSSD bool) error
 	DeleteNetwork(project, name string) error
 	DeleteSubnetwork(project, region, name string) error
 	DeleteTargetInstance(project, zone, name string) error
 	DeprecateImage(project, name string, deprecationstatus *compute.DeprecationStatus) error
 	DeprecateImageAlpha(project, name string, deprecationstatus *computeAlpha.DeprecationStatus) error
+	DeprecateImageFamily(project, family string, keepLatest int, status *compute.DeprecationStatus) error
 	GetMachineType(project, zone, machineType string) (*compute.MachineType, error)
+	GetAcceleratorType(project, zone, acceleratorType string) (*compute.AcceleratorType, error)
+	GetReservation(project, zone, name string) (*compute.Reservation, error)
 	GetProject(project string) (*compute.Project, error)
 	GetSerialPortOutput(project, zone, name string, port, start int64) (*compute.SerialPortOutput, error)
+	SendDiagnosticInterrupt(project, zone, instance string) error
 	GetZone(project, zone string) (*compute.Zone, error)
 	GetInstance(project, zone, name string) (*compute.Instance, error)
 	GetInstanceAlpha(project, zone, name string) (*computeAlpha.Instance, error)
 	GetInstanceBeta(project, zone, name string) (*computeBeta.Instance, error)
 	GetDisk(project, zone, name string) (*compute.Disk, error)
+	GetRegionDisk(project, region, name string) (*compute.Disk, error)
 	GetDiskAlpha(project, zone, name string) (*computeAlpha.Disk, error)
 	GetDiskBeta(project, zone, name string) (*computeBeta.Disk, error)
 	GetForwardingRule(project, region, name string) (*compute.ForwardingRule, error)
 	GetFirewallRule(project, name string) (*compute.Firewall, error)
+	GetFirewallRuleBeta(project, name string) (*computeBeta.Firewall, error)
 	GetGuestAttributes(project, zone, name, queryPath, variableKey string) (*compute.GuestAttributes, error)
 	GetImage(project, name string) (*compute.Image, error)
 	GetImageAlpha(project, name string) (*computeAlpha.Image, error)
 	GetImageBeta(project, name string) (*computeBeta.Image, error)
 	GetImageFromFamily(project, family string) (*compute.Image, error)
+	GetLatestImageFromFamilies(projects []string, family string) (*compute.Image, error)
 	GetLicense(project, name string) (*compute.License, error)
+	GetInstanceTemplate(project, name string) (*compute.InstanceTemplate, error)
+	GetInstanceGroupManager(project, zone, name string) (*compute.InstanceGroupManager, error)
+	GetRegionInstanceGroupManager(project, region, name string) (*compute.InstanceGroupManager, error)
+	WaitForInstanceGroupManagerStable(project, zone, name string, timeout time.Duration) error
+	WaitForRegionInstanceGroupManagerStable(project, region, name string, timeout time.Duration) error
 	GetNetwork(project, name string) (*compute.Network, error)
 	GetRegion(project, region string) (*compute.Region, error)
 	GetSubnetwork(project, region, name string) (*compute.Subnetwork, error)
 	GetTargetInstance(project, zone, name string) (*compute.TargetInstance, error)
+	GetBackendService(project, name string) (*compute.BackendService, error)
+	GetBackendServiceHealth(project, backendService, group string) (*compute.BackendServiceGroupHealth, error)
+	GetHealthCheck(project, name string) (*compute.HealthCheck, error)
+	CreateHTTPHealthCheck(project string, hc *compute.HttpHealthCheck) error
+	GetHTTPHealthCheck(project, name string) (*compute.HttpHealthCheck, error)
+	DeleteHTTPHealthCheck(project, name string) error
+	CreateHTTPSHealthCheck(project string, hc *compute.HttpsHealthCheck) error
+	GetHTTPSHealthCheck(project, name string) (*compute.HttpsHealthCheck, error)
+	DeleteHTTPSHealthCheck(project, name string) error
+	CreateSecurityPolicy(project string, sp *compute.SecurityPolicy) error
+	GetSecurityPolicy(project, name string) (*compute.SecurityPolicy, error)
+	DeleteSecurityPolicy(project, name string) error
+	AddSecurityPolicyRule(project, securityPolicy string, r *compute.SecurityPolicyRule) error
+	PatchSecurityPolicyRule(project, securityPolicy string, priority int64, r *compute.SecurityPolicyRule) error
+	GetFirewallPolicy(policy string) (*compute.FirewallPolicy, error)
+	AddFirewallPolicyAssociation(policy string, assoc *compute.FirewallPolicyAssociation) error
+	AddFirewallPolicyRule(policy string, rule *compute.FirewallPolicyRule) error
+	CreatePacketMirroring(project, region string, pm *compute.PacketMirroring) error
+	GetPacketMirroring(project, region, name string) (*compute.PacketMirroring, error)
+	DeletePacketMirroring(project, region, name string) error
+	GetURLMap(project, name string) (*compute.UrlMap, error)
+	GetTargetHTTPProxy(project, name string) (*compute.TargetHttpProxy, error)
+	GetNetworkEndpointGroup(project, zone, name string) (*compute.NetworkEndpointGroup, error)
 	InstanceStatus(project, zone, name string) (string, error)
 	InstanceStopped(project, zone, name string) (bool, error)
+	WaitForInstanceRunning(project, zone, name string, timeout time.Duration) error
+	WaitForInstanceStopped(project, zone, name string, timeout time.Duration) error
+	WaitForGuestAttribute(project, zone, instance, namespace, key, wantValue string, timeout time.Duration) error
+	WaitForGuestAttributeContext(ctx context.Context, project, zone, instance, namespace, key, wantValue string) error
+	WaitForOperationWithProgress(project, selfLink string, onProgress func(percent int)) error
+	WaitForOperationWithConfig(project, selfLink string, wc WaitConfig) error
+	DeleteOperation(project, selfLink string) error
+	ListZoneOperations(project, zone string, opts ...ListCallOption) ([]*compute.Operation, error)
+	ListRegionOperations(project, region string, opts ...ListCallOption) ([]*compute.Operation, error)
+	ListGlobalOperations(project string, opts ...ListCallOption) ([]*compute.Operation, error)
+	GetOperationsForTarget(project, scope, targetSelfLink string) ([]*compute.Operation, error)
 	ListMachineTypes(project, zone string, opts ...ListCallOption) ([]*compute.MachineType, error)
+	ListAcceleratorTypes(project, zone string, opts ...ListCallOption) ([]*compute.AcceleratorType, error)
 	ListLicenses(project string, opts ...ListCallOption) ([]*compute.License, error)
 	ListZones(project string, opts ...ListCallOption) ([]*compute.Zone, error)
+	PickZone(project, region, machineType string) (string, error)
 	ListRegions(project string, opts ...ListCallOption) ([]*compute.Region, error)
 	AggregatedListInstances(project string, opts ...ListCallOption) ([]*compute.Instance, error)
+	AggregatedListInstancesByZone(project string, opts ...ListCallOption) (map[string][]*compute.Instance, error)
 	ListInstances(project, zone string, opts ...ListCallOption) ([]*compute.Instance, error)
+	ListInstancesByStatus(project, zone, status string) ([]*compute.Instance, error)
+	GetInstanceReferrers(project, zone, instance string) ([]*compute.Reference, error)
+	ListDiskUsers(project, zone, disk string) ([]*compute.Instance, error)
+	ListAttachedAccelerators(project, zone string) (map[string][]*compute.AcceleratorConfig, error)
 	AggregatedListDisks(project string, opts ...ListCallOption) ([]*compute.Disk, error)
+	AggregatedListDisksByZone(project string, opts ...ListCallOption) (map[string][]*compute.Disk, error)
 	ListDisks(project, zone string, opts ...ListCallOption) ([]*compute.Disk, error)
+	ListUnattachedDisks(project, zone string) ([]*compute.Disk, error)
+	DeleteUnattachedDisks(project, zone string) error
 	AggregatedListForwardingRules(project string, opts ...ListCallOption) ([]*compute.ForwardingRule, error)
 	ListForwardingRules(project, zone string, opts ...ListCallOption) ([]*compute.ForwardingRule, error)
 	ListFirewallRules(project string, opts ...ListCallOption) ([]*compute.Firewall, error)
 	ListImages(project string, opts ...ListCallOption) ([]*compute.Image, error)
 	ListImagesAlpha(project string, opts ...ListCallOption) ([]*computeAlpha.Image, error)
 	GetSnapshot(project, name string) (*compute.Snapshot, error)
+	SetSnapshotLabels(project, name string, labels map[string]string, fingerprint string) error
 	ListSnapshots(project string, opts ...ListCallOption) ([]*compute.Snapshot, error)
 	DeleteSnapshot(project, name string) error
 	ListNetworks(project string, opts ...ListCallOption) ([]*compute.Network, error)
@@ -109,7 +206,14 @@ type Client interface {
 	ListTargetInstances(project, zone string, opts ...ListCallOption) ([]*compute.TargetInstance, error)
 	ResizeDisk(project, zone, disk string, drr *compute.DisksResizeRequest) error
 	SetInstanceMetadata(project, zone, name string, md *compute.Metadata) error
+	ResetWindowsPassword(project, zone, instance, username string) (string, error)
 	SetCommonInstanceMetadata(project string, md *compute.Metadata) error
+	SetProjectMetadataItem(project, key, value string) error
+	SetUsageExportBucket(project string, cfg *compute.UsageExportLocation) error
+	GetXpnHost(project string) (*compute.Project, error)
+	ListXpnHosts(project, organization string) ([]*compute.Project, error)
+	EnableXpnResource(project string, req *compute.ProjectsEnableXpnResourceRequest) error
+	DisableXpnResource(project string, req *compute.ProjectsDisableXpnResourceRequest) error
 	SetDiskAutoDelete(project, zone, instance string, autoDelete bool, deviceName string) error
 	ListMachineImages(project string, opts ...ListCallOption) ([]*compute.MachineImage, error)
 	DeleteMachineImage(project, name string) error
@@ -117,30 +221,60 @@ type Client interface {
 	GetMachineImage(project, name string) (*compute.MachineImage, error)
 	Suspend(project, zone, instance string) error
 	Resume(project, zone, instance string) error
+	ResumeWithEncryptionKey(project, zone, instance string, req *computeBeta.InstancesResumeRequest) error
+	PerformMaintenance(project, zone, instance string) error
+	SetInstanceName(project, zone, instance, newName string) error
+	UpdateInstance(project, zone string, i *compute.Instance, fields ...string) error
 	DeleteRegionTargetHTTPProxy(project, region, name string) error
 	CreateRegionTargetHTTPProxy(project, region string, p *compute.TargetHttpProxy) error
 	ListRegionTargetHTTPProxies(project, region string, opts ...ListCallOption) ([]*compute.TargetHttpProxy, error)
 	GetRegionTargetHTTPProxy(project, region, name string) (*compute.TargetHttpProxy, error)
+	DeleteRegionSSLCertificate(project, region, name string) error
+	CreateRegionSSLCertificate(project, region string, sc *compute.SslCertificate) error
+	GetRegionSSLCertificate(project, region, name string) (*compute.SslCertificate, error)
+	DeleteRegionTargetHTTPSProxy(project, region, name string) error
+	CreateRegionTargetHTTPSProxy(project, region string, p *compute.TargetHttpsProxy) error
+	GetRegionTargetHTTPSProxy(project, region, name string) (*compute.TargetHttpsProxy, error)
+	SetRegionSSLCertificates(project, region, proxy string, sslCertificates []string) error
 	DeleteRegionURLMap(project, region, name string) error
 	CreateRegionURLMap(project, region string, u *compute.UrlMap) error
 	ListRegionURLMaps(project, region string, opts ...ListCallOption) ([]*compute.UrlMap, error)
 	GetRegionURLMap(project, region, name string) (*compute.UrlMap, error)
+	ValidateRegionURLMap(project, region string, u *compute.UrlMap) (*compute.UrlMapsValidateResponse, error)
+	ValidateURLMap(project string, u *compute.UrlMap) (*compute.UrlMapsValidateResponse, error)
 	DeleteRegionBackendService(project, region, name string) error
 	CreateRegionBackendService(project, region string, b *compute.BackendService) error
+	PatchRegionBackendService(project, region, name string, b *compute.BackendService) error
 	ListRegionBackendServices(project, region string, opts ...ListCallOption) ([]*compute.BackendService, error)
 	GetRegionBackendService(project, region, name string) (*compute.BackendService, error)
+	GetRegionBackendServiceHealth(project, region, backendService, group string) (*compute.BackendServiceGroupHealth, error)
+	WaitForBackendServiceHealthy(project, region, backendService, group string, minHealthy int, timeout time.Duration) error
 	DeleteRegionHealthCheck(project, region, name string) error
 	CreateRegionHealthCheck(project, region string, h *compute.HealthCheck) error
 	ListRegionHealthChecks(project, region string, opts ...ListCallOption) ([]*compute.HealthCheck, error)
 	GetRegionHealthCheck(project, region, name string) (*compute.HealthCheck, error)
+	CreateInstanceGroup(project, zone string, ig *compute.InstanceGroup) error
+	DeleteInstanceGroup(project, zone, name string) error
+	GetInstanceGroup(project, zone, name string) (*compute.InstanceGroup, error)
+	AddInstanceGroupInstances(project, zone, name string, instances []string) error
+	RemoveInstanceGroupInstances(project, zone, name string, instances []string) error
+	AttachNetworkEndpoints(project, zone, neg string, endpoints []*compute.NetworkEndpoint) error
+	DetachNetworkEndpoints(project, zone, neg string, endpoints []*compute.NetworkEndpoint) error
+	AttachRegionNetworkEndpoints(project, region, neg string, endpoints []*compute.NetworkEndpoint) error
+	DetachRegionNetworkEndpoints(project, region, neg string, endpoints []*compute.NetworkEndpoint) error
 	DeleteRegionNetworkEndpointGroup(project, region, name string) error
 	CreateRegionNetworkEndpointGroup(project, region string, n *compute.NetworkEndpointGroup) error
 	ListRegionNetworkEndpointGroups(project, region string, opts ...ListCallOption) ([]*compute.NetworkEndpointGroup, error)
 	GetRegionNetworkEndpointGroup(project, region, name string) (*compute.NetworkEndpointGroup, error)
+	GetRegionAutoscaler(project, region, name string) (*compute.Autoscaler, error)
+	ListRegionAutoscalers(project, region string, opts ...ListCallOption) ([]*compute.Autoscaler, error)
+	GetRegionAutoscalerRecommendedSize(project, region, name string) (int64, error)
 
 	Retry(f func(opts ...googleapi.CallOption) (*compute.Operation, error), opts ...googleapi.CallOption) (op *compute.Operation, err error)
+	RetryContext(ctx context.Context, f func(opts ...googleapi.CallOption) (*compute.Operation, error), opts ...googleapi.CallOption) (op *compute.Operation, err error)
 	RetryBeta(f func(opts ...googleapi.CallOption) (*computeBeta.Operation, error), opts ...googleapi.CallOption) (op *computeBeta.Operation, err error)
 	BasePath() string
+	DefaultProject() *ProjectClient
 }
 
 // A ListCallOption is an option for a Google Compute API *ListCall.
@@ -169,8 +303,12 @@ func (o OrderBy) listCallOptionApply(i interface{}) interface{} {
 		return c.OrderBy(string(o))
 	case *compute.MachineTypesListCall:
 		return c.OrderBy(string(o))
+	case *compute.AcceleratorTypesListCall:
+		return c.OrderBy(string(o))
 	case *compute.ZonesListCall:
 		return c.OrderBy(string(o))
+	case *compute.RegionsListCall:
+		return c.OrderBy(string(o))
 	case *compute.InstancesListCall:
 		return c.OrderBy(string(o))
 	case *compute.DisksListCall:
@@ -179,16 +317,133 @@ func (o OrderBy) listCallOptionApply(i interface{}) interface{} {
 		return c.OrderBy(string(o))
 	case *compute.SubnetworksListCall:
 		return c.OrderBy(string(o))
+	case *compute.RegionAutoscalersListCall:
+		return c.OrderBy(string(o))
+	case *compute.ZoneOperationsListCall:
+		return c.OrderBy(string(o))
+	case *compute.RegionOperationsListCall:
+		return c.OrderBy(string(o))
+	case *compute.GlobalOperationsListCall:
+		return c.OrderBy(string(o))
 	case *compute.InstancesAggregatedListCall:
 		return c.OrderBy(string(o))
 	case *compute.DisksAggregatedListCall:
 		return c.OrderBy(string(o))
 	case *compute.SubnetworksAggregatedListCall:
 		return c.OrderBy(string(o))
+	case *compute.ForwardingRulesAggregatedListCall:
+		return c.OrderBy(string(o))
+	case *compute.SnapshotsListCall:
+		return c.OrderBy(string(o))
+	case *compute.ForwardingRulesListCall:
+		return c.OrderBy(string(o))
+	case *compute.LicensesListCall:
+		return c.OrderBy(string(o))
+	case *compute.RegionBackendServicesListCall:
+		return c.OrderBy(string(o))
+	case *compute.RegionHealthChecksListCall:
+		return c.OrderBy(string(o))
+	case *compute.RegionNetworkEndpointGroupsListCall:
+		return c.OrderBy(string(o))
+	case *compute.RegionTargetHttpProxiesListCall:
+		return c.OrderBy(string(o))
+	case *compute.RegionUrlMapsListCall:
+		return c.OrderBy(string(o))
+	case *compute.TargetInstancesListCall:
+		return c.OrderBy(string(o))
+	}
+	return i
+}
+
+// MaxResults caps the number of results returned by a list call, which stops
+// paging once it has collected that many. It also sets the optional parameter
+// "maxResults", the page size, to the cap or the API's maximum of 500. Combined
+// with OrderBy("creationTimestamp desc") this lists, for example, the most
+// recent images without paging through all of them.
+type MaxResults int64
+
+// maxPageSize is the largest "maxResults" the API accepts.
+const maxPageSize = 500
+
+func (o MaxResults) listCallOptionApply(i interface{}) interface{} {
+	n := int64(o)
+	if n > maxPageSize {
+		n = maxPageSize
+	}
+	switch c := i.(type) {
+	case *compute.ZoneOperationsListCall:
+		return c.MaxResults(n)
+	case *compute.RegionOperationsListCall:
+		return c.MaxResults(n)
+	case *compute.GlobalOperationsListCall:
+		return c.MaxResults(n)
+	case *compute.RegionTargetHttpProxiesListCall:
+		return c.MaxResults(n)
+	case *compute.RegionBackendServicesListCall:
+		return c.MaxResults(n)
+	case *compute.RegionUrlMapsListCall:
+		return c.MaxResults(n)
+	case *compute.RegionHealthChecksListCall:
+		return c.MaxResults(n)
+	case *compute.RegionNetworkEndpointGroupsListCall:
+		return c.MaxResults(n)
+	case *compute.RegionAutoscalersListCall:
+		return c.MaxResults(n)
+	case *compute.MachineTypesListCall:
+		return c.MaxResults(n)
+	case *compute.AcceleratorTypesListCall:
+		return c.MaxResults(n)
+	case *compute.ZonesListCall:
+		return c.MaxResults(n)
+	case *compute.RegionsListCall:
+		return c.MaxResults(n)
+	case *compute.InstancesAggregatedListCall:
+		return c.MaxResults(n)
+	case *compute.InstancesListCall:
+		return c.MaxResults(n)
+	case *compute.DisksAggregatedListCall:
+		return c.MaxResults(n)
+	case *compute.DisksListCall:
+		return c.MaxResults(n)
+	case *compute.ForwardingRulesAggregatedListCall:
+		return c.MaxResults(n)
+	case *compute.ForwardingRulesListCall:
+		return c.MaxResults(n)
+	case *compute.FirewallsListCall:
+		return c.MaxResults(n)
+	case *compute.ImagesListCall:
+		return c.MaxResults(n)
+	case *computeAlpha.ImagesListCall:
+		return c.MaxResults(n)
+	case *compute.SnapshotsListCall:
+		return c.MaxResults(n)
+	case *compute.NetworksListCall:
+		return c.MaxResults(n)
+	case *compute.SubnetworksAggregatedListCall:
+		return c.MaxResults(n)
+	case *compute.SubnetworksListCall:
+		return c.MaxResults(n)
+	case *compute.TargetInstancesListCall:
+		return c.MaxResults(n)
+	case *compute.LicensesListCall:
+		return c.MaxResults(n)
+	case *compute.MachineImagesListCall:
+		return c.MaxResults(n)
 	}
 	return i
 }
 
+// listLimit returns the cap set by a MaxResults in opts, or 0 for no cap.
+func listLimit(opts []ListCallOption) int {
+	var limit int
+	for _, opt := range opts {
+		if m, ok := opt.(MaxResults); ok && m > 0 {
+			limit = int(m)
+		}
+	}
+	return limit
+}
+
 // Filter sets the optional parameter "filter": Sets a filter {expression} for
 // filtering listed resources. Your {expression} must be in the format:
 // field_name comparison_string literal_string.
@@ -210,8 +465,12 @@ func (o Filter) listCallOptionApply(i interface{}) interface{} {
 		return c.Filter(string(o))
 	case *compute.MachineTypesListCall:
 		return c.Filter(string(o))
+	case *compute.AcceleratorTypesListCall:
+		return c.Filter(string(o))
 	case *compute.ZonesListCall:
 		return c.Filter(string(o))
+	case *compute.RegionsListCall:
+		return c.Filter(string(o))
 	case *compute.InstancesListCall:
 		return c.Filter(string(o))
 	case *compute.DisksListCall:
@@ -220,12 +479,24 @@ func (o Filter) listCallOptionApply(i interface{}) interface{} {
 		return c.Filter(string(o))
 	case *compute.SubnetworksListCall:
 		return c.Filter(string(o))
+	case *compute.RegionAutoscalersListCall:
+		return c.Filter(string(o))
+	case *compute.ZoneOperationsListCall:
+		return c.Filter(string(o))
+	case *compute.RegionOperationsListCall:
+		return c.Filter(string(o))
+	case *compute.GlobalOperationsListCall:
+		return c.Filter(string(o))
 	case *compute.InstancesAggregatedListCall:
 		return c.Filter(string(o))
 	case *compute.DisksAggregatedListCall:
 		return c.Filter(string(o))
 	case *compute.SubnetworksAggregatedListCall:
 		return c.Filter(string(o))
+	case *compute.ForwardingRulesAggregatedListCall:
+		return c.Filter(string(o))
+	case *compute.SnapshotsListCall:
+		return c.Filter(string(o))
 	}
 	return i
 }
@@ -235,21 +506,104 @@ type clientImpl interface {
 	zoneOperationsWait(project, zone, name string) error
 	regionOperationsWait(project, region, name string) error
 	globalOperationsWait(project, name string) error
+	organizationOperationsWait(name string) error
+	zoneOperationsWaitBeta(project, zone, name string) error
+	globalOperationsWaitBeta(project, name string) error
+	zoneOperationsWaitAlpha(project, zone, name string) error
+	globalOperationsWaitAlpha(project, name string) error
 }
 
+var _ clientImpl = (*client)(nil)
+
 type client struct {
-	i        clientImpl
-	hc       *http.Client
-	raw      *compute.Service
-	rawBeta  *computeBeta.Service
-	rawAlpha *computeAlpha.Service
+	i         clientImpl
+	hc        *http.Client
+	raw       *compute.Service
+	rawBeta   *computeBeta.Service
+	rawAlpha  *computeAlpha.Service
+	validate  bool
+	userAgent string
+
+	requestTimeout   time.Duration
+	retryPolicy      RetryPolicy
+	concurrencyLimit int
+	opPollers        *operationPollers
+	waitConfig       *WaitConfig
+	recorder         io.Writer
+	clock            Clock
+	opCache          *operationCache
+	defaultProject   string
+	regionalQPS      float64
+	skipReadback     bool
+}
+
+// Option configures optional client behavior.
+type Option func(*client)
+
+// WithValidation makes the client check requests against the API before
+// issuing them, so that some invalid requests fail before an operation is
+// started. For example, CreateInstance checks that the requested accelerator
+// types are available in the zone and that confidential VMs use a supported
+// machine type, as do instances requesting Tier_1 networking, which must also
+// use gVNIC. BulkInsertInstance checks that its source instance template
+// exists. CreateDiskAlpha and CreateDiskBeta check that multi-writer disks use
+// a disk type that supports it.
+// CreateInstance, CreateDisk, CreateImage and CreateSnapshot also check labels
+// with ValidateLabels, and CreateDisk checks that provisioned IOPS and
+// throughput are only set for disk types that support them.
+func WithValidation() Option {
+	return func(c *client) {
+		c.validate = true
+	}
+}
+
+// WithSkipReadback makes create methods such as CreateInstance and CreateDisk
+// return as soon as the create operation is done, without reading the
+// resource back with the matching Get method. The resource passed in is then
+// not updated with fields set by the API, such as its self link. This saves
+// one API call per create for callers that only need to know that the create
+// succeeded. CreateInstanceAndGet and CreateInstanceIfNotExists always read
+// the instance back.
+func WithSkipReadback() Option {
+	return func(c *client) {
+		c.skipReadback = true
+	}
+}
+
+// WithUserAgent appends ua to the User-Agent header sent with every request,
+// so that API usage can be attributed to the calling tool.
+func WithUserAgent(ua string) Option {
+	return func(c *client) {
+		c.userAgent = ua
+	}
 }
 
-// shouldRetryWithWait returns true if the HTTP response / error indicates
-// that the request should be attempted again.
+// shouldRetryWithWait is shouldRetryWithClock using the real clock.
 func shouldRetryWithWait(tripper http.RoundTripper, err error, multiplier int) bool {
+	return shouldRetryWithClock(realClock{}, tripper, err, multiplier)
+}
+
+// shouldRetry is shouldRetryWithWait using the client's transport and clock.
+func (c *client) shouldRetry(err error, multiplier int) bool {
+	return shouldRetryWithClock(c.clock, c.hc.Transport, err, multiplier)
+}
+
+// shouldRetryWithClock returns true if the HTTP response / error indicates
+// that the request should be attempted again, after sleeping on clk for the
+// retry backoff.
+func shouldRetryWithClock(clk Clock, tripper http.RoundTripper, err error, multiplier int) bool {
+	sleep, retry := retryBackoff(clk, tripper, err, multiplier)
+	if retry {
+		clk.Sleep(sleep)
+	}
+	return retry
+}
+
+// retryBackoff reports whether the HTTP response / error indicates that the
+// request should be attempted again, and how long to wait before doing so.
+func retryBackoff(clk Clock, tripper http.RoundTripper, err error, multiplier int) (time.Duration, bool) {
 	if err == nil {
-		return false
+		return 0, false
 	}
 	tkValid := true
 	trans, ok := tripper.(*oauth2.Transport)
@@ -264,13 +618,15 @@ func shouldRetryWithWait(tripper http.RoundTripper, err error, multiplier int) b
 	switch {
 	case !ok && (strings.Contains(err.Error(), "connection reset by peer") || strings.Contains(err.Error(), "unexpected EOF")):
 		retry = true
+	case !ok && errors.Is(err, errRequestTimeout):
+		retry = true
 	case !ok && (strings.Contains(err.Error(), "server sent GOAWAY") || strings.Contains(err.Error(), "ENHANCE_YOUR_CALM")):
 		// The wait operation can return GOAWAY/ENHANCE_YOUR_CALM messages, so doubling the wait multiplier as it based on the retry count.
 		multiplier = multiplier * 2
 		retry = true
 	case !ok && tkValid:
 		// Not a googleapi.Error and the token is still valid.
-		return false
+		return 0, false
 	case apiErr.Code >= 500 && apiErr.Code <= 599:
 		retry = true
 	case apiErr.Code >= 429:
@@ -280,21 +636,82 @@ func shouldRetryWithWait(tripper http.RoundTripper, err error, multiplier int) b
 		// Quota errors are reported as 403.
 		// Generally we don't want to retry on quota errors, but if it's quota on rate (GetSerialPortOutput) - we should.
 		retry = true
+	case apiErr.Code == http.StatusConflict && hasTransientConflictReason(apiErr):
+		// The resource is busy with another operation rather than in true
+		// conflict, e.g. already existing.
+		retry = true
 	case !tkValid:
 		// This was probably a failure to get new token from metadata server.
 		retry = true
 	}
 	if !retry {
-		return false
+		return 0, false
 	}
 
 	sleep := (time.Duration(rand.Intn(1000))*time.Millisecond + 1*time.Second) * time.Duration(multiplier)
-	time.Sleep(sleep)
-	return true
+	if ok {
+		if ra := retryAfter(apiErr, clk.Now()); ra > sleep {
+			sleep = ra
+		}
+	}
+	return sleep, true
+}
+
+// transientConflictReasons are the error reasons, normalized by
+// normalizeReason, of 409 errors that may succeed when retried.
+var transientConflictReasons = map[string]bool{"resourcenotready": true, "operationinprogress": true}
+
+// hasTransientConflictReason reports whether any of the reasons given in an
+// API error marks it as a transient conflict.
+func hasTransientConflictReason(apiErr *googleapi.Error) bool {
+	for _, e := range apiErr.Errors {
+		if transientConflictReasons[normalizeReason(e.Reason)] {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeReason lowercases an error reason and drops underscores, so that
+// "RESOURCE_NOT_READY" and "resourceNotReady" compare equal.
+func normalizeReason(r string) string {
+	return strings.ToLower(strings.ReplaceAll(r, "_", ""))
+}
+
+// maxRetryAfter caps how long a server-directed Retry-After delay is honored.
+const maxRetryAfter = 5 * time.Minute
+
+// retryAfter returns the delay requested by the Retry-After header of an API
+// error, given either in seconds or as an HTTP date, or 0 if there is none.
+func retryAfter(apiErr *googleapi.Error, now time.Time) time.Duration {
+	v := apiErr.Header.Get("Retry-After")
+	if v == "" {
+		return 0
+	}
+	var d time.Duration
+	if secs, err := strconv.Atoi(v); err == nil {
+		d = time.Duration(secs) * time.Second
+	} else if t, err := http.ParseTime(v); err == nil {
+		d = t.Sub(now)
+	}
+	if d < 0 {
+		return 0
+	}
+	if d > maxRetryAfter {
+		return maxRetryAfter
+	}
+	return d
 }
 
 // NewClient creates a new Google Cloud Compute client.
 func NewClient(ctx context.Context, opts ...option.ClientOption) (Client, error) {
+	return NewClientWithOptions(ctx, opts)
+}
+
+// NewClientWithOptions creates a new Google Cloud Compute client using the
+// given API client options and optional client behaviors.
+func NewClientWithOptions(ctx context.Context, apiOpts []option.ClientOption, clientOpts ...Option) (Client, error) {
+	opts := apiOpts
 	// Set these scopes to be align with compute.NewService
 	o := []option.ClientOption{
 		option.WithScopes(
@@ -311,6 +728,15 @@ func NewClient(ctx context.Context, opts ...option.ClientOption) (Client, error)
 	if err != nil {
 		return nil, fmt.Errorf("error creating HTTP API client: %v", err)
 	}
+
+	c := &client{hc: hc, clock: realClock{}, opCache: newOperationCache()}
+	for _, opt := range clientOpts {
+		opt(c)
+	}
+	// The services use a copy of the HTTP client so that a client passed in
+	// with option.WithHTTPClient is never modified.
+	hc = c.wrapHTTPClient(hc)
+
 	rawService, err := compute.New(hc)
 	if err != nil {
 		return nil, fmt.Errorf("compute client: %v", err)
@@ -333,7 +759,11 @@ func NewClient(ctx context.Context, opts ...option.ClientOption) (Client, error)
 		rawAlphaService.BasePath = ep
 	}
 
-	c := &client{hc: hc, raw: rawService, rawBeta: rawBetaService, rawAlpha: rawAlphaService}
+	rawService.UserAgent = c.userAgent
+	rawBetaService.UserAgent = c.userAgent
+	rawAlphaService.UserAgent = c.userAgent
+
+	c.raw, c.rawBeta, c.rawAlpha = rawService, rawBetaService, rawAlphaService
 	c.i = c
 
 	return c, nil
@@ -344,11 +774,46 @@ func (c *client) BasePath() string {
 	return c.raw.BasePath
 }
 
+// IsNotFound reports whether err is a GCE API error with a 404 status code.
+func IsNotFound(err error) bool {
+	var apiErr *googleapi.Error
+	return errors.As(err, &apiErr) && apiErr.Code == http.StatusNotFound
+}
+
+// wrapResourceError annotates *err, if set, with the action that failed and
+// the path of the resource it failed for, e.g.
+// "create disk my-project/us-central1-a/my-disk: <err>". The original error is
+// wrapped, so errors.Is, errors.As and IsNotFound still see it. It is used by
+// the methods that act on a single named resource, in the v1, Beta and Alpha
+// APIs alike, including their operation waits; list methods and helpers that
+// span several resources return the errors of the calls they make.
+func wrapResourceError(err *error, action string, path ...string) {
+	if *err != nil {
+		*err = fmt.Errorf("%s %s: %w", action, strings.Join(path, "/"), *err)
+	}
+}
+
 type operationGetterFunc func() (*compute.Operation, error)
 
 func (c *client) zoneOperationsWait(project, zone, name string) error {
-	return c.operationsWaitHelper(project, name, func() (op *compute.Operation, err error) {
-		op, err = c.Retry(c.raw.ZoneOperations.Wait(project, zone, name).Do)
+	get := func() (*compute.Operation, error) {
+		return c.opCache.get(fmt.Sprintf("projects/%s/zones/%s/operations/%s", project, zone, name), c.clock, func() (*compute.Operation, error) {
+			return c.Retry(c.raw.ZoneOperations.Wait(project, zone, name).Do)
+		})
+	}
+	interval := operationPollInterval
+	if c.opPollers != nil {
+		p := c.opPollers.poller(project+"/zones/"+zone, c.clock, func(filter string) ([]*compute.Operation, error) {
+			return c.listOperations(0, func(pt string) (*compute.OperationList, error) {
+				return c.raw.ZoneOperations.List(project, zone).Filter(filter).PageToken(pt).Do()
+			})
+		})
+		get = func() (*compute.Operation, error) { return p.get(name) }
+		// The poller waits its own interval before each list.
+		interval = 0
+	}
+	return c.operationsWaitHelper(project, name, interval, func() (op *compute.Operation, err error) {
+		op, err = get()
 		if err != nil {
 			err = fmt.Errorf("failed to get zone operation %s: %v", name, err)
 		}
@@ -357,8 +822,24 @@ func (c *client) zoneOperationsWait(project, zone, name string) error {
 }
 
 func (c *client) regionOperationsWait(project, region, name string) error {
-	return c.operationsWaitHelper(project, name, func() (op *compute.Operation, err error) {
-		op, err = c.Retry(c.raw.RegionOperations.Wait(project, region, name).Do)
+	get := func() (*compute.Operation, error) {
+		return c.opCache.get(fmt.Sprintf("projects/%s/regions/%s/operations/%s", project, region, name), c.clock, func() (*compute.Operation, error) {
+			return c.Retry(c.raw.RegionOperations.Wait(project, region, name).Do)
+		})
+	}
+	interval := operationPollInterval
+	if c.opPollers != nil {
+		p := c.opPollers.poller(project+"/regions/"+region, c.clock, func(filter string) ([]*compute.Operation, error) {
+			return c.listOperations(0, func(pt string) (*compute.OperationList, error) {
+				return c.raw.RegionOperations.List(project, region).Filter(filter).PageToken(pt).Do()
+			})
+		})
+		get = func() (*compute.Operation, error) { return p.get(name) }
+		// The poller waits its own interval before each list.
+		interval = 0
+	}
+	return c.operationsWaitHelper(project, name, interval, func() (op *compute.Operation, err error) {
+		op, err = get()
 		if err != nil {
 			err = fmt.Errorf("failed to get region operation %s: %v", name, err)
 		}
@@ -367,8 +848,24 @@ func (c *client) regionOperationsWait(project, region, name string) error {
 }
 
 func (c *client) globalOperationsWait(project, name string) error {
-	return c.operationsWaitHelper(project, name, func() (op *compute.Operation, err error) {
-		op, err = c.Retry(c.raw.GlobalOperations.Wait(project, name).Do)
+	get := func() (*compute.Operation, error) {
+		return c.opCache.get(fmt.Sprintf("projects/%s/global/operations/%s", project, name), c.clock, func() (*compute.Operation, error) {
+			return c.Retry(c.raw.GlobalOperations.Wait(project, name).Do)
+		})
+	}
+	interval := operationPollInterval
+	if c.opPollers != nil {
+		p := c.opPollers.poller(project+"/global", c.clock, func(filter string) ([]*compute.Operation, error) {
+			return c.listOperations(0, func(pt string) (*compute.OperationList, error) {
+				return c.raw.GlobalOperations.List(project).Filter(filter).PageToken(pt).Do()
+			})
+		})
+		get = func() (*compute.Operation, error) { return p.get(name) }
+		// The poller waits its own interval before each list.
+		interval = 0
+	}
+	return c.operationsWaitHelper(project, name, interval, func() (op *compute.Operation, err error) {
+		op, err = get()
 		if err != nil {
 			err = fmt.Errorf("failed to get global operation %s: %v", name, err)
 		}
@@ -376,31 +873,247 @@ func (c *client) globalOperationsWait(project, name string) error {
 	})
 }
 
+// organizationOperationsWait waits on an operation of an organization level
+// resource, such as a hierarchical firewall policy. These operations have no
+// Wait method and are polled with Get instead.
+func (c *client) organizationOperationsWait(name string) error {
+	if name == "" {
+		return errors.New("cannot wait on organization operation: operation name is empty")
+	}
+	return c.operationsWaitProgressHelper(operationPollInterval, c.waitConfig, func() (*compute.Operation, error) {
+		op, err := c.Retry(c.raw.GlobalOrganizationOperations.Get(name).Do)
+		if err != nil {
+			err = fmt.Errorf("failed to get organization operation %s: %v", name, err)
+		}
+		return op, err
+	}, nil)
+}
+
+// toOperation converts an Alpha or Beta API operation to a GA API operation so
+// that the same wait logic applies to all API versions.
+func toOperation(op interface{}) (*compute.Operation, error) {
+	b, err := json.Marshal(op)
+	if err != nil {
+		return nil, err
+	}
+	var gaOp compute.Operation
+	if err := json.Unmarshal(b, &gaOp); err != nil {
+		return nil, err
+	}
+	return &gaOp, nil
+}
+
+// zoneOperationsWaitBeta waits on a zone operation using the Beta API, for
+// operations started with the Beta API.
+func (c *client) zoneOperationsWaitBeta(project, zone, name string) error {
+	return c.operationsWaitHelper(project, name, operationPollInterval, func() (*compute.Operation, error) {
+		op, err := c.RetryBeta(c.rawBeta.ZoneOperations.Wait(project, zone, name).Do)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get zone operation %s: %v", name, err)
+		}
+		return toOperation(op)
+	})
+}
+
+// globalOperationsWaitBeta waits on a global operation using the Beta API,
+// for operations started with the Beta API.
+func (c *client) globalOperationsWaitBeta(project, name string) error {
+	return c.operationsWaitHelper(project, name, operationPollInterval, func() (*compute.Operation, error) {
+		op, err := c.RetryBeta(c.rawBeta.GlobalOperations.Wait(project, name).Do)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get global operation %s: %v", name, err)
+		}
+		return toOperation(op)
+	})
+}
+
+// zoneOperationsWaitAlpha waits on a zone operation using the Alpha API, for
+// operations started with the Alpha API.
+func (c *client) zoneOperationsWaitAlpha(project, zone, name string) error {
+	return c.operationsWaitHelper(project, name, operationPollInterval, func() (*compute.Operation, error) {
+		op, err := c.RetryAlpha(c.rawAlpha.ZoneOperations.Wait(project, zone, name).Do)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get zone operation %s: %v", name, err)
+		}
+		return toOperation(op)
+	})
+}
+
+// globalOperationsWaitAlpha waits on a global operation using the Alpha API,
+// for operations started with the Alpha API.
+func (c *client) globalOperationsWaitAlpha(project, name string) error {
+	return c.operationsWaitHelper(project, name, operationPollInterval, func() (*compute.Operation, error) {
+		op, err := c.RetryAlpha(c.rawAlpha.GlobalOperations.Wait(project, name).Do)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get global operation %s: %v", name, err)
+		}
+		return toOperation(op)
+	})
+}
+
+// listOperations gets all pages of an operations list call.
+func (c *client) listOperations(limit int, listPage func(pt string) (*compute.OperationList, error)) ([]*compute.Operation, error) {
+	var ops []*compute.Operation
+	var pt string
+	for {
+		ol, err := listPage(pt)
+		if c.shouldRetry(err, 2) {
+			ol, err = listPage(pt)
+		}
+		if err != nil {
+			return nil, err
+		}
+		ops = append(ops, ol.Items...)
+
+		if limit > 0 && len(ops) >= limit {
+			return ops[:limit], nil
+		}
+		if ol.NextPageToken == "" {
+			return ops, nil
+		}
+		pt = ol.NextPageToken
+	}
+}
+
+// ListZoneOperations gets a list of GCE zone operations, for example those
+// still running with Filter("status = RUNNING").
+func (c *client) ListZoneOperations(project, zone string, opts ...ListCallOption) ([]*compute.Operation, error) {
+	call := c.raw.ZoneOperations.List(project, zone)
+	for _, opt := range opts {
+		call = opt.listCallOptionApply(call).(*compute.ZoneOperationsListCall)
+	}
+	limit := listLimit(opts)
+	return c.listOperations(limit, func(pt string) (*compute.OperationList, error) {
+		return call.PageToken(pt).Do()
+	})
+}
+
+// ListRegionOperations gets a list of GCE region operations.
+func (c *client) ListRegionOperations(project, region string, opts ...ListCallOption) ([]*compute.Operation, error) {
+	call := c.raw.RegionOperations.List(project, region)
+	for _, opt := range opts {
+		call = opt.listCallOptionApply(call).(*compute.RegionOperationsListCall)
+	}
+	limit := listLimit(opts)
+	return c.listOperations(limit, func(pt string) (*compute.OperationList, error) {
+		return call.PageToken(pt).Do()
+	})
+}
+
+// ListGlobalOperations gets a list of GCE global operations.
+func (c *client) ListGlobalOperations(project string, opts ...ListCallOption) ([]*compute.Operation, error) {
+	call := c.raw.GlobalOperations.List(project)
+	for _, opt := range opts {
+		call = opt.listCallOptionApply(call).(*compute.GlobalOperationsListCall)
+	}
+	limit := listLimit(opts)
+	return c.listOperations(limit, func(pt string) (*compute.OperationList, error) {
+		return call.PageToken(pt).Do()
+	})
+}
+
+// GetOperationsForTarget gets the operations on the resource given by
+// targetSelfLink, a full or partial URL, such as its insert, reset and delete.
+// scope is where the operations live: "zones/<zone>", "regions/<region>" or
+// "global".
+func (c *client) GetOperationsForTarget(project, scope, targetSelfLink string) ([]*compute.Operation, error) {
+	target := targetSelfLink
+	if i := strings.Index(target, "projects/"); i != -1 {
+		target = target[i:]
+	}
+	filter := Filter(fmt.Sprintf(`targetLink eq ".*/%s"`, regexp.QuoteMeta(target)))
+
+	kind, name, _ := strings.Cut(scope, "/")
+	switch {
+	case kind == "zones" && name != "":
+		return c.i.ListZoneOperations(project, name, filter)
+	case kind == "regions" && name != "":
+		return c.i.ListRegionOperations(project, name, filter)
+	case scope == "global":
+		return c.i.ListGlobalOperations(project, filter)
+	}
+	return nil, fmt.Errorf("invalid operation scope %q, want \"zones/<zone>\", \"regions/<region>\" or \"global\"", scope)
+}
+
 // OperationErrorCodeFormat is the format of operation error code.
 var OperationErrorCodeFormat = "Code: %s"
 
 var operationErrorMessageFormat = "Message: %s"
 
-func (c *client) operationsWaitHelper(project, name string, getOperation operationGetterFunc) error {
+// OperationError is returned when a GCE operation completes with errors.
+type OperationError struct {
+	Op *compute.Operation
+}
+
+func (e *OperationError) Error() string {
+	var operrs string
+	for _, operr := range e.Errors() {
+		operrs = operrs + fmt.Sprintf(
+			fmt.Sprintf("\n%v\n%v", OperationErrorCodeFormat, operationErrorMessageFormat),
+			operr.Code, operr.Message)
+	}
+	return fmt.Sprintf("operation failed %+v: %s", e.Op, operrs)
+}
+
+// Errors returns the structured errors reported by the operation.
+func (e *OperationError) Errors() []*compute.OperationErrorErrors {
+	if e.Op == nil || e.Op.Error == nil {
+		return nil
+	}
+	return e.Op.Error.Errors
+}
+
+// HasCode reports whether any of the operation errors has the given code,
+// e.g. ZONE_RESOURCE_POOL_EXHAUSTED.
+func (e *OperationError) HasCode(code string) bool {
+	for _, operr := range e.Errors() {
+		if operr.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+// operationPollInterval is how long waits sleep between polls of an operation.
+const operationPollInterval = 1 * time.Second
+
+func (c *client) operationsWaitHelper(project, name string, interval time.Duration, getOperation operationGetterFunc) error {
+	if name == "" {
+		return fmt.Errorf("cannot wait on operation in project %q: operation name is empty", project)
+	}
+	return c.operationsWaitProgressHelper(interval, c.waitConfig, getOperation, nil)
+}
+
+// operationsWaitProgressHelper polls an operation until it is done, sleeping
+// for interval between polls and passing its progress to onProgress, if set,
+// after each poll. An interval of 0 is for getters that already block until
+// the next poll. If wc is set, it gives up once the wc timeout for the
+// resource the operation acts on has passed.
+func (c *client) operationsWaitProgressHelper(interval time.Duration, wc *WaitConfig, getOperation operationGetterFunc, onProgress func(percent int)) error {
+	start := c.clock.Now()
 	for {
 		op, err := getOperation()
 		if err != nil {
 			return err
 		}
+		if onProgress != nil {
+			onProgress(int(op.Progress))
+		}
 
 		switch op.Status {
-		case "PENDING", "RUNNING":
-			time.Sleep(1 * time.Second)
+		case OpStatusPending, OpStatusRunning:
+			if wc != nil {
+				if timeout := wc.timeout(op.TargetLink); timeout > 0 && c.clock.Now().Sub(start) >= timeout {
+					return fmt.Errorf("operation %s on %s not done after %s, last status %q", op.Name, op.TargetLink, timeout, op.Status)
+				}
+			}
+			if interval > 0 {
+				c.clock.Sleep(interval)
+			}
 			continue
-		case "DONE":
+		case OpStatusDone:
 			if op.Error != nil {
-				var operrs string
-				for _, operr := range op.Error.Errors {
-					operrs = operrs + fmt.Sprintf(
-						fmt.Sprintf("\n%v\n%v", OperationErrorCodeFormat, operationErrorMessageFormat),
-						operr.Code, operr.Message)
-				}
-				return fmt.Errorf("operation failed %+v: %s", op, operrs)
+				return &OperationError{Op: op}
 			}
 		default:
 			return fmt.Errorf("unknown operation status %q: %+v", op.Status, op)
@@ -409,6 +1122,80 @@ func (c *client) operationsWaitHelper(project, name string, getOperation operati
 	}
 }
 
+var operationSelfLinkRegex = regexp.MustCompile(`projects/[^/]+/(?:zones/([^/]+)|regions/([^/]+)|global)/operations/([^/]+)$`)
+
+// WaitForOperationWithProgress waits for the zonal, regional or global
+// operation given by selfLink to finish, calling onProgress with the
+// operation's progress percentage after each poll.
+func (c *client) WaitForOperationWithProgress(project, selfLink string, onProgress func(percent int)) error {
+	return c.waitForOperation(project, selfLink, c.waitConfig, onProgress)
+}
+
+// WaitForOperationWithConfig waits for the zonal, regional or global
+// operation given by selfLink to finish, using the timeouts in wc instead of
+// those the client was created with.
+func (c *client) WaitForOperationWithConfig(project, selfLink string, wc WaitConfig) error {
+	return c.waitForOperation(project, selfLink, &wc, nil)
+}
+
+func (c *client) waitForOperation(project, selfLink string, wc *WaitConfig, onProgress func(percent int)) error {
+	m := operationSelfLinkRegex.FindStringSubmatch(selfLink)
+	if m == nil {
+		return fmt.Errorf("invalid operation self link %q", selfLink)
+	}
+	zone, region, name := m[1], m[2], m[3]
+
+	var key string
+	var get func() (*compute.Operation, error)
+	switch {
+	case zone != "":
+		key = fmt.Sprintf("projects/%s/zones/%s/operations/%s", project, zone, name)
+		get = func() (*compute.Operation, error) {
+			return c.Retry(c.raw.ZoneOperations.Get(project, zone, name).Do)
+		}
+	case region != "":
+		key = fmt.Sprintf("projects/%s/regions/%s/operations/%s", project, region, name)
+		get = func() (*compute.Operation, error) {
+			return c.Retry(c.raw.RegionOperations.Get(project, region, name).Do)
+		}
+	default:
+		key = fmt.Sprintf("projects/%s/global/operations/%s", project, name)
+		get = func() (*compute.Operation, error) {
+			return c.Retry(c.raw.GlobalOperations.Get(project, name).Do)
+		}
+	}
+	return c.operationsWaitProgressHelper(operationPollInterval, wc, func() (op *compute.Operation, err error) {
+		op, err = c.opCache.get(key, c.clock, get)
+		if err != nil {
+			err = fmt.Errorf("failed to get operation %s: %v", name, err)
+		}
+		return op, err
+	}, onProgress)
+}
+
+// DeleteOperation deletes the zonal, regional or global operation resource
+// given by selfLink. The Compute API has no way to cancel an operation, so
+// this does not stop the work the operation is doing; it only removes the
+// operation from the operations list.
+func (c *client) DeleteOperation(project, selfLink string) error {
+	m := operationSelfLinkRegex.FindStringSubmatch(selfLink)
+	if m == nil {
+		return fmt.Errorf("invalid operation self link %q", selfLink)
+	}
+	zone, region, name := m[1], m[2], m[3]
+
+	var err error
+	switch {
+	case zone != "":
+		err = c.raw.ZoneOperations.Delete(project, zone, name).Do()
+	case region != "":
+		err = c.raw.RegionOperations.Delete(project, region, name).Do()
+	default:
+		err = c.raw.GlobalOperations.Delete(project, name).Do()
+	}
+	return err
+}
+
 // Retry invokes the given function, retrying it multiple times if the HTTP
 // status response indicates the request should be attempted again or the
 // oauth Token is no longer valid.
@@ -418,7 +1205,32 @@ func (c *client) Retry(f func(opts ...googleapi.CallOption) (*compute.Operation,
 		if err == nil {
 			return op, nil
 		}
-		if !shouldRetryWithWait(c.hc.Transport, err, i) {
+		if !c.shouldRetry(err, i) {
+			return nil, err
+		}
+	}
+	return
+}
+
+// RetryContext is Retry, but stops retrying once ctx is done. If ctx is
+// cancelled during the backoff between attempts, RetryContext returns
+// ctx.Err() right away rather than finishing the backoff. ctx is not attached
+// to the requests f makes, so a call already in flight is not cancelled; pass
+// ctx to the call itself, for example with call.Context(ctx), for that.
+func (c *client) RetryContext(ctx context.Context, f func(opts ...googleapi.CallOption) (*compute.Operation, error), opts ...googleapi.CallOption) (op *compute.Operation, err error) {
+	for i := 1; i < 4; i++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		op, err = f(opts...)
+		if err == nil {
+			return op, nil
+		}
+		sleep, retry := retryBackoff(c.clock, c.hc.Transport, err, i)
+		if !retry {
+			return nil, err
+		}
+		if err := sleepContext(ctx, c.clock, sleep); err != nil {
 			return nil, err
 		}
 	}
@@ -434,7 +1246,7 @@ func (c *client) RetryBeta(f func(opts ...googleapi.CallOption) (*computeBeta.Op
 		if err == nil {
 			return op, nil
 		}
-		if !shouldRetryWithWait(c.hc.Transport, err, i) {
+		if !c.shouldRetry(err, i) {
 			return nil, err
 		}
 	}
@@ -450,7 +1262,7 @@ func (c *client) RetryAlpha(f func(opts ...googleapi.CallOption) (*computeAlpha.
 		if err == nil {
 			return op, nil
 		}
-		if !shouldRetryWithWait(c.hc.Transport, err, i) {
+		if !c.shouldRetry(err, i) {
 			return nil, err
 		}
 	}
@@ -477,47 +1289,95 @@ func (c *client) DetachDisk(project, zone, instance, disk string) error {
 	return c.i.zoneOperationsWait(project, zone, op.Name)
 }
 
-// CreateDisk creates a GCE persistent disk.
-func (c *client) CreateDisk(project, zone string, d *compute.Disk) error {
-	op, err := c.Retry(c.raw.Disks.Insert(project, zone, d).Do)
+var snapshotSelfLinkRegex = regexp.MustCompile(`projects/([^/]+)/global/snapshots/([^/]+)$`)
+
+// CreateDiskFromSnapshot creates a GCE persistent disk restored from the
+// snapshot given by snapshotSelfLink, which may be a full or partial URL. A
+// sizeGb of 0 keeps the size of the snapshot's source disk; a smaller size is
+// an error. diskType may be a disk type name, a URL or empty for the default.
+func (c *client) CreateDiskFromSnapshot(project, zone, diskName, snapshotSelfLink string, sizeGb int64, diskType string) error {
+	m := snapshotSelfLinkRegex.FindStringSubmatch(snapshotSelfLink)
+	if m == nil {
+		return fmt.Errorf("invalid snapshot URL %q", snapshotSelfLink)
+	}
+	s, err := c.i.GetSnapshot(m[1], m[2])
 	if err != nil {
 		return err
 	}
-
-	if err := c.i.zoneOperationsWait(project, zone, op.Name); err != nil {
-		return err
+	if sizeGb != 0 && sizeGb < s.DiskSizeGb {
+		return fmt.Errorf("cannot create disk %q of %dGB from snapshot %q of a %dGB disk: disks cannot be smaller than their snapshot", diskName, sizeGb, snapshotSelfLink, s.DiskSizeGb)
 	}
-
-	var createdDisk *compute.Disk
-	if createdDisk, err = c.i.GetDisk(project, zone, d.Name); err != nil {
-		return err
+	if diskType != "" && !strings.Contains(diskType, "/") {
+		diskType = DiskTypeURL(project, zone, diskType)
 	}
-	*d = *createdDisk
-	return nil
+	return c.i.CreateDisk(project, zone, &compute.Disk{
+		Name:           diskName,
+		SourceSnapshot: snapshotSelfLink,
+		SizeGb:         sizeGb,
+		Type:           diskType,
+	})
 }
 
-// CreateDiskAlpha creates a GCE persistent disk.
-func (c *client) CreateDiskAlpha(project, zone string, d *computeAlpha.Disk) error {
-	op, err := c.RetryAlpha(c.rawAlpha.Disks.Insert(project, zone, d).Do)
+// CloneDiskReencrypt creates diskName as a clone of sourceDisk in the same
+// zone, encrypted with key, e.g. from CMEK. A source disk encrypted with a
+// Cloud KMS key must be given a new key, and disks encrypted with a
+// customer-supplied key cannot be cloned.
+func (c *client) CloneDiskReencrypt(project, zone, sourceDisk, diskName string, key *compute.CustomerEncryptionKey) error {
+	src, err := c.i.GetDisk(project, zone, sourceDisk)
 	if err != nil {
 		return err
 	}
+	if sk := src.DiskEncryptionKey; sk != nil {
+		if sk.KmsKeyName == "" {
+			return fmt.Errorf("cannot clone disk %q: disks encrypted with a customer-supplied key cannot be cloned", sourceDisk)
+		}
+		if isEmptyEncryptionKey(key) {
+			return fmt.Errorf("cannot clone disk %q encrypted with Cloud KMS key %q: no encryption key given for disk %q", sourceDisk, sk.KmsKeyName, diskName)
+		}
+	}
+	return c.i.CreateDisk(project, zone, &compute.Disk{
+		Name:              diskName,
+		SourceDisk:        src.SelfLink,
+		DiskEncryptionKey: key,
+	})
+}
 
-	if err := c.i.zoneOperationsWait(project, zone, op.Name); err != nil {
+func isEmptyEncryptionKey(k *compute.CustomerEncryptionKey) bool {
+	return k == nil || k.KmsKeyName == "" && k.RawKey == "" && k.RsaEncryptedKey == ""
+}
+
+// CreateDiskFromImageAndAttach creates a GCE persistent disk from an image
+// and attaches it to an instance, using the disk name as the device name. The
+// disk is deleted again if it cannot be attached.
+func (c *client) CreateDiskFromImageAndAttach(project, zone, instance, image string, d *compute.Disk) error {
+	d.SourceImage = image
+	if err := c.i.CreateDisk(project, zone, d); err != nil {
 		return err
 	}
 
-	var createdDisk *computeAlpha.Disk
-	if createdDisk, err = c.i.GetDiskAlpha(project, zone, d.Name); err != nil {
+	source := d.SelfLink
+	if source == "" {
+		source = fmt.Sprintf("projects/%s/zones/%s/disks/%s", project, zone, d.Name)
+	}
+	ad := &compute.AttachedDisk{Source: source, DeviceName: d.Name}
+	if err := c.i.AttachDisk(project, zone, instance, ad); err != nil {
+		if dErr := c.i.DeleteDisk(project, zone, d.Name); dErr != nil {
+			return fmt.Errorf("error attaching disk %q: %v, error deleting disk: %v", d.Name, err, dErr)
+		}
 		return err
 	}
-	*d = *createdDisk
 	return nil
 }
 
-// CreateDiskBeta creates a GCE persistent disk.
-func (c *client) CreateDiskBeta(project, zone string, d *computeBeta.Disk) error {
-	op, err := c.RetryBeta(c.rawBeta.Disks.Insert(project, zone, d).Do)
+// CreateDisk creates a GCE persistent disk.
+func (c *client) CreateDisk(project, zone string, d *compute.Disk) (err error) {
+	defer wrapResourceError(&err, "create disk", project, zone, d.Name)
+	if c.validate {
+		if err := validateDisk(d); err != nil {
+			return err
+		}
+	}
+	op, err := c.Retry(c.raw.Disks.Insert(project, zone, d).Do)
 	if err != nil {
 		return err
 	}
@@ -526,6 +1386,111 @@ func (c *client) CreateDiskBeta(project, zone string, d *computeBeta.Disk) error
 		return err
 	}
 
+	if c.skipReadback {
+		return nil
+	}
+
+	var createdDisk *compute.Disk
+	if createdDisk, err = c.i.GetDisk(project, zone, d.Name); err != nil {
+		return err
+	}
+	*d = *createdDisk
+	return nil
+}
+
+// diskTypeName returns the name of a disk type given by name or URL. An empty
+// type means the default pd-standard.
+func diskTypeName(diskType string) string {
+	dt := diskType
+	if idx := strings.LastIndex(dt, "/"); idx != -1 {
+		dt = dt[idx+1:]
+	}
+	if dt == "" {
+		dt = "pd-standard"
+	}
+	return dt
+}
+
+// validateMultiWriterDiskType checks that a disk type, given by name or URL,
+// supports multi-writer mode.
+func validateMultiWriterDiskType(diskType string) error {
+	if dt := diskTypeName(diskType); dt != "pd-ssd" {
+		return fmt.Errorf("multi-writer disks require disk type %q, got %q", "pd-ssd", dt)
+	}
+	return nil
+}
+
+// validateDisk checks a disk's labels and that provisioned performance is
+// only set for disk types that support it: provisioned IOPS for pd-extreme and
+// hyperdisk types, provisioned throughput for hyperdisk types.
+func validateDisk(d *compute.Disk) error {
+	if err := ValidateLabels(d.Labels); err != nil {
+		return err
+	}
+	dt := diskTypeName(d.Type)
+	hyperdisk := strings.HasPrefix(dt, "hyperdisk-")
+	if d.ProvisionedIops != 0 && !hyperdisk && dt != "pd-extreme" {
+		return fmt.Errorf("disk %q: provisioned IOPS is not supported for disk type %q, only for pd-extreme and hyperdisk types", d.Name, dt)
+	}
+	if d.ProvisionedThroughput != 0 && !hyperdisk {
+		return fmt.Errorf("disk %q: provisioned throughput is not supported for disk type %q, only for hyperdisk types", d.Name, dt)
+	}
+	return nil
+}
+
+// CreateDiskAlpha creates a GCE persistent disk using Alpha API, and waits
+// on the operation using Alpha API. Use it, or CreateDiskBeta, for
+// multi-writer disks, as the v1 API has no multiWriter field.
+func (c *client) CreateDiskAlpha(project, zone string, d *computeAlpha.Disk) (err error) {
+	defer wrapResourceError(&err, "create disk", project, zone, d.Name)
+	if c.validate && d.MultiWriter {
+		if err := validateMultiWriterDiskType(d.Type); err != nil {
+			return err
+		}
+	}
+	op, err := c.RetryAlpha(c.rawAlpha.Disks.Insert(project, zone, d).Do)
+	if err != nil {
+		return err
+	}
+
+	if err := c.i.zoneOperationsWaitAlpha(project, zone, op.Name); err != nil {
+		return err
+	}
+
+	if c.skipReadback {
+		return nil
+	}
+
+	var createdDisk *computeAlpha.Disk
+	if createdDisk, err = c.i.GetDiskAlpha(project, zone, d.Name); err != nil {
+		return err
+	}
+	*d = *createdDisk
+	return nil
+}
+
+// CreateDiskBeta creates a GCE persistent disk using Beta API, and waits on
+// the operation using Beta API.
+func (c *client) CreateDiskBeta(project, zone string, d *computeBeta.Disk) (err error) {
+	defer wrapResourceError(&err, "create disk", project, zone, d.Name)
+	if c.validate && d.MultiWriter {
+		if err := validateMultiWriterDiskType(d.Type); err != nil {
+			return err
+		}
+	}
+	op, err := c.RetryBeta(c.rawBeta.Disks.Insert(project, zone, d).Do)
+	if err != nil {
+		return err
+	}
+
+	if err := c.i.zoneOperationsWaitBeta(project, zone, op.Name); err != nil {
+		return err
+	}
+
+	if c.skipReadback {
+		return nil
+	}
+
 	var createdDisk *computeBeta.Disk
 	if createdDisk, err = c.i.GetDiskBeta(project, zone, d.Name); err != nil {
 		return err
@@ -535,7 +1500,8 @@ func (c *client) CreateDiskBeta(project, zone string, d *computeBeta.Disk) error
 }
 
 // CreateForwardingRule creates a GCE forwarding rule.
-func (c *client) CreateForwardingRule(project, region string, fr *compute.ForwardingRule) error {
+func (c *client) CreateForwardingRule(project, region string, fr *compute.ForwardingRule) (err error) {
+	defer wrapResourceError(&err, "create forwarding rule", project, region, fr.Name)
 	op, err := c.Retry(c.raw.ForwardingRules.Insert(project, region, fr).Do)
 	if err != nil {
 		return err
@@ -545,6 +1511,10 @@ func (c *client) CreateForwardingRule(project, region string, fr *compute.Forwar
 		return err
 	}
 
+	if c.skipReadback {
+		return nil
+	}
+
 	var createdForwardingRule *compute.ForwardingRule
 	if createdForwardingRule, err = c.i.GetForwardingRule(project, region, fr.Name); err != nil {
 		return err
@@ -553,7 +1523,8 @@ func (c *client) CreateForwardingRule(project, region string, fr *compute.Forwar
 	return nil
 }
 
-func (c *client) CreateFirewallRule(project string, i *compute.Firewall) error {
+func (c *client) CreateFirewallRule(project string, i *compute.Firewall) (err error) {
+	defer wrapResourceError(&err, "create firewall rule", project, i.Name)
 	op, err := c.Retry(c.raw.Firewalls.Insert(project, i).Do)
 	if err != nil {
 		return err
@@ -563,6 +1534,10 @@ func (c *client) CreateFirewallRule(project string, i *compute.Firewall) error {
 		return err
 	}
 
+	if c.skipReadback {
+		return nil
+	}
+
 	var createdFirewallRule *compute.Firewall
 	if createdFirewallRule, err = c.i.GetFirewallRule(project, i.Name); err != nil {
 		return err
@@ -571,11 +1546,55 @@ func (c *client) CreateFirewallRule(project string, i *compute.Firewall) error {
 	return nil
 }
 
+// CreateFirewallRuleBeta creates a GCE FirewallRule using Beta API, and waits
+// on the operation using Beta API.
+func (c *client) CreateFirewallRuleBeta(project string, fw *computeBeta.Firewall) (err error) {
+	defer wrapResourceError(&err, "create firewall rule", project, fw.Name)
+	op, err := c.RetryBeta(c.rawBeta.Firewalls.Insert(project, fw).Do)
+	if err != nil {
+		return err
+	}
+
+	if err := c.i.globalOperationsWaitBeta(project, op.Name); err != nil {
+		return err
+	}
+
+	if c.skipReadback {
+		return nil
+	}
+
+	var createdFirewallRule *computeBeta.Firewall
+	if createdFirewallRule, err = c.i.GetFirewallRuleBeta(project, fw.Name); err != nil {
+		return err
+	}
+	*fw = *createdFirewallRule
+	return nil
+}
+
+// PatchFirewallRuleBeta patches a GCE FirewallRule using Beta API, e.g. to
+// change its LogConfig, and waits on the operation using Beta API. Only the
+// fields set in fw are changed. Fields with zero values, such as Disabled set
+// to false, are not sent, and so left unchanged, unless they are named in
+// fw.ForceSendFields.
+func (c *client) PatchFirewallRuleBeta(project, name string, fw *computeBeta.Firewall) error {
+	op, err := c.RetryBeta(c.rawBeta.Firewalls.Patch(project, name, fw).Do)
+	if err != nil {
+		return err
+	}
+	return c.i.globalOperationsWaitBeta(project, op.Name)
+}
+
 // CreateImage creates a GCE image.
 // Only one of sourceDisk or sourceFile must be specified, sourceDisk is the
 // url (full or partial) to the source disk, sourceFile is the full Google
 // Cloud Storage URL where the disk image is stored.
-func (c *client) CreateImage(project string, i *compute.Image) error {
+func (c *client) CreateImage(project string, i *compute.Image) (err error) {
+	defer wrapResourceError(&err, "create image", project, i.Name)
+	if c.validate {
+		if err := ValidateLabels(i.Labels); err != nil {
+			return err
+		}
+	}
 	op, err := c.Retry(c.raw.Images.Insert(project, i).Do)
 	if err != nil {
 		return err
@@ -585,6 +1604,10 @@ func (c *client) CreateImage(project string, i *compute.Image) error {
 		return err
 	}
 
+	if c.skipReadback {
+		return nil
+	}
+
 	var createdImage *compute.Image
 	if createdImage, err = c.i.GetImage(project, i.Name); err != nil {
 		return err
@@ -593,20 +1616,76 @@ func (c *client) CreateImage(project string, i *compute.Image) error {
 	return nil
 }
 
-// CreateImageBeta creates a GCE image using Beta API.
+// CreateImageFromEncryptedDisk creates a GCE image from im.SourceDisk, a zonal
+// or regional disk encrypted with diskKey. The key is set as the image's
+// SourceDiskEncryptionKey; an error is returned if the source disk is
+// encrypted and no key is given, which the API would otherwise reject with a
+// permission error.
+func (c *client) CreateImageFromEncryptedDisk(project string, im *compute.Image, diskKey *compute.CustomerEncryptionKey) error {
+	diskProject, scope, location, resourceType, name, err := ParseResourceURL(im.SourceDisk)
+	if err != nil || resourceType != "disks" || scope == "global" {
+		return fmt.Errorf("invalid source disk %q for image %q", im.SourceDisk, im.Name)
+	}
+	if diskProject == "" {
+		diskProject = project
+	}
+	var d *compute.Disk
+	if scope == "regions" {
+		d, err = c.i.GetRegionDisk(diskProject, location, name)
+	} else {
+		d, err = c.i.GetDisk(diskProject, location, name)
+	}
+	if err != nil {
+		return err
+	}
+	if d.DiskEncryptionKey != nil && isEmptyEncryptionKey(diskKey) {
+		if d.DiskEncryptionKey.KmsKeyName != "" {
+			return fmt.Errorf("cannot create image %q: source disk %q is encrypted with Cloud KMS key %q but no source disk key was given", im.Name, im.SourceDisk, d.DiskEncryptionKey.KmsKeyName)
+		}
+		return fmt.Errorf("cannot create image %q: source disk %q is encrypted with a customer-supplied key but no source disk key was given", im.Name, im.SourceDisk)
+	}
+	im.SourceDiskEncryptionKey = diskKey
+	return c.i.CreateImage(project, im)
+}
+
+// CopyImageToLocation creates dstImage in dstProject from srcImage in
+// srcProject, stored in storageLocations. If the source image cannot be read
+// from dstProject, the returned error says so; the Compute Engine service
+// agent of dstProject then needs roles/compute.imageUser on srcProject.
+func (c *client) CopyImageToLocation(srcProject, srcImage, dstProject, dstImage string, storageLocations []string) error {
+	im := &compute.Image{
+		Name:             dstImage,
+		SourceImage:      fmt.Sprintf("projects/%s/global/images/%s", srcProject, srcImage),
+		StorageLocations: storageLocations,
+	}
+	err := c.i.CreateImage(dstProject, im)
+	var apiErr *googleapi.Error
+	if srcProject != dstProject && errors.As(err, &apiErr) && apiErr.Code == http.StatusForbidden {
+		return fmt.Errorf("cannot read source image %s/%s from project %q, check that it has roles/compute.imageUser on %q: %w", srcProject, srcImage, dstProject, srcProject, err)
+	}
+	return err
+}
+
+// CreateImageBeta creates a GCE image using Beta API, and waits on the
+// operation using Beta API.
 // Only one of sourceDisk or sourceFile must be specified, sourceDisk is the
 // url (full or partial) to the source disk, sourceFile is the full Google
 // Cloud Storage URL where the disk image is stored.
-func (c *client) CreateImageBeta(project string, i *computeBeta.Image) error {
+func (c *client) CreateImageBeta(project string, i *computeBeta.Image) (err error) {
+	defer wrapResourceError(&err, "create image", project, i.Name)
 	op, err := c.RetryBeta(c.rawBeta.Images.Insert(project, i).Do)
 	if err != nil {
 		return err
 	}
 
-	if err := c.i.globalOperationsWait(project, op.Name); err != nil {
+	if err := c.i.globalOperationsWaitBeta(project, op.Name); err != nil {
 		return err
 	}
 
+	if c.skipReadback {
+		return nil
+	}
+
 	var createdImage *computeBeta.Image
 	if createdImage, err = c.i.GetImageBeta(project, i.Name); err != nil {
 		return err
@@ -615,20 +1694,26 @@ func (c *client) CreateImageBeta(project string, i *computeBeta.Image) error {
 	return nil
 }
 
-// CreateImageAlpha creates a GCE image using Alpha API.
+// CreateImageAlpha creates a GCE image using Alpha API, and waits on the
+// operation using Alpha API.
 // Only one of sourceDisk or sourceFile must be specified, sourceDisk is the
 // url (full or partial) to the source disk, sourceFile is the full Google
 // Cloud Storage URL where the disk image is stored.
-func (c *client) CreateImageAlpha(project string, i *computeAlpha.Image) error {
+func (c *client) CreateImageAlpha(project string, i *computeAlpha.Image) (err error) {
+	defer wrapResourceError(&err, "create image", project, i.Name)
 	op, err := c.RetryAlpha(c.rawAlpha.Images.Insert(project, i).Do)
 	if err != nil {
 		return err
 	}
 
-	if err := c.i.globalOperationsWait(project, op.Name); err != nil {
+	if err := c.i.globalOperationsWaitAlpha(project, op.Name); err != nil {
 		return err
 	}
 
+	if c.skipReadback {
+		return nil
+	}
+
 	var createdImage *computeAlpha.Image
 	if createdImage, err = c.i.GetImageAlpha(project, i.Name); err != nil {
 		return err
@@ -638,7 +1723,8 @@ func (c *client) CreateImageAlpha(project string, i *computeAlpha.Image) error {
 }
 
 // DeleteRegionTargetHTTPProxy deletes a GCE RegionTargetHTTPProxy.
-func (c *client) DeleteRegionTargetHTTPProxy(project, region, name string) error {
+func (c *client) DeleteRegionTargetHTTPProxy(project, region, name string) (err error) {
+	defer wrapResourceError(&err, "delete target HTTP proxy", project, region, name)
 	op, err := c.Retry(c.raw.RegionTargetHttpProxies.Delete(project, region, name).Do)
 	if err != nil {
 		return err
@@ -647,7 +1733,8 @@ func (c *client) DeleteRegionTargetHTTPProxy(project, region, name string) error
 }
 
 // CreateRegionTargetHTTPProxy creates a GCE RegionTargetHTTPProxy.
-func (c *client) CreateRegionTargetHTTPProxy(project, region string, p *compute.TargetHttpProxy) error {
+func (c *client) CreateRegionTargetHTTPProxy(project, region string, p *compute.TargetHttpProxy) (err error) {
+	defer wrapResourceError(&err, "create target HTTP proxy", project, region, p.Name)
 	op, err := c.Retry(c.raw.RegionTargetHttpProxies.Insert(project, region, p).Do)
 	if err != nil {
 		return err
@@ -655,6 +1742,10 @@ func (c *client) CreateRegionTargetHTTPProxy(project, region string, p *compute.
 	if err := c.i.regionOperationsWait(project, region, op.Name); err != nil {
 		return err
 	}
+	if c.skipReadback {
+		return nil
+	}
+
 	var createdRegionTargetHTTPProxy *compute.TargetHttpProxy
 	if createdRegionTargetHTTPProxy, err = c.i.GetRegionTargetHTTPProxy(project, region, p.Name); err != nil {
 		return err
@@ -664,9 +1755,10 @@ func (c *client) CreateRegionTargetHTTPProxy(project, region string, p *compute.
 }
 
 // GetRegionTargetHTTPProxy gets a GCE RegionTargetHTTPProxy.
-func (c *client) GetRegionTargetHTTPProxy(project, region, name string) (*compute.TargetHttpProxy, error) {
+func (c *client) GetRegionTargetHTTPProxy(project, region, name string) (_ *compute.TargetHttpProxy, err error) {
+	defer wrapResourceError(&err, "get target HTTP proxy", project, region, name)
 	i, err := c.raw.RegionTargetHttpProxies.Get(project, region, name).Do()
-	if shouldRetryWithWait(c.hc.Transport, err, 2) {
+	if c.shouldRetry(err, 2) {
 		return c.raw.RegionTargetHttpProxies.Get(project, region, name).Do()
 	}
 	return i, err
@@ -680,8 +1772,9 @@ func (c *client) ListRegionTargetHTTPProxies(project, region string, opts ...Lis
 	for _, opt := range opts {
 		call = opt.listCallOptionApply(call).(*compute.RegionTargetHttpProxiesListCall)
 	}
+	limit := listLimit(opts)
 	for il, err := call.PageToken(pt).Do(); ; il, err = call.PageToken(pt).Do() {
-		if shouldRetryWithWait(c.hc.Transport, err, 2) {
+		if c.shouldRetry(err, 2) {
 			il, err = call.PageToken(pt).Do()
 		}
 		if err != nil {
@@ -689,6 +1782,9 @@ func (c *client) ListRegionTargetHTTPProxies(project, region string, opts ...Lis
 		}
 		is = append(is, il.Items...)
 
+		if limit > 0 && len(is) >= limit {
+			return is[:limit], nil
+		}
 		if il.NextPageToken == "" {
 			return is, nil
 		}
@@ -696,201 +1792,757 @@ func (c *client) ListRegionTargetHTTPProxies(project, region string, opts ...Lis
 	}
 }
 
-// DeleteRegionBackendService deletes a GCE RegionBackendService.
-func (c *client) DeleteRegionBackendService(project, region, name string) error {
-	op, err := c.Retry(c.raw.RegionBackendServices.Delete(project, region, name).Do)
+// CreateHTTPHealthCheck creates a legacy GCE HttpHealthCheck, for use with target pools.
+func (c *client) CreateHTTPHealthCheck(project string, hc *compute.HttpHealthCheck) (err error) {
+	defer wrapResourceError(&err, "create HTTP health check", project, hc.Name)
+	op, err := c.Retry(c.raw.HttpHealthChecks.Insert(project, hc).Do)
 	if err != nil {
 		return err
 	}
-	return c.i.regionOperationsWait(project, region, op.Name)
-}
-
-// CreateRegionBackendService creates a GCE RegionBackendService.
-func (c *client) CreateRegionBackendService(project, region string, p *compute.BackendService) error {
-	op, err := c.Retry(c.raw.RegionBackendServices.Insert(project, region, p).Do)
-	if err != nil {
+	if err := c.i.globalOperationsWait(project, op.Name); err != nil {
 		return err
 	}
-	if err := c.i.regionOperationsWait(project, region, op.Name); err != nil {
-		return err
+	if c.skipReadback {
+		return nil
 	}
-	var createdRegionBackendService *compute.BackendService
-	if createdRegionBackendService, err = c.i.GetRegionBackendService(project, region, p.Name); err != nil {
+
+	var createdHttpHealthCheck *compute.HttpHealthCheck
+	if createdHttpHealthCheck, err = c.i.GetHTTPHealthCheck(project, hc.Name); err != nil {
 		return err
 	}
-	*p = *createdRegionBackendService
+	*hc = *createdHttpHealthCheck
 	return nil
 }
 
-// GetRegionBackendService gets a GCE RegionBackendService.
-func (c *client) GetRegionBackendService(project, region, name string) (*compute.BackendService, error) {
-	i, err := c.raw.RegionBackendServices.Get(project, region, name).Do()
-	if shouldRetryWithWait(c.hc.Transport, err, 2) {
-		return c.raw.RegionBackendServices.Get(project, region, name).Do()
-	}
-	return i, err
-}
-
-// ListRegionBackendServices lists GCE RegionBackendServices.
-func (c *client) ListRegionBackendServices(project, region string, opts ...ListCallOption) ([]*compute.BackendService, error) {
-	var is []*compute.BackendService
-	var pt string
-	call := c.raw.RegionBackendServices.List(project, region)
-	for _, opt := range opts {
-		call = opt.listCallOptionApply(call).(*compute.RegionBackendServicesListCall)
-	}
-	for il, err := call.PageToken(pt).Do(); ; il, err = call.PageToken(pt).Do() {
-		if shouldRetryWithWait(c.hc.Transport, err, 2) {
-			il, err = call.PageToken(pt).Do()
-		}
-		if err != nil {
-			return nil, err
-		}
-		is = append(is, il.Items...)
-
-		if il.NextPageToken == "" {
-			return is, nil
-		}
-		pt = il.NextPageToken
+// GetHTTPHealthCheck gets a legacy GCE HttpHealthCheck.
+func (c *client) GetHTTPHealthCheck(project, name string) (_ *compute.HttpHealthCheck, err error) {
+	defer wrapResourceError(&err, "get HTTP health check", project, name)
+	hc, err := c.raw.HttpHealthChecks.Get(project, name).Do()
+	if c.shouldRetry(err, 2) {
+		return c.raw.HttpHealthChecks.Get(project, name).Do()
 	}
+	return hc, err
 }
 
-// DeleteRegionURLMap deletes a GCE RegionURLMap.
-func (c *client) DeleteRegionURLMap(project, region, name string) error {
-	op, err := c.Retry(c.raw.RegionUrlMaps.Delete(project, region, name).Do)
+// DeleteHTTPHealthCheck deletes a legacy GCE HttpHealthCheck.
+func (c *client) DeleteHTTPHealthCheck(project, name string) (err error) {
+	defer wrapResourceError(&err, "delete HTTP health check", project, name)
+	op, err := c.Retry(c.raw.HttpHealthChecks.Delete(project, name).Do)
 	if err != nil {
 		return err
 	}
-	return c.i.regionOperationsWait(project, region, op.Name)
+	return c.i.globalOperationsWait(project, op.Name)
 }
 
-// CreateRegionURLMap creates a GCE RegionURLMap.
-func (c *client) CreateRegionURLMap(project, region string, p *compute.UrlMap) error {
-	op, err := c.Retry(c.raw.RegionUrlMaps.Insert(project, region, p).Do)
+// CreateHTTPSHealthCheck creates a legacy GCE HttpsHealthCheck, for use with target pools.
+func (c *client) CreateHTTPSHealthCheck(project string, hc *compute.HttpsHealthCheck) (err error) {
+	defer wrapResourceError(&err, "create HTTPS health check", project, hc.Name)
+	op, err := c.Retry(c.raw.HttpsHealthChecks.Insert(project, hc).Do)
 	if err != nil {
 		return err
 	}
-	if err := c.i.regionOperationsWait(project, region, op.Name); err != nil {
+	if err := c.i.globalOperationsWait(project, op.Name); err != nil {
 		return err
 	}
-	var createdRegionURLMap *compute.UrlMap
-	if createdRegionURLMap, err = c.i.GetRegionURLMap(project, region, p.Name); err != nil {
-		return err
+	if c.skipReadback {
+		return nil
 	}
-	*p = *createdRegionURLMap
-	return nil
-}
 
-// GetRegionURLMap gets a GCE RegionURLMap.
-func (c *client) GetRegionURLMap(project, region, name string) (*compute.UrlMap, error) {
-	i, err := c.raw.RegionUrlMaps.Get(project, region, name).Do()
-	if shouldRetryWithWait(c.hc.Transport, err, 2) {
-		return c.raw.RegionUrlMaps.Get(project, region, name).Do()
+	var createdHttpsHealthCheck *compute.HttpsHealthCheck
+	if createdHttpsHealthCheck, err = c.i.GetHTTPSHealthCheck(project, hc.Name); err != nil {
+		return err
 	}
-	return i, err
+	*hc = *createdHttpsHealthCheck
+	return nil
 }
 
-// ListRegionURLMaps lists GCE RegionURLMaps.
-func (c *client) ListRegionURLMaps(project, region string, opts ...ListCallOption) ([]*compute.UrlMap, error) {
-	var is []*compute.UrlMap
-	var pt string
-	call := c.raw.RegionUrlMaps.List(project, region)
-	for _, opt := range opts {
-		call = opt.listCallOptionApply(call).(*compute.RegionUrlMapsListCall)
-	}
-	for il, err := call.PageToken(pt).Do(); ; il, err = call.PageToken(pt).Do() {
-		if shouldRetryWithWait(c.hc.Transport, err, 2) {
-			il, err = call.PageToken(pt).Do()
-		}
-		if err != nil {
-			return nil, err
-		}
-		is = append(is, il.Items...)
-
-		if il.NextPageToken == "" {
-			return is, nil
-		}
-		pt = il.NextPageToken
+// GetHTTPSHealthCheck gets a legacy GCE HttpsHealthCheck.
+func (c *client) GetHTTPSHealthCheck(project, name string) (_ *compute.HttpsHealthCheck, err error) {
+	defer wrapResourceError(&err, "get HTTPS health check", project, name)
+	hc, err := c.raw.HttpsHealthChecks.Get(project, name).Do()
+	if c.shouldRetry(err, 2) {
+		return c.raw.HttpsHealthChecks.Get(project, name).Do()
 	}
+	return hc, err
 }
 
-// DeleteRegionHealthCheck deletes a GCE RegionHealthCheck.
-func (c *client) DeleteRegionHealthCheck(project, region, name string) error {
-	op, err := c.Retry(c.raw.RegionHealthChecks.Delete(project, region, name).Do)
+// DeleteHTTPSHealthCheck deletes a legacy GCE HttpsHealthCheck.
+func (c *client) DeleteHTTPSHealthCheck(project, name string) (err error) {
+	defer wrapResourceError(&err, "delete HTTPS health check", project, name)
+	op, err := c.Retry(c.raw.HttpsHealthChecks.Delete(project, name).Do)
 	if err != nil {
 		return err
 	}
-	return c.i.regionOperationsWait(project, region, op.Name)
+	return c.i.globalOperationsWait(project, op.Name)
 }
 
-// CreateRegionHealthCheck creates a GCE RegionHealthCheck.
-func (c *client) CreateRegionHealthCheck(project, region string, p *compute.HealthCheck) error {
-	op, err := c.Retry(c.raw.RegionHealthChecks.Insert(project, region, p).Do)
+// CreateSecurityPolicy creates a Cloud Armor SecurityPolicy.
+func (c *client) CreateSecurityPolicy(project string, sp *compute.SecurityPolicy) (err error) {
+	defer wrapResourceError(&err, "create security policy", project, sp.Name)
+	op, err := c.Retry(c.raw.SecurityPolicies.Insert(project, sp).Do)
 	if err != nil {
 		return err
 	}
-	if err := c.i.regionOperationsWait(project, region, op.Name); err != nil {
+	if err := c.i.globalOperationsWait(project, op.Name); err != nil {
 		return err
 	}
-	var createdRegionHealthCheck *compute.HealthCheck
-	if createdRegionHealthCheck, err = c.i.GetRegionHealthCheck(project, region, p.Name); err != nil {
+	if c.skipReadback {
+		return nil
+	}
+
+	var createdSecurityPolicy *compute.SecurityPolicy
+	if createdSecurityPolicy, err = c.i.GetSecurityPolicy(project, sp.Name); err != nil {
 		return err
 	}
-	*p = *createdRegionHealthCheck
+	*sp = *createdSecurityPolicy
 	return nil
 }
 
-// GetRegionHealthCheck gets a GCE RegionHealthCheck.
-func (c *client) GetRegionHealthCheck(project, region, name string) (*compute.HealthCheck, error) {
-	i, err := c.raw.RegionHealthChecks.Get(project, region, name).Do()
-	if shouldRetryWithWait(c.hc.Transport, err, 2) {
-		return c.raw.RegionHealthChecks.Get(project, region, name).Do()
+// GetSecurityPolicy gets a Cloud Armor SecurityPolicy.
+func (c *client) GetSecurityPolicy(project, name string) (_ *compute.SecurityPolicy, err error) {
+	defer wrapResourceError(&err, "get security policy", project, name)
+	sp, err := c.raw.SecurityPolicies.Get(project, name).Do()
+	if c.shouldRetry(err, 2) {
+		return c.raw.SecurityPolicies.Get(project, name).Do()
 	}
-	return i, err
+	return sp, err
 }
 
-// ListRegionHealthChecks lists GCE RegionHealthChecks.
-func (c *client) ListRegionHealthChecks(project, region string, opts ...ListCallOption) ([]*compute.HealthCheck, error) {
-	var is []*compute.HealthCheck
-	var pt string
-	call := c.raw.RegionHealthChecks.List(project, region)
-	for _, opt := range opts {
-		call = opt.listCallOptionApply(call).(*compute.RegionHealthChecksListCall)
-	}
-	for il, err := call.PageToken(pt).Do(); ; il, err = call.PageToken(pt).Do() {
-		if shouldRetryWithWait(c.hc.Transport, err, 2) {
-			il, err = call.PageToken(pt).Do()
-		}
-		if err != nil {
-			return nil, err
-		}
-		is = append(is, il.Items...)
-
-		if il.NextPageToken == "" {
-			return is, nil
-		}
-		pt = il.NextPageToken
+// DeleteSecurityPolicy deletes a Cloud Armor SecurityPolicy.
+func (c *client) DeleteSecurityPolicy(project, name string) (err error) {
+	defer wrapResourceError(&err, "delete security policy", project, name)
+	op, err := c.Retry(c.raw.SecurityPolicies.Delete(project, name).Do)
+	if err != nil {
+		return err
 	}
+	return c.i.globalOperationsWait(project, op.Name)
 }
 
-// DeleteRegionNetworkEndpointGroup deletes a GCE RegionNetworkEndpointGroup.
-func (c *client) DeleteRegionNetworkEndpointGroup(project, region, name string) error {
-	op, err := c.Retry(c.raw.RegionNetworkEndpointGroups.Delete(project, region, name).Do)
+// AddSecurityPolicyRule adds a rule to a Cloud Armor SecurityPolicy.
+func (c *client) AddSecurityPolicyRule(project, securityPolicy string, r *compute.SecurityPolicyRule) error {
+	op, err := c.Retry(c.raw.SecurityPolicies.AddRule(project, securityPolicy, r).Do)
 	if err != nil {
 		return err
 	}
-	return c.i.regionOperationsWait(project, region, op.Name)
+	return c.i.globalOperationsWait(project, op.Name)
 }
 
-// CreateRegionNetworkEndpointGroup creates a GCE RegionNetworkEndpointGroup.
-func (c *client) CreateRegionNetworkEndpointGroup(project, region string, p *compute.NetworkEndpointGroup) error {
-	op, err := c.Retry(c.raw.RegionNetworkEndpointGroups.Insert(project, region, p).Do)
+// PatchSecurityPolicyRule patches the rule at the given priority in a Cloud
+// Armor SecurityPolicy.
+func (c *client) PatchSecurityPolicyRule(project, securityPolicy string, priority int64, r *compute.SecurityPolicyRule) error {
+	op, err := c.Retry(c.raw.SecurityPolicies.PatchRule(project, securityPolicy, r).Priority(priority).Do)
 	if err != nil {
 		return err
 	}
-	if err := c.i.regionOperationsWait(project, region, op.Name); err != nil {
-		return err
-	}
+	return c.i.globalOperationsWait(project, op.Name)
+}
+
+// GetFirewallPolicy gets a hierarchical firewall policy. policy is the
+// policy's numeric ID.
+func (c *client) GetFirewallPolicy(policy string) (_ *compute.FirewallPolicy, err error) {
+	defer wrapResourceError(&err, "get firewall policy", policy)
+	fp, err := c.raw.FirewallPolicies.Get(policy).Do()
+	if c.shouldRetry(err, 2) {
+		return c.raw.FirewallPolicies.Get(policy).Do()
+	}
+	return fp, err
+}
+
+// AddFirewallPolicyAssociation associates a hierarchical firewall policy with
+// the organization or folder named by assoc.AttachmentTarget, e.g.
+// "folders/123".
+func (c *client) AddFirewallPolicyAssociation(policy string, assoc *compute.FirewallPolicyAssociation) (err error) {
+	defer wrapResourceError(&err, "add firewall policy association", policy)
+	op, err := c.Retry(c.raw.FirewallPolicies.AddAssociation(policy, assoc).Do)
+	if err != nil {
+		return err
+	}
+	return c.i.organizationOperationsWait(op.Name)
+}
+
+// AddFirewallPolicyRule adds a rule to a hierarchical firewall policy.
+func (c *client) AddFirewallPolicyRule(policy string, rule *compute.FirewallPolicyRule) (err error) {
+	defer wrapResourceError(&err, "add firewall policy rule", policy)
+	op, err := c.Retry(c.raw.FirewallPolicies.AddRule(policy, rule).Do)
+	if err != nil {
+		return err
+	}
+	return c.i.organizationOperationsWait(op.Name)
+}
+
+// CreatePacketMirroring creates a GCE PacketMirroring.
+func (c *client) CreatePacketMirroring(project, region string, pm *compute.PacketMirroring) (err error) {
+	defer wrapResourceError(&err, "create packet mirroring", project, region, pm.Name)
+	op, err := c.Retry(c.raw.PacketMirrorings.Insert(project, region, pm).Do)
+	if err != nil {
+		return err
+	}
+	if err := c.i.regionOperationsWait(project, region, op.Name); err != nil {
+		return err
+	}
+	if c.skipReadback {
+		return nil
+	}
+
+	var createdPacketMirroring *compute.PacketMirroring
+	if createdPacketMirroring, err = c.i.GetPacketMirroring(project, region, pm.Name); err != nil {
+		return err
+	}
+	*pm = *createdPacketMirroring
+	return nil
+}
+
+// GetPacketMirroring gets a GCE PacketMirroring.
+func (c *client) GetPacketMirroring(project, region, name string) (_ *compute.PacketMirroring, err error) {
+	defer wrapResourceError(&err, "get packet mirroring", project, region, name)
+	pm, err := c.raw.PacketMirrorings.Get(project, region, name).Do()
+	if c.shouldRetry(err, 2) {
+		return c.raw.PacketMirrorings.Get(project, region, name).Do()
+	}
+	return pm, err
+}
+
+// DeletePacketMirroring deletes a GCE PacketMirroring.
+func (c *client) DeletePacketMirroring(project, region, name string) (err error) {
+	defer wrapResourceError(&err, "delete packet mirroring", project, region, name)
+	op, err := c.Retry(c.raw.PacketMirrorings.Delete(project, region, name).Do)
+	if err != nil {
+		return err
+	}
+	return c.i.regionOperationsWait(project, region, op.Name)
+}
+
+// DeleteRegionSSLCertificate deletes a GCE RegionSSLCertificate.
+func (c *client) DeleteRegionSSLCertificate(project, region, name string) (err error) {
+	defer wrapResourceError(&err, "delete SSL certificate", project, region, name)
+	op, err := c.Retry(c.raw.RegionSslCertificates.Delete(project, region, name).Do)
+	if err != nil {
+		return err
+	}
+	return c.i.regionOperationsWait(project, region, op.Name)
+}
+
+// CreateRegionSSLCertificate creates a GCE RegionSSLCertificate.
+func (c *client) CreateRegionSSLCertificate(project, region string, sc *compute.SslCertificate) (err error) {
+	defer wrapResourceError(&err, "create SSL certificate", project, region, sc.Name)
+	op, err := c.Retry(c.raw.RegionSslCertificates.Insert(project, region, sc).Do)
+	if err != nil {
+		return err
+	}
+	if err := c.i.regionOperationsWait(project, region, op.Name); err != nil {
+		return err
+	}
+	if c.skipReadback {
+		return nil
+	}
+
+	var createdRegionSSLCertificate *compute.SslCertificate
+	if createdRegionSSLCertificate, err = c.i.GetRegionSSLCertificate(project, region, sc.Name); err != nil {
+		return err
+	}
+	*sc = *createdRegionSSLCertificate
+	return nil
+}
+
+// GetRegionSSLCertificate gets a GCE RegionSSLCertificate.
+func (c *client) GetRegionSSLCertificate(project, region, name string) (_ *compute.SslCertificate, err error) {
+	defer wrapResourceError(&err, "get SSL certificate", project, region, name)
+	i, err := c.raw.RegionSslCertificates.Get(project, region, name).Do()
+	if c.shouldRetry(err, 2) {
+		return c.raw.RegionSslCertificates.Get(project, region, name).Do()
+	}
+	return i, err
+}
+
+// DeleteRegionTargetHTTPSProxy deletes a GCE RegionTargetHTTPSProxy.
+func (c *client) DeleteRegionTargetHTTPSProxy(project, region, name string) (err error) {
+	defer wrapResourceError(&err, "delete target HTTPS proxy", project, region, name)
+	op, err := c.Retry(c.raw.RegionTargetHttpsProxies.Delete(project, region, name).Do)
+	if err != nil {
+		return err
+	}
+	return c.i.regionOperationsWait(project, region, op.Name)
+}
+
+// CreateRegionTargetHTTPSProxy creates a GCE RegionTargetHTTPSProxy.
+func (c *client) CreateRegionTargetHTTPSProxy(project, region string, p *compute.TargetHttpsProxy) (err error) {
+	defer wrapResourceError(&err, "create target HTTPS proxy", project, region, p.Name)
+	op, err := c.Retry(c.raw.RegionTargetHttpsProxies.Insert(project, region, p).Do)
+	if err != nil {
+		return err
+	}
+	if err := c.i.regionOperationsWait(project, region, op.Name); err != nil {
+		return err
+	}
+	if c.skipReadback {
+		return nil
+	}
+
+	var createdRegionTargetHTTPSProxy *compute.TargetHttpsProxy
+	if createdRegionTargetHTTPSProxy, err = c.i.GetRegionTargetHTTPSProxy(project, region, p.Name); err != nil {
+		return err
+	}
+	*p = *createdRegionTargetHTTPSProxy
+	return nil
+}
+
+// GetRegionTargetHTTPSProxy gets a GCE RegionTargetHTTPSProxy.
+func (c *client) GetRegionTargetHTTPSProxy(project, region, name string) (_ *compute.TargetHttpsProxy, err error) {
+	defer wrapResourceError(&err, "get target HTTPS proxy", project, region, name)
+	i, err := c.raw.RegionTargetHttpsProxies.Get(project, region, name).Do()
+	if c.shouldRetry(err, 2) {
+		return c.raw.RegionTargetHttpsProxies.Get(project, region, name).Do()
+	}
+	return i, err
+}
+
+// SetRegionSSLCertificates replaces the SSL certificates, given by their URLs,
+// used by a GCE RegionTargetHTTPSProxy.
+func (c *client) SetRegionSSLCertificates(project, region, proxy string, sslCertificates []string) error {
+	req := &compute.RegionTargetHttpsProxiesSetSslCertificatesRequest{SslCertificates: sslCertificates}
+	op, err := c.Retry(c.raw.RegionTargetHttpsProxies.SetSslCertificates(project, region, proxy, req).Do)
+	if err != nil {
+		return err
+	}
+	return c.i.regionOperationsWait(project, region, op.Name)
+}
+
+// DeleteRegionBackendService deletes a GCE RegionBackendService.
+func (c *client) DeleteRegionBackendService(project, region, name string) (err error) {
+	defer wrapResourceError(&err, "delete backend service", project, region, name)
+	op, err := c.Retry(c.raw.RegionBackendServices.Delete(project, region, name).Do)
+	if err != nil {
+		return err
+	}
+	return c.i.regionOperationsWait(project, region, op.Name)
+}
+
+// CreateRegionBackendService creates a GCE RegionBackendService.
+func (c *client) CreateRegionBackendService(project, region string, p *compute.BackendService) (err error) {
+	defer wrapResourceError(&err, "create backend service", project, region, p.Name)
+	op, err := c.Retry(c.raw.RegionBackendServices.Insert(project, region, p).Do)
+	if err != nil {
+		return err
+	}
+	if err := c.i.regionOperationsWait(project, region, op.Name); err != nil {
+		return err
+	}
+	if c.skipReadback {
+		return nil
+	}
+
+	var createdRegionBackendService *compute.BackendService
+	if createdRegionBackendService, err = c.i.GetRegionBackendService(project, region, p.Name); err != nil {
+		return err
+	}
+	*p = *createdRegionBackendService
+	return nil
+}
+
+// PatchRegionBackendService patches a GCE RegionBackendService in place, only
+// the fields set in p are changed.
+func (c *client) PatchRegionBackendService(project, region, name string, p *compute.BackendService) error {
+	op, err := c.Retry(c.raw.RegionBackendServices.Patch(project, region, name, p).Do)
+	if err != nil {
+		return err
+	}
+	if err := c.i.regionOperationsWait(project, region, op.Name); err != nil {
+		return err
+	}
+	var patchedRegionBackendService *compute.BackendService
+	if patchedRegionBackendService, err = c.i.GetRegionBackendService(project, region, name); err != nil {
+		return err
+	}
+	*p = *patchedRegionBackendService
+	return nil
+}
+
+// GetRegionBackendService gets a GCE RegionBackendService.
+func (c *client) GetRegionBackendService(project, region, name string) (_ *compute.BackendService, err error) {
+	defer wrapResourceError(&err, "get backend service", project, region, name)
+	i, err := c.raw.RegionBackendServices.Get(project, region, name).Do()
+	if c.shouldRetry(err, 2) {
+		return c.raw.RegionBackendServices.Get(project, region, name).Do()
+	}
+	return i, err
+}
+
+// GetRegionBackendServiceHealth gets the health of the backends in group, the
+// URL of an instance group or network endpoint group, of a regional backend
+// service.
+func (c *client) GetRegionBackendServiceHealth(project, region, backendService, group string) (_ *compute.BackendServiceGroupHealth, err error) {
+	defer wrapResourceError(&err, "get health of regional backend service", project, region, backendService)
+	ref := &compute.ResourceGroupReference{Group: group}
+	h, err := c.raw.RegionBackendServices.GetHealth(project, region, backendService, ref).Do()
+	if c.shouldRetry(err, 2) {
+		return c.raw.RegionBackendServices.GetHealth(project, region, backendService, ref).Do()
+	}
+	return h, err
+}
+
+// backendHealthPollInterval is how often WaitForBackendServiceHealthy checks
+// backend health.
+var backendHealthPollInterval = 5 * time.Second
+
+// WaitForBackendServiceHealthy polls the health of the backends in group of a
+// backend service until at least minHealthy of them are HEALTHY, or returns an
+// error once the timeout has elapsed. A region of "" or "global" selects a
+// global backend service, as used by external HTTP(S) load balancers.
+func (c *client) WaitForBackendServiceHealthy(project, region, backendService, group string, minHealthy int, timeout time.Duration) error {
+	getHealth := func() (*compute.BackendServiceGroupHealth, error) {
+		return c.i.GetRegionBackendServiceHealth(project, region, backendService, group)
+	}
+	if region == "" || region == "global" {
+		getHealth = func() (*compute.BackendServiceGroupHealth, error) {
+			return c.i.GetBackendServiceHealth(project, backendService, group)
+		}
+	}
+	deadline := c.clock.Now().Add(timeout)
+	for {
+		h, err := getHealth()
+		if err != nil {
+			return err
+		}
+		healthy := 0
+		for _, hs := range h.HealthStatus {
+			if hs.HealthState == "HEALTHY" {
+				healthy++
+			}
+		}
+		if healthy >= minHealthy {
+			return nil
+		}
+		if c.clock.Now().After(deadline) {
+			return fmt.Errorf("backend service %q: %d of %d backends in group %q healthy after %s, want at least %d (%d unhealthy)", backendService, healthy, len(h.HealthStatus), group, timeout, minHealthy, len(h.HealthStatus)-healthy)
+		}
+		c.clock.Sleep(backendHealthPollInterval)
+	}
+}
+
+// ListRegionBackendServices lists GCE RegionBackendServices.
+func (c *client) ListRegionBackendServices(project, region string, opts ...ListCallOption) ([]*compute.BackendService, error) {
+	var is []*compute.BackendService
+	var pt string
+	call := c.raw.RegionBackendServices.List(project, region)
+	for _, opt := range opts {
+		call = opt.listCallOptionApply(call).(*compute.RegionBackendServicesListCall)
+	}
+	limit := listLimit(opts)
+	for il, err := call.PageToken(pt).Do(); ; il, err = call.PageToken(pt).Do() {
+		if c.shouldRetry(err, 2) {
+			il, err = call.PageToken(pt).Do()
+		}
+		if err != nil {
+			return nil, err
+		}
+		is = append(is, il.Items...)
+
+		if limit > 0 && len(is) >= limit {
+			return is[:limit], nil
+		}
+		if il.NextPageToken == "" {
+			return is, nil
+		}
+		pt = il.NextPageToken
+	}
+}
+
+// CreateInstanceGroup creates a GCE unmanaged InstanceGroup.
+func (c *client) CreateInstanceGroup(project, zone string, ig *compute.InstanceGroup) (err error) {
+	defer wrapResourceError(&err, "create instance group", project, zone, ig.Name)
+	op, err := c.Retry(c.raw.InstanceGroups.Insert(project, zone, ig).Do)
+	if err != nil {
+		return err
+	}
+	if err := c.i.zoneOperationsWait(project, zone, op.Name); err != nil {
+		return err
+	}
+	if c.skipReadback {
+		return nil
+	}
+
+	var createdInstanceGroup *compute.InstanceGroup
+	if createdInstanceGroup, err = c.i.GetInstanceGroup(project, zone, ig.Name); err != nil {
+		return err
+	}
+	*ig = *createdInstanceGroup
+	return nil
+}
+
+// DeleteInstanceGroup deletes a GCE unmanaged InstanceGroup.
+func (c *client) DeleteInstanceGroup(project, zone, name string) (err error) {
+	defer wrapResourceError(&err, "delete instance group", project, zone, name)
+	op, err := c.Retry(c.raw.InstanceGroups.Delete(project, zone, name).Do)
+	if err != nil {
+		return err
+	}
+	return c.i.zoneOperationsWait(project, zone, op.Name)
+}
+
+// GetInstanceGroup gets a GCE unmanaged InstanceGroup.
+func (c *client) GetInstanceGroup(project, zone, name string) (_ *compute.InstanceGroup, err error) {
+	defer wrapResourceError(&err, "get instance group", project, zone, name)
+	ig, err := c.raw.InstanceGroups.Get(project, zone, name).Do()
+	if c.shouldRetry(err, 2) {
+		return c.raw.InstanceGroups.Get(project, zone, name).Do()
+	}
+	return ig, err
+}
+
+func instanceReferences(instances []string) []*compute.InstanceReference {
+	var refs []*compute.InstanceReference
+	for _, i := range instances {
+		refs = append(refs, &compute.InstanceReference{Instance: i})
+	}
+	return refs
+}
+
+// AddInstanceGroupInstances adds instances, given by their self-links, to a
+// GCE unmanaged InstanceGroup.
+func (c *client) AddInstanceGroupInstances(project, zone, name string, instances []string) error {
+	req := &compute.InstanceGroupsAddInstancesRequest{Instances: instanceReferences(instances)}
+	op, err := c.Retry(c.raw.InstanceGroups.AddInstances(project, zone, name, req).Do)
+	if err != nil {
+		return err
+	}
+	return c.i.zoneOperationsWait(project, zone, op.Name)
+}
+
+// RemoveInstanceGroupInstances removes instances, given by their self-links,
+// from a GCE unmanaged InstanceGroup.
+func (c *client) RemoveInstanceGroupInstances(project, zone, name string, instances []string) error {
+	req := &compute.InstanceGroupsRemoveInstancesRequest{Instances: instanceReferences(instances)}
+	op, err := c.Retry(c.raw.InstanceGroups.RemoveInstances(project, zone, name, req).Do)
+	if err != nil {
+		return err
+	}
+	return c.i.zoneOperationsWait(project, zone, op.Name)
+}
+
+// DeleteRegionURLMap deletes a GCE RegionURLMap.
+func (c *client) DeleteRegionURLMap(project, region, name string) (err error) {
+	defer wrapResourceError(&err, "delete URL map", project, region, name)
+	op, err := c.Retry(c.raw.RegionUrlMaps.Delete(project, region, name).Do)
+	if err != nil {
+		return err
+	}
+	return c.i.regionOperationsWait(project, region, op.Name)
+}
+
+// CreateRegionURLMap creates a GCE RegionURLMap.
+func (c *client) CreateRegionURLMap(project, region string, p *compute.UrlMap) (err error) {
+	defer wrapResourceError(&err, "create URL map", project, region, p.Name)
+	op, err := c.Retry(c.raw.RegionUrlMaps.Insert(project, region, p).Do)
+	if err != nil {
+		return err
+	}
+	if err := c.i.regionOperationsWait(project, region, op.Name); err != nil {
+		return err
+	}
+	if c.skipReadback {
+		return nil
+	}
+
+	var createdRegionURLMap *compute.UrlMap
+	if createdRegionURLMap, err = c.i.GetRegionURLMap(project, region, p.Name); err != nil {
+		return err
+	}
+	*p = *createdRegionURLMap
+	return nil
+}
+
+// GetRegionURLMap gets a GCE RegionURLMap.
+func (c *client) GetRegionURLMap(project, region, name string) (_ *compute.UrlMap, err error) {
+	defer wrapResourceError(&err, "get URL map", project, region, name)
+	i, err := c.raw.RegionUrlMaps.Get(project, region, name).Do()
+	if c.shouldRetry(err, 2) {
+		return c.raw.RegionUrlMaps.Get(project, region, name).Do()
+	}
+	return i, err
+}
+
+// ValidateRegionURLMap runs the tests in a GCE RegionURLMap without creating it.
+func (c *client) ValidateRegionURLMap(project, region string, u *compute.UrlMap) (*compute.UrlMapsValidateResponse, error) {
+	req := &compute.RegionUrlMapsValidateRequest{Resource: u}
+	r, err := c.raw.RegionUrlMaps.Validate(project, region, u.Name, req).Do()
+	if c.shouldRetry(err, 2) {
+		return c.raw.RegionUrlMaps.Validate(project, region, u.Name, req).Do()
+	}
+	return r, err
+}
+
+// ValidateURLMap runs the tests in a global GCE URLMap without creating it.
+func (c *client) ValidateURLMap(project string, u *compute.UrlMap) (*compute.UrlMapsValidateResponse, error) {
+	req := &compute.UrlMapsValidateRequest{Resource: u}
+	r, err := c.raw.UrlMaps.Validate(project, u.Name, req).Do()
+	if c.shouldRetry(err, 2) {
+		return c.raw.UrlMaps.Validate(project, u.Name, req).Do()
+	}
+	return r, err
+}
+
+// ListRegionURLMaps lists GCE RegionURLMaps.
+func (c *client) ListRegionURLMaps(project, region string, opts ...ListCallOption) ([]*compute.UrlMap, error) {
+	var is []*compute.UrlMap
+	var pt string
+	call := c.raw.RegionUrlMaps.List(project, region)
+	for _, opt := range opts {
+		call = opt.listCallOptionApply(call).(*compute.RegionUrlMapsListCall)
+	}
+	limit := listLimit(opts)
+	for il, err := call.PageToken(pt).Do(); ; il, err = call.PageToken(pt).Do() {
+		if c.shouldRetry(err, 2) {
+			il, err = call.PageToken(pt).Do()
+		}
+		if err != nil {
+			return nil, err
+		}
+		is = append(is, il.Items...)
+
+		if limit > 0 && len(is) >= limit {
+			return is[:limit], nil
+		}
+		if il.NextPageToken == "" {
+			return is, nil
+		}
+		pt = il.NextPageToken
+	}
+}
+
+// DeleteRegionHealthCheck deletes a GCE RegionHealthCheck.
+func (c *client) DeleteRegionHealthCheck(project, region, name string) (err error) {
+	defer wrapResourceError(&err, "delete health check", project, region, name)
+	op, err := c.Retry(c.raw.RegionHealthChecks.Delete(project, region, name).Do)
+	if err != nil {
+		return err
+	}
+	return c.i.regionOperationsWait(project, region, op.Name)
+}
+
+// CreateRegionHealthCheck creates a GCE RegionHealthCheck.
+func (c *client) CreateRegionHealthCheck(project, region string, p *compute.HealthCheck) (err error) {
+	defer wrapResourceError(&err, "create health check", project, region, p.Name)
+	op, err := c.Retry(c.raw.RegionHealthChecks.Insert(project, region, p).Do)
+	if err != nil {
+		return err
+	}
+	if err := c.i.regionOperationsWait(project, region, op.Name); err != nil {
+		return err
+	}
+	if c.skipReadback {
+		return nil
+	}
+
+	var createdRegionHealthCheck *compute.HealthCheck
+	if createdRegionHealthCheck, err = c.i.GetRegionHealthCheck(project, region, p.Name); err != nil {
+		return err
+	}
+	*p = *createdRegionHealthCheck
+	return nil
+}
+
+// GetRegionHealthCheck gets a GCE RegionHealthCheck.
+func (c *client) GetRegionHealthCheck(project, region, name string) (_ *compute.HealthCheck, err error) {
+	defer wrapResourceError(&err, "get health check", project, region, name)
+	i, err := c.raw.RegionHealthChecks.Get(project, region, name).Do()
+	if c.shouldRetry(err, 2) {
+		return c.raw.RegionHealthChecks.Get(project, region, name).Do()
+	}
+	return i, err
+}
+
+// ListRegionHealthChecks lists GCE RegionHealthChecks.
+func (c *client) ListRegionHealthChecks(project, region string, opts ...ListCallOption) ([]*compute.HealthCheck, error) {
+	var is []*compute.HealthCheck
+	var pt string
+	call := c.raw.RegionHealthChecks.List(project, region)
+	for _, opt := range opts {
+		call = opt.listCallOptionApply(call).(*compute.RegionHealthChecksListCall)
+	}
+	limit := listLimit(opts)
+	for il, err := call.PageToken(pt).Do(); ; il, err = call.PageToken(pt).Do() {
+		if c.shouldRetry(err, 2) {
+			il, err = call.PageToken(pt).Do()
+		}
+		if err != nil {
+			return nil, err
+		}
+		is = append(is, il.Items...)
+
+		if limit > 0 && len(is) >= limit {
+			return is[:limit], nil
+		}
+		if il.NextPageToken == "" {
+			return is, nil
+		}
+		pt = il.NextPageToken
+	}
+}
+
+// AttachNetworkEndpoints attaches endpoints to a zonal GCE NetworkEndpointGroup.
+func (c *client) AttachNetworkEndpoints(project, zone, neg string, endpoints []*compute.NetworkEndpoint) error {
+	req := &compute.NetworkEndpointGroupsAttachEndpointsRequest{NetworkEndpoints: endpoints}
+	op, err := c.Retry(c.raw.NetworkEndpointGroups.AttachNetworkEndpoints(project, zone, neg, req).Do)
+	if err != nil {
+		return err
+	}
+	return c.i.zoneOperationsWait(project, zone, op.Name)
+}
+
+// DetachNetworkEndpoints detaches endpoints from a zonal GCE NetworkEndpointGroup.
+func (c *client) DetachNetworkEndpoints(project, zone, neg string, endpoints []*compute.NetworkEndpoint) error {
+	req := &compute.NetworkEndpointGroupsDetachEndpointsRequest{NetworkEndpoints: endpoints}
+	op, err := c.Retry(c.raw.NetworkEndpointGroups.DetachNetworkEndpoints(project, zone, neg, req).Do)
+	if err != nil {
+		return err
+	}
+	return c.i.zoneOperationsWait(project, zone, op.Name)
+}
+
+// AttachRegionNetworkEndpoints attaches endpoints to a GCE RegionNetworkEndpointGroup.
+func (c *client) AttachRegionNetworkEndpoints(project, region, neg string, endpoints []*compute.NetworkEndpoint) error {
+	req := &compute.RegionNetworkEndpointGroupsAttachEndpointsRequest{NetworkEndpoints: endpoints}
+	op, err := c.Retry(c.raw.RegionNetworkEndpointGroups.AttachNetworkEndpoints(project, region, neg, req).Do)
+	if err != nil {
+		return err
+	}
+	return c.i.regionOperationsWait(project, region, op.Name)
+}
+
+// DetachRegionNetworkEndpoints detaches endpoints from a GCE RegionNetworkEndpointGroup.
+func (c *client) DetachRegionNetworkEndpoints(project, region, neg string, endpoints []*compute.NetworkEndpoint) error {
+	req := &compute.RegionNetworkEndpointGroupsDetachEndpointsRequest{NetworkEndpoints: endpoints}
+	op, err := c.Retry(c.raw.RegionNetworkEndpointGroups.DetachNetworkEndpoints(project, region, neg, req).Do)
+	if err != nil {
+		return err
+	}
+	return c.i.regionOperationsWait(project, region, op.Name)
+}
+
+// DeleteRegionNetworkEndpointGroup deletes a GCE RegionNetworkEndpointGroup.
+func (c *client) DeleteRegionNetworkEndpointGroup(project, region, name string) (err error) {
+	defer wrapResourceError(&err, "delete network endpoint group", project, region, name)
+	op, err := c.Retry(c.raw.RegionNetworkEndpointGroups.Delete(project, region, name).Do)
+	if err != nil {
+		return err
+	}
+	return c.i.regionOperationsWait(project, region, op.Name)
+}
+
+// CreateRegionNetworkEndpointGroup creates a GCE RegionNetworkEndpointGroup.
+func (c *client) CreateRegionNetworkEndpointGroup(project, region string, p *compute.NetworkEndpointGroup) (err error) {
+	defer wrapResourceError(&err, "create network endpoint group", project, region, p.Name)
+	op, err := c.Retry(c.raw.RegionNetworkEndpointGroups.Insert(project, region, p).Do)
+	if err != nil {
+		return err
+	}
+	if err := c.i.regionOperationsWait(project, region, op.Name); err != nil {
+		return err
+	}
+	if c.skipReadback {
+		return nil
+	}
+
 	var createdRegionNetworkEndpointGroup *compute.NetworkEndpointGroup
 	if createdRegionNetworkEndpointGroup, err = c.i.GetRegionNetworkEndpointGroup(project, region, p.Name); err != nil {
 		return err
@@ -900,9 +2552,10 @@ func (c *client) CreateRegionNetworkEndpointGroup(project, region string, p *com
 }
 
 // GetRegionNetworkEndpointGroup gets a GCE RegionNetworkEndpointGroup.
-func (c *client) GetRegionNetworkEndpointGroup(project, region, name string) (*compute.NetworkEndpointGroup, error) {
+func (c *client) GetRegionNetworkEndpointGroup(project, region, name string) (_ *compute.NetworkEndpointGroup, err error) {
+	defer wrapResourceError(&err, "get network endpoint group", project, region, name)
 	i, err := c.raw.RegionNetworkEndpointGroups.Get(project, region, name).Do()
-	if shouldRetryWithWait(c.hc.Transport, err, 2) {
+	if c.shouldRetry(err, 2) {
 		return c.raw.RegionNetworkEndpointGroups.Get(project, region, name).Do()
 	}
 	return i, err
@@ -916,51 +2569,408 @@ func (c *client) ListRegionNetworkEndpointGroups(project, region string, opts ..
 	for _, opt := range opts {
 		call = opt.listCallOptionApply(call).(*compute.RegionNetworkEndpointGroupsListCall)
 	}
+	limit := listLimit(opts)
 	for il, err := call.PageToken(pt).Do(); ; il, err = call.PageToken(pt).Do() {
-		if shouldRetryWithWait(c.hc.Transport, err, 2) {
+		if c.shouldRetry(err, 2) {
 			il, err = call.PageToken(pt).Do()
 		}
 		if err != nil {
 			return nil, err
 		}
-		is = append(is, il.Items...)
+		is = append(is, il.Items...)
+
+		if limit > 0 && len(is) >= limit {
+			return is[:limit], nil
+		}
+		if il.NextPageToken == "" {
+			return is, nil
+		}
+		pt = il.NextPageToken
+	}
+}
+
+// GetRegionAutoscaler gets a GCE RegionAutoscaler.
+func (c *client) GetRegionAutoscaler(project, region, name string) (_ *compute.Autoscaler, err error) {
+	defer wrapResourceError(&err, "get autoscaler", project, region, name)
+	a, err := c.raw.RegionAutoscalers.Get(project, region, name).Do()
+	if c.shouldRetry(err, 2) {
+		return c.raw.RegionAutoscalers.Get(project, region, name).Do()
+	}
+	return a, err
+}
+
+// ListRegionAutoscalers lists GCE RegionAutoscalers.
+func (c *client) ListRegionAutoscalers(project, region string, opts ...ListCallOption) ([]*compute.Autoscaler, error) {
+	var as []*compute.Autoscaler
+	var pt string
+	call := c.raw.RegionAutoscalers.List(project, region)
+	for _, opt := range opts {
+		call = opt.listCallOptionApply(call).(*compute.RegionAutoscalersListCall)
+	}
+	limit := listLimit(opts)
+	for al, err := call.PageToken(pt).Do(); ; al, err = call.PageToken(pt).Do() {
+		if c.shouldRetry(err, 2) {
+			al, err = call.PageToken(pt).Do()
+		}
+		if err != nil {
+			return nil, err
+		}
+		as = append(as, al.Items...)
+
+		if limit > 0 && len(as) >= limit {
+			return as[:limit], nil
+		}
+		if al.NextPageToken == "" {
+			return as, nil
+		}
+		pt = al.NextPageToken
+	}
+}
+
+// GetRegionAutoscalerRecommendedSize returns the managed instance group size
+// currently recommended by a GCE RegionAutoscaler. The autoscaler's
+// StatusDetails explain how it arrived at that size.
+func (c *client) GetRegionAutoscalerRecommendedSize(project, region, name string) (int64, error) {
+	a, err := c.i.GetRegionAutoscaler(project, region, name)
+	if err != nil {
+		return 0, err
+	}
+	return a.RecommendedSize, nil
+}
+
+func (c *client) CreateInstance(project, zone string, i *compute.Instance) (err error) {
+	defer wrapResourceError(&err, "create instance", project, zone, i.Name)
+	if c.validate {
+		if err := c.validateInstance(project, zone, i); err != nil {
+			return err
+		}
+	}
+	op, err := c.Retry(c.raw.Instances.Insert(project, zone, i).Do)
+	if err != nil {
+		return err
+	}
+
+	if err := c.i.zoneOperationsWait(project, zone, op.Name); err != nil {
+		return err
+	}
+
+	if c.skipReadback {
+		return nil
+	}
+
+	var createdInstance *compute.Instance
+	if createdInstance, err = c.i.GetInstance(project, zone, i.Name); err != nil {
+		return err
+	}
+	*i = *createdInstance
+	return nil
+}
+
+// isStockout reports whether err is an operation error caused by a zone
+// running out of resources.
+func isStockout(err error) bool {
+	var opErr *OperationError
+	if !errors.As(err, &opErr) {
+		return false
+	}
+	return opErr.HasCode("ZONE_RESOURCE_POOL_EXHAUSTED") || opErr.HasCode("ZONE_RESOURCE_POOL_EXHAUSTED_WITH_DETAILS")
+}
+
+// CreateInstanceFromMachineImage creates a GCE instance from a machine image,
+// given by URL. i must have a name; any other fields set in i, such as the
+// machine type or network interfaces, override the machine image's instance
+// properties.
+func (c *client) CreateInstanceFromMachineImage(project, zone, machineImage string, i *compute.Instance) error {
+	i.SourceMachineImage = machineImage
+	return c.i.CreateInstance(project, zone, i)
+}
+
+// CreateInstanceAndGet creates a GCE instance and returns it as read back with
+// GetInstance once the create operation is done, including fields set by the
+// API such as its self link and assigned IP addresses. i is updated the same
+// way as by CreateInstance.
+func (c *client) CreateInstanceAndGet(project, zone string, i *compute.Instance) (*compute.Instance, error) {
+	if err := c.i.CreateInstance(project, zone, i); err != nil {
+		return nil, err
+	}
+	if c.skipReadback {
+		created, err := c.i.GetInstance(project, zone, i.Name)
+		if err != nil {
+			return nil, err
+		}
+		*i = *created
+	}
+	created := *i
+	return &created, nil
+}
+
+// CreateInstanceIfNotExists creates a GCE instance unless an instance with the
+// same name already exists in the zone, and reports whether it created one.
+// In either case i is updated with the instance as it exists in GCE. Only the
+// name is compared; an existing instance with a different spec is not an
+// error.
+func (c *client) CreateInstanceIfNotExists(project, zone string, i *compute.Instance) (bool, error) {
+	existing, err := c.i.GetInstance(project, zone, i.Name)
+	if err == nil {
+		*i = *existing
+		return false, nil
+	}
+	if !IsNotFound(err) {
+		return false, err
+	}
+	if err := c.i.CreateInstance(project, zone, i); err != nil {
+		return false, err
+	}
+	if c.skipReadback {
+		created, err := c.i.GetInstance(project, zone, i.Name)
+		if err != nil {
+			return true, err
+		}
+		*i = *created
+	}
+	return true, nil
+}
+
+// CreateInstanceInZones creates a GCE instance in the first of the given zones
+// that has capacity for it and returns that zone. Zones are tried in order and
+// any error other than a stockout is returned immediately. Zonal references in
+// the instance, such as its machine type, must be valid in every zone.
+func (c *client) CreateInstanceInZones(project string, zones []string, i *compute.Instance) (string, error) {
+	if len(zones) == 0 {
+		return "", errors.New("no zones given to create instance in")
+	}
+	var err error
+	for _, zone := range zones {
+		if err = c.i.CreateInstance(project, zone, i); err == nil {
+			return zone, nil
+		}
+		if !isStockout(err) {
+			return "", err
+		}
+	}
+	return "", fmt.Errorf("instance %q could not be created in any of zones %v: %v", i.Name, zones, err)
+}
+
+// validateInstance checks an instance's labels, its machine type if it is a
+// confidential VM, that the accelerator types it requests are available in the
+// zone, and that a specific reservation it consumes exists.
+func (c *client) validateInstance(project, zone string, i *compute.Instance) error {
+	if err := ValidateLabels(i.Labels); err != nil {
+		return err
+	}
+	if err := validateConfidentialInstance(i); err != nil {
+		return err
+	}
+	if err := validateNetworkPerformance(i); err != nil {
+		return err
+	}
+	for _, ac := range i.GuestAccelerators {
+		at := ac.AcceleratorType
+		if idx := strings.LastIndex(at, "/"); idx != -1 {
+			at = at[idx+1:]
+		}
+		if _, err := c.i.GetAcceleratorType(project, zone, at); err != nil {
+			if IsNotFound(err) {
+				return fmt.Errorf("accelerator type %q is not supported in zone %q", at, zone)
+			}
+			return err
+		}
+	}
+	return c.validateReservationAffinity(project, zone, i.ReservationAffinity)
+}
+
+// validateReservationAffinity checks that the reservations named by a
+// SPECIFIC_RESERVATION affinity exist in the zone. Reservations shared from
+// another project are named as "projects/<project>/reservations/<name>".
+func (c *client) validateReservationAffinity(project, zone string, ra *compute.ReservationAffinity) error {
+	if ra == nil || ra.ConsumeReservationType != "SPECIFIC_RESERVATION" {
+		return nil
+	}
+	if len(ra.Values) == 0 {
+		return errors.New("SPECIFIC_RESERVATION affinity must name a reservation")
+	}
+	for _, v := range ra.Values {
+		p, name := project, v
+		if parts := strings.Split(v, "/"); len(parts) == 4 && parts[0] == "projects" && parts[2] == "reservations" {
+			p, name = parts[1], parts[3]
+		}
+		if _, err := c.i.GetReservation(p, zone, name); err != nil {
+			if IsNotFound(err) {
+				return fmt.Errorf("reservation %q does not exist in zone %q", v, zone)
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// confidentialMachineFamilies are the machine families that support
+// confidential computing.
+var confidentialMachineFamilies = map[string]bool{"n2d": true, "c2d": true, "c3d": true}
+
+// validateConfidentialInstance checks that a confidential VM uses a machine
+// type that supports confidential computing.
+func validateConfidentialInstance(i *compute.Instance) error {
+	if i.ConfidentialInstanceConfig == nil || !i.ConfidentialInstanceConfig.EnableConfidentialCompute {
+		return nil
+	}
+	mt := i.MachineType
+	if idx := strings.LastIndex(mt, "/"); idx != -1 {
+		mt = mt[idx+1:]
+	}
+	family := strings.SplitN(mt, "-", 2)[0]
+	if !confidentialMachineFamilies[family] {
+		return fmt.Errorf("instance %q: machine type %q does not support confidential computing, use an n2d, c2d or c3d machine type", i.Name, mt)
+	}
+	return nil
+}
+
+// noTier1MachineFamilies are machine families known not to support Tier_1
+// networking performance. Families not listed here, including new ones, are
+// left for the API to check.
+var noTier1MachineFamilies = map[string]bool{
+	"e2": true, "n1": true, "f1": true, "g1": true, "t2d": true, "t2a": true,
+}
+
+// validateNetworkPerformance checks that an instance requesting Tier_1
+// networking uses gVNIC on all network interfaces and is not of a machine
+// family known not to support it. The minimum number of vCPUs is left for
+// the API to check.
+func validateNetworkPerformance(i *compute.Instance) error {
+	if i.NetworkPerformanceConfig == nil || i.NetworkPerformanceConfig.TotalEgressBandwidthTier != "TIER_1" {
+		return nil
+	}
+	for _, ni := range i.NetworkInterfaces {
+		if ni.NicType != "GVNIC" {
+			return fmt.Errorf("instance %q: Tier_1 networking requires gVNIC, network interface %q has NIC type %q", i.Name, ni.Name, ni.NicType)
+		}
+	}
+	mt := i.MachineType
+	if idx := strings.LastIndex(mt, "/"); idx != -1 {
+		mt = mt[idx+1:]
+	}
+	family := strings.SplitN(mt, "-", 2)[0]
+	if noTier1MachineFamilies[family] {
+		return fmt.Errorf("instance %q: machine type %q does not support Tier_1 networking", i.Name, mt)
+	}
+	return nil
+}
+
+// BulkInsertInstance creates multiple GCE instances in a zone. A bulk insert
+// can partially succeed, use GetBulkInsertInstanceResult to find out which
+// instances came up. The instances are described by r.InstanceProperties or
+// created from the instance template r.SourceInstanceTemplate, one of which
+// must be set. With WithValidation, a global source template must exist.
+func (c *client) BulkInsertInstance(project, zone string, r *compute.BulkInsertInstanceResource) error {
+	if r.InstanceProperties == nil && r.SourceInstanceTemplate == "" {
+		return errors.New("bulk insert needs instance properties or a source instance template")
+	}
+	if c.validate && r.SourceInstanceTemplate != "" {
+		if err := c.validateInstanceTemplate(project, r.SourceInstanceTemplate); err != nil {
+			return err
+		}
+	}
+	op, err := c.Retry(c.raw.Instances.BulkInsert(project, zone, r).Do)
+	if err != nil {
+		return err
+	}
+
+	return c.i.zoneOperationsWait(project, zone, op.Name)
+}
+
+// validateInstanceTemplate checks that the instance template at the full or
+// partial URL template exists. Regional templates are not checked.
+func (c *client) validateInstanceTemplate(project, template string) error {
+	p, scope, _, resourceType, name, err := ParseResourceURL(template)
+	if err != nil || resourceType != "instanceTemplates" {
+		return fmt.Errorf("invalid instance template URL %q", template)
+	}
+	if scope != "global" {
+		return nil
+	}
+	if p == "" {
+		p = project
+	}
+	if _, err := c.i.GetInstanceTemplate(p, name); err != nil {
+		if IsNotFound(err) {
+			return fmt.Errorf("instance template %q does not exist", template)
+		}
+		return err
+	}
+	return nil
+}
 
-		if il.NextPageToken == "" {
-			return is, nil
+// bulkInsertNameRegex returns a regex matching the instance names generated
+// from a bulk insert name pattern, e.g. "builder-####".
+func bulkInsertNameRegex(pattern string) (*regexp.Regexp, error) {
+	var expr string
+	for _, part := range regexp.MustCompile("#+|[^#]+").FindAllString(pattern, -1) {
+		if strings.HasPrefix(part, "#") {
+			expr += "[0-9]+"
+		} else {
+			expr += regexp.QuoteMeta(part)
 		}
-		pt = il.NextPageToken
 	}
+	return regexp.Compile("^" + expr + "$")
 }
 
-func (c *client) CreateInstance(project, zone string, i *compute.Instance) error {
-	op, err := c.Retry(c.raw.Instances.Insert(project, zone, i).Do)
-	if err != nil {
-		return err
+// GetBulkInsertInstanceResult returns the names of the instances from a bulk
+// insert that reached a 'RUNNING' state and of those that did not. Instances
+// named in PerInstanceProperties that do not exist are reported as failed;
+// instances that were never created from a NamePattern cannot be named and
+// are not reported.
+func (c *client) GetBulkInsertInstanceResult(project, zone string, r *compute.BulkInsertInstanceResource) (running, failed []string, err error) {
+	var nameRegex *regexp.Regexp
+	if r.NamePattern != "" {
+		if nameRegex, err = bulkInsertNameRegex(r.NamePattern); err != nil {
+			return nil, nil, fmt.Errorf("invalid name pattern %q: %v", r.NamePattern, err)
+		}
 	}
 
-	if err := c.i.zoneOperationsWait(project, zone, op.Name); err != nil {
-		return err
+	is, err := c.i.ListInstances(project, zone)
+	if err != nil {
+		return nil, nil, err
 	}
-
-	var createdInstance *compute.Instance
-	if createdInstance, err = c.i.GetInstance(project, zone, i.Name); err != nil {
-		return err
+	found := map[string]bool{}
+	for _, i := range is {
+		_, named := r.PerInstanceProperties[i.Name]
+		if !named && (nameRegex == nil || !nameRegex.MatchString(i.Name)) {
+			continue
+		}
+		found[i.Name] = true
+		if i.Status == StatusRunning {
+			running = append(running, i.Name)
+		} else {
+			failed = append(failed, i.Name)
+		}
 	}
-	*i = *createdInstance
-	return nil
+	for name := range r.PerInstanceProperties {
+		if !found[name] {
+			failed = append(failed, name)
+		}
+	}
+	sort.Strings(running)
+	sort.Strings(failed)
+	return running, failed, nil
 }
 
-// CreateInstanceAlpha creates a GCE image using Alpha API.
-func (c *client) CreateInstanceAlpha(project, zone string, i *computeAlpha.Instance) error {
+// CreateInstanceAlpha creates a GCE instance using Alpha API, and waits on
+// the operation using Alpha API.
+func (c *client) CreateInstanceAlpha(project, zone string, i *computeAlpha.Instance) (err error) {
+	defer wrapResourceError(&err, "create instance", project, zone, i.Name)
 	op, err := c.RetryAlpha(c.rawAlpha.Instances.Insert(project, zone, i).Do)
 	if err != nil {
 		return err
 	}
 
-	if err := c.i.zoneOperationsWait(project, zone, op.Name); err != nil {
+	if err := c.i.zoneOperationsWaitAlpha(project, zone, op.Name); err != nil {
 		return err
 	}
 
+	if c.skipReadback {
+		return nil
+	}
+
 	var createdInstance *computeAlpha.Instance
 	if createdInstance, err = c.i.GetInstanceAlpha(project, zone, i.Name); err != nil {
 		return err
@@ -969,17 +2979,23 @@ func (c *client) CreateInstanceAlpha(project, zone string, i *computeAlpha.Insta
 	return nil
 }
 
-// CreateInstanceBeta creates a GCE image using Beta API.
-func (c *client) CreateInstanceBeta(project, zone string, i *computeBeta.Instance) error {
+// CreateInstanceBeta creates a GCE instance using Beta API, and waits on the
+// operation using Beta API.
+func (c *client) CreateInstanceBeta(project, zone string, i *computeBeta.Instance) (err error) {
+	defer wrapResourceError(&err, "create instance", project, zone, i.Name)
 	op, err := c.RetryBeta(c.rawBeta.Instances.Insert(project, zone, i).Do)
 	if err != nil {
 		return err
 	}
 
-	if err := c.i.zoneOperationsWait(project, zone, op.Name); err != nil {
+	if err := c.i.zoneOperationsWaitBeta(project, zone, op.Name); err != nil {
 		return err
 	}
 
+	if c.skipReadback {
+		return nil
+	}
+
 	var createdInstance *computeBeta.Instance
 	if createdInstance, err = c.i.GetInstanceBeta(project, zone, i.Name); err != nil {
 		return err
@@ -988,7 +3004,8 @@ func (c *client) CreateInstanceBeta(project, zone string, i *computeBeta.Instanc
 	return nil
 }
 
-func (c *client) CreateNetwork(project string, n *compute.Network) error {
+func (c *client) CreateNetwork(project string, n *compute.Network) (err error) {
+	defer wrapResourceError(&err, "create network", project, n.Name)
 	op, err := c.Retry(c.raw.Networks.Insert(project, n).Do)
 	if err != nil {
 		return err
@@ -998,6 +3015,10 @@ func (c *client) CreateNetwork(project string, n *compute.Network) error {
 		return err
 	}
 
+	if c.skipReadback {
+		return nil
+	}
+
 	var createdNetwork *compute.Network
 	if createdNetwork, err = c.i.GetNetwork(project, n.Name); err != nil {
 		return err
@@ -1006,7 +3027,8 @@ func (c *client) CreateNetwork(project string, n *compute.Network) error {
 	return nil
 }
 
-func (c *client) CreateSubnetwork(project, region string, n *compute.Subnetwork) error {
+func (c *client) CreateSubnetwork(project, region string, n *compute.Subnetwork) (err error) {
+	defer wrapResourceError(&err, "create subnetwork", project, region, n.Name)
 	op, err := c.Retry(c.raw.Subnetworks.Insert(project, region, n).Do)
 	if err != nil {
 		return err
@@ -1016,6 +3038,10 @@ func (c *client) CreateSubnetwork(project, region string, n *compute.Subnetwork)
 		return err
 	}
 
+	if c.skipReadback {
+		return nil
+	}
+
 	var createdSubnetwork *compute.Subnetwork
 	if createdSubnetwork, err = c.i.GetSubnetwork(project, region, n.Name); err != nil {
 		return err
@@ -1026,7 +3052,8 @@ func (c *client) CreateSubnetwork(project, region string, n *compute.Subnetwork)
 
 // CreateTargetInstance creates a GCE Target Instance, which can be used as
 // target on ForwardingRule
-func (c *client) CreateTargetInstance(project, zone string, ti *compute.TargetInstance) error {
+func (c *client) CreateTargetInstance(project, zone string, ti *compute.TargetInstance) (err error) {
+	defer wrapResourceError(&err, "create target instance", project, zone, ti.Name)
 	op, err := c.Retry(c.raw.TargetInstances.Insert(project, zone, ti).Do)
 	if err != nil {
 		return err
@@ -1036,6 +3063,10 @@ func (c *client) CreateTargetInstance(project, zone string, ti *compute.TargetIn
 		return err
 	}
 
+	if c.skipReadback {
+		return nil
+	}
+
 	var createdTargetInstance *compute.TargetInstance
 	if createdTargetInstance, err = c.i.GetTargetInstance(project, zone, ti.Name); err != nil {
 		return err
@@ -1045,7 +3076,8 @@ func (c *client) CreateTargetInstance(project, zone string, ti *compute.TargetIn
 }
 
 // DeleteFirewallRule deletes a GCE FirewallRule.
-func (c *client) DeleteFirewallRule(project, name string) error {
+func (c *client) DeleteFirewallRule(project, name string) (err error) {
+	defer wrapResourceError(&err, "delete firewall rule", project, name)
 	op, err := c.Retry(c.raw.Firewalls.Delete(project, name).Do)
 	if err != nil {
 		return err
@@ -1055,7 +3087,8 @@ func (c *client) DeleteFirewallRule(project, name string) error {
 }
 
 // DeleteImage deletes a GCE image.
-func (c *client) DeleteImage(project, name string) error {
+func (c *client) DeleteImage(project, name string) (err error) {
+	defer wrapResourceError(&err, "delete image", project, name)
 	op, err := c.Retry(c.raw.Images.Delete(project, name).Do)
 	if err != nil {
 		return err
@@ -1065,7 +3098,8 @@ func (c *client) DeleteImage(project, name string) error {
 }
 
 // DeleteDisk deletes a GCE persistent disk.
-func (c *client) DeleteDisk(project, zone, name string) error {
+func (c *client) DeleteDisk(project, zone, name string) (err error) {
+	defer wrapResourceError(&err, "delete disk", project, zone, name)
 	op, err := c.Retry(c.raw.Disks.Delete(project, zone, name).Do)
 	if err != nil {
 		return err
@@ -1074,6 +3108,17 @@ func (c *client) DeleteDisk(project, zone, name string) error {
 	return c.i.zoneOperationsWait(project, zone, op.Name)
 }
 
+// DeleteRegionDisk deletes a regional GCE persistent disk.
+func (c *client) DeleteRegionDisk(project, region, name string) (err error) {
+	defer wrapResourceError(&err, "delete regional disk", project, region, name)
+	op, err := c.Retry(c.raw.RegionDisks.Delete(project, region, name).Do)
+	if err != nil {
+		return err
+	}
+
+	return c.i.regionOperationsWait(project, region, op.Name)
+}
+
 // SetDiskAutoDelete set auto-delete of an attached disk
 func (c *client) SetDiskAutoDelete(project, zone, instance string, autoDelete bool, deviceName string) error {
 	op, err := c.Retry(c.raw.Instances.SetDiskAutoDelete(project, zone, instance, autoDelete, deviceName).Do)
@@ -1085,7 +3130,8 @@ func (c *client) SetDiskAutoDelete(project, zone, instance string, autoDelete bo
 }
 
 // DeleteForwardingRule deletes a GCE ForwardingRule.
-func (c *client) DeleteForwardingRule(project, region, name string) error {
+func (c *client) DeleteForwardingRule(project, region, name string) (err error) {
+	defer wrapResourceError(&err, "delete forwarding rule", project, region, name)
 	op, err := c.Retry(c.raw.ForwardingRules.Delete(project, region, name).Do)
 	if err != nil {
 		return err
@@ -1095,7 +3141,8 @@ func (c *client) DeleteForwardingRule(project, region, name string) error {
 }
 
 // DeleteInstance deletes a GCE instance.
-func (c *client) DeleteInstance(project, zone, name string) error {
+func (c *client) DeleteInstance(project, zone, name string) (err error) {
+	defer wrapResourceError(&err, "delete instance", project, zone, name)
 	op, err := c.Retry(c.raw.Instances.Delete(project, zone, name).Do)
 	if err != nil {
 		return err
@@ -1104,6 +3151,235 @@ func (c *client) DeleteInstance(project, zone, name string) error {
 	return c.i.zoneOperationsWait(project, zone, op.Name)
 }
 
+// DeleteInstancesByFilter deletes all GCE instances in a zone matching a list
+// filter, e.g. "labels.daisy-workflow = foo". Instances are deleted
+// concurrently and on a best-effort basis: instances that are already gone are
+// ignored and all other errors are returned together.
+func (c *client) DeleteInstancesByFilter(project, zone, filter string) error {
+	is, err := c.i.ListInstances(project, zone, Filter(filter))
+	if err != nil {
+		return err
+	}
+
+	deletes := map[string]func() error{}
+	for _, i := range is {
+		name := i.Name
+		deletes[fmt.Sprintf("instance %q", name)] = func() error { return c.i.DeleteInstance(project, zone, name) }
+	}
+	return deleteAll(deletes)
+}
+
+// TeardownByLabel deletes all forwarding rules, instances, zonal and regional
+// disks, images and snapshots in a project that carry the label
+// labelKey=labelValue. Resources are deleted in dependency order, forwarding
+// rules and instances before the disks they use, on a best-effort basis:
+// resources that are already gone are ignored and all other errors are
+// returned together. Networks and firewall rules have no labels and so are
+// not discovered.
+func (c *client) TeardownByLabel(project, labelKey, labelValue string) error {
+	filter := Filter(fmt.Sprintf("labels.%s = %s", labelKey, labelValue))
+	var errs []error
+
+	frs, err := c.i.AggregatedListForwardingRules(project, filter)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("error listing forwarding rules: %v", err))
+	}
+	deletes := map[string]func() error{}
+	for _, fr := range frs {
+		region, name := path.Base(fr.Region), fr.Name
+		deletes[fmt.Sprintf("forwarding rule %s/%s/%s", project, region, name)] = func() error { return c.i.DeleteForwardingRule(project, region, name) }
+	}
+	if err := deleteAll(deletes); err != nil {
+		errs = append(errs, err)
+	}
+
+	is, err := c.i.AggregatedListInstancesByZone(project, filter)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("error listing instances: %v", err))
+	}
+	deletes = map[string]func() error{}
+	for zone, zis := range is {
+		for _, i := range zis {
+			zone, name := zone, i.Name
+			deletes[fmt.Sprintf("instance %s/%s/%s", project, zone, name)] = func() error { return c.i.DeleteInstance(project, zone, name) }
+		}
+	}
+	if err := deleteAll(deletes); err != nil {
+		errs = append(errs, err)
+	}
+
+	ds, err := c.i.AggregatedListDisksByZone(project, filter)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("error listing disks: %v", err))
+	}
+	deletes = map[string]func() error{}
+	for scope, zds := range ds {
+		for _, d := range zds {
+			name := d.Name
+			if region, ok := strings.CutPrefix(scope, "regions/"); ok {
+				deletes[fmt.Sprintf("regional disk %s/%s/%s", project, region, name)] = func() error { return c.i.DeleteRegionDisk(project, region, name) }
+				continue
+			}
+			zone := scope
+			deletes[fmt.Sprintf("disk %s/%s/%s", project, zone, name)] = func() error { return c.i.DeleteDisk(project, zone, name) }
+		}
+	}
+	if err := deleteAll(deletes); err != nil {
+		errs = append(errs, err)
+	}
+
+	ims, err := c.i.ListImages(project, filter)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("error listing images: %v", err))
+	}
+	ss, err := c.i.ListSnapshots(project, filter)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("error listing snapshots: %v", err))
+	}
+	deletes = map[string]func() error{}
+	for _, im := range ims {
+		name := im.Name
+		deletes[fmt.Sprintf("image %q", name)] = func() error { return c.i.DeleteImage(project, name) }
+	}
+	for _, s := range ss {
+		name := s.Name
+		deletes[fmt.Sprintf("snapshot %q", name)] = func() error { return c.i.DeleteSnapshot(project, name) }
+	}
+	if err := deleteAll(deletes); err != nil {
+		errs = append(errs, err)
+	}
+
+	return errors.Join(errs...)
+}
+
+// deleteAll runs deletes, keyed by a description of the resource, concurrently.
+// Resources that are already gone are ignored and all other errors are
+// returned together.
+func deleteAll(deletes map[string]func() error) error {
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var errs []error
+	for desc, del := range deletes {
+		wg.Add(1)
+		go func(desc string, del func() error) {
+			defer wg.Done()
+			if err := del(); err != nil && !IsNotFound(err) {
+				mu.Lock()
+				errs = append(errs, fmt.Errorf("error deleting %s: %v", desc, err))
+				mu.Unlock()
+			}
+		}(desc, del)
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// SetDeletionProtection enables or disables deletion protection on a GCE
+// instance. Protection must be disabled before the instance can be deleted.
+func (c *client) SetDeletionProtection(project, zone, instance string, enabled bool) error {
+	op, err := c.Retry(c.raw.Instances.SetDeletionProtection(project, zone, instance).DeletionProtection(enabled).Do)
+	if err != nil {
+		return err
+	}
+
+	return c.i.zoneOperationsWait(project, zone, op.Name)
+}
+
+// UpdateNetworkInterface patches the named network interface, e.g. "nic0", of
+// a GCE instance, for example to change its alias IP ranges. ni must carry the
+// interface's current Fingerprint; a stale fingerprint fails with a 412 error.
+func (c *client) UpdateNetworkInterface(project, zone, instance, networkInterface string, ni *compute.NetworkInterface) error {
+	op, err := c.Retry(c.raw.Instances.UpdateNetworkInterface(project, zone, instance, networkInterface, ni).Do)
+	if err != nil {
+		return err
+	}
+
+	return c.i.zoneOperationsWait(project, zone, op.Name)
+}
+
+// GetEffectiveFirewalls gets the firewall rules and firewall policies that
+// apply to a network interface of a GCE instance, such as "nic0".
+func (c *client) GetEffectiveFirewalls(project, zone, instance, networkInterface string) (_ *compute.InstancesGetEffectiveFirewallsResponse, err error) {
+	defer wrapResourceError(&err, "get effective firewalls of instance", project, zone, instance)
+	r, err := c.raw.Instances.GetEffectiveFirewalls(project, zone, instance, networkInterface).Do()
+	if c.shouldRetry(err, 2) {
+		return c.raw.Instances.GetEffectiveFirewalls(project, zone, instance, networkInterface).Do()
+	}
+	return r, err
+}
+
+// GetScreenshot gets a screenshot of a GCE instance's display, which must be
+// enabled on the instance. Contents holds the base64-encoded PNG image.
+func (c *client) GetScreenshot(project, zone, instance string) (_ *compute.Screenshot, err error) {
+	defer wrapResourceError(&err, "get screenshot of instance", project, zone, instance)
+	sc, err := c.raw.Instances.GetScreenshot(project, zone, instance).Do()
+	if c.shouldRetry(err, 2) {
+		return c.raw.Instances.GetScreenshot(project, zone, instance).Do()
+	}
+	return sc, err
+}
+
+// GetInstanceIamPolicy gets the IAM policy of a GCE instance.
+func (c *client) GetInstanceIamPolicy(project, zone, instance string) (_ *compute.Policy, err error) {
+	defer wrapResourceError(&err, "get IAM policy of instance", project, zone, instance)
+	p, err := c.raw.Instances.GetIamPolicy(project, zone, instance).Do()
+	if c.shouldRetry(err, 2) {
+		return c.raw.Instances.GetIamPolicy(project, zone, instance).Do()
+	}
+	return p, err
+}
+
+// SetInstanceIamPolicy replaces the IAM policy of a GCE instance. To avoid
+// overwriting concurrent changes, policy should be read with
+// GetInstanceIamPolicy and modified, keeping its Etag.
+func (c *client) SetInstanceIamPolicy(project, zone, instance string, policy *compute.Policy) (err error) {
+	defer wrapResourceError(&err, "set IAM policy of instance", project, zone, instance)
+	req := &compute.ZoneSetPolicyRequest{Policy: policy}
+	_, err = c.raw.Instances.SetIamPolicy(project, zone, instance, req).Do()
+	if c.shouldRetry(err, 2) {
+		_, err = c.raw.Instances.SetIamPolicy(project, zone, instance, req).Do()
+	}
+	return err
+}
+
+// TestInstanceIamPermissions returns the subset of permissions that the
+// caller has on a GCE instance.
+func (c *client) TestInstanceIamPermissions(project, zone, instance string, permissions []string) (_ []string, err error) {
+	defer wrapResourceError(&err, "test IAM permissions of instance", project, zone, instance)
+	req := &compute.TestPermissionsRequest{Permissions: permissions}
+	r, err := c.raw.Instances.TestIamPermissions(project, zone, instance, req).Do()
+	if c.shouldRetry(err, 2) {
+		r, err = c.raw.Instances.TestIamPermissions(project, zone, instance, req).Do()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return r.Permissions, nil
+}
+
+// GetShieldedInstanceIdentity gets the vTPM signing and encryption keys of a
+// Shielded VM GCE instance.
+func (c *client) GetShieldedInstanceIdentity(project, zone, instance string) (_ *compute.ShieldedInstanceIdentity, err error) {
+	defer wrapResourceError(&err, "get shielded instance identity of instance", project, zone, instance)
+	sii, err := c.raw.Instances.GetShieldedInstanceIdentity(project, zone, instance).Do()
+	if c.shouldRetry(err, 2) {
+		return c.raw.Instances.GetShieldedInstanceIdentity(project, zone, instance).Do()
+	}
+	return sii, err
+}
+
+// SetShieldedInstanceIntegrityPolicy sets the integrity policy of a Shielded
+// VM GCE instance. Setting UpdateAutoLearnPolicy resets the integrity
+// baseline to the instance's current boot measurements.
+func (c *client) SetShieldedInstanceIntegrityPolicy(project, zone, instance string, p *compute.ShieldedInstanceIntegrityPolicy) error {
+	op, err := c.Retry(c.raw.Instances.SetShieldedInstanceIntegrityPolicy(project, zone, instance, p).Do)
+	if err != nil {
+		return err
+	}
+
+	return c.i.zoneOperationsWait(project, zone, op.Name)
+}
+
 // StartInstance starts a GCE instance.
 func (c *client) StartInstance(project, zone, name string) error {
 	op, err := c.Retry(c.raw.Instances.Start(project, zone, name).Do)
@@ -1124,8 +3400,21 @@ func (c *client) StopInstance(project, zone, name string) error {
 	return c.i.zoneOperationsWait(project, zone, op.Name)
 }
 
+// StopInstanceWithOptions stops a GCE instance. If discardLocalSSD is set,
+// the contents of its local SSDs are discarded, which is required to stop an
+// instance that has local SSDs.
+func (c *client) StopInstanceWithOptions(project, zone, name string, discardLocalSSD bool) error {
+	op, err := c.Retry(c.raw.Instances.Stop(project, zone, name).DiscardLocalSsd(discardLocalSSD).Do)
+	if err != nil {
+		return err
+	}
+
+	return c.i.zoneOperationsWait(project, zone, op.Name)
+}
+
 // DeleteNetwork deletes a GCE network.
-func (c *client) DeleteNetwork(project, name string) error {
+func (c *client) DeleteNetwork(project, name string) (err error) {
+	defer wrapResourceError(&err, "delete network", project, name)
 	op, err := c.Retry(c.raw.Networks.Delete(project, name).Do)
 	if err != nil {
 		return err
@@ -1135,7 +3424,8 @@ func (c *client) DeleteNetwork(project, name string) error {
 }
 
 // DeleteSubnetwork deletes a GCE subnetwork.
-func (c *client) DeleteSubnetwork(project, region, name string) error {
+func (c *client) DeleteSubnetwork(project, region, name string) (err error) {
+	defer wrapResourceError(&err, "delete subnetwork", project, region, name)
 	op, err := c.Retry(c.raw.Subnetworks.Delete(project, region, name).Do)
 	if err != nil {
 		return err
@@ -1145,7 +3435,8 @@ func (c *client) DeleteSubnetwork(project, region, name string) error {
 }
 
 // DeleteTargetInstance deletes a GCE TargetInstance.
-func (c *client) DeleteTargetInstance(project, zone, name string) error {
+func (c *client) DeleteTargetInstance(project, zone, name string) (err error) {
+	defer wrapResourceError(&err, "delete target instance", project, zone, name)
 	op, err := c.Retry(c.raw.TargetInstances.Delete(project, zone, name).Do)
 	if err != nil {
 		return err
@@ -1164,19 +3455,53 @@ func (c *client) DeprecateImage(project, name string, deprecationstatus *compute
 	return c.i.globalOperationsWait(project, op.Name)
 }
 
+// DeprecateImageFamily sets status on all images in an image family except
+// the keepLatest most recently created ones. Images are deprecated on a
+// best-effort basis and all errors are returned together.
+func (c *client) DeprecateImageFamily(project, family string, keepLatest int, status *compute.DeprecationStatus) error {
+	if keepLatest < 0 {
+		return fmt.Errorf("keepLatest must not be negative, got %d", keepLatest)
+	}
+	ims, err := c.i.ListImages(project, Filter(fmt.Sprintf("family = %s", family)))
+	if err != nil {
+		return err
+	}
+	created := map[string]time.Time{}
+	for _, im := range ims {
+		t, err := time.Parse(time.RFC3339, im.CreationTimestamp)
+		if err != nil {
+			return fmt.Errorf("error parsing creation time of image %q: %v", im.Name, err)
+		}
+		created[im.Name] = t
+	}
+	sort.SliceStable(ims, func(i, j int) bool { return created[ims[i].Name].After(created[ims[j].Name]) })
+	if len(ims) <= keepLatest {
+		return nil
+	}
+
+	var errs []error
+	for _, im := range ims[keepLatest:] {
+		if err := c.i.DeprecateImage(project, im.Name, status); err != nil {
+			errs = append(errs, fmt.Errorf("error deprecating image %q: %v", im.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
 // DeprecateImageAlpha sets deprecation status on a GCE image using the Alpha API.
 func (c *client) DeprecateImageAlpha(project, name string, deprecationstatus *computeAlpha.DeprecationStatus) error {
 	op, err := c.RetryAlpha(c.rawAlpha.Images.Deprecate(project, name, deprecationstatus).Do)
 	if err != nil {
 		return err
 	}
-	return c.i.globalOperationsWait(project, op.Name)
+	return c.i.globalOperationsWaitAlpha(project, op.Name)
 }
 
 // GetMachineType gets a GCE MachineType.
-func (c *client) GetMachineType(project, zone, machineType string) (*compute.MachineType, error) {
+func (c *client) GetMachineType(project, zone, machineType string) (_ *compute.MachineType, err error) {
+	defer wrapResourceError(&err, "get machine type", project, zone, machineType)
 	mt, err := c.raw.MachineTypes.Get(project, zone, machineType).Do()
-	if shouldRetryWithWait(c.hc.Transport, err, 2) {
+	if c.shouldRetry(err, 2) {
 		return c.raw.MachineTypes.Get(project, zone, machineType).Do()
 	}
 	return mt, err
@@ -1190,8 +3515,9 @@ func (c *client) ListMachineTypes(project, zone string, opts ...ListCallOption)
 	for _, opt := range opts {
 		call = opt.listCallOptionApply(call).(*compute.MachineTypesListCall)
 	}
+	limit := listLimit(opts)
 	for mtl, err := call.PageToken(pt).Do(); ; mtl, err = call.PageToken(pt).Do() {
-		if shouldRetryWithWait(c.hc.Transport, err, 2) {
+		if c.shouldRetry(err, 2) {
 			mtl, err = call.PageToken(pt).Do()
 		}
 		if err != nil {
@@ -1199,6 +3525,9 @@ func (c *client) ListMachineTypes(project, zone string, opts ...ListCallOption)
 		}
 		mts = append(mts, mtl.Items...)
 
+		if limit > 0 && len(mts) >= limit {
+			return mts[:limit], nil
+		}
 		if mtl.NextPageToken == "" {
 			return mts, nil
 		}
@@ -1206,34 +3535,94 @@ func (c *client) ListMachineTypes(project, zone string, opts ...ListCallOption)
 	}
 }
 
+// GetReservation gets a GCE Reservation.
+func (c *client) GetReservation(project, zone, name string) (_ *compute.Reservation, err error) {
+	defer wrapResourceError(&err, "get reservation", project, zone, name)
+	r, err := c.raw.Reservations.Get(project, zone, name).Do()
+	if c.shouldRetry(err, 2) {
+		return c.raw.Reservations.Get(project, zone, name).Do()
+	}
+	return r, err
+}
+
+// GetAcceleratorType gets a GCE AcceleratorType.
+func (c *client) GetAcceleratorType(project, zone, acceleratorType string) (_ *compute.AcceleratorType, err error) {
+	defer wrapResourceError(&err, "get accelerator type", project, zone, acceleratorType)
+	at, err := c.raw.AcceleratorTypes.Get(project, zone, acceleratorType).Do()
+	if c.shouldRetry(err, 2) {
+		return c.raw.AcceleratorTypes.Get(project, zone, acceleratorType).Do()
+	}
+	return at, err
+}
+
+// ListAcceleratorTypes gets a list of GCE AcceleratorTypes.
+func (c *client) ListAcceleratorTypes(project, zone string, opts ...ListCallOption) ([]*compute.AcceleratorType, error) {
+	var ats []*compute.AcceleratorType
+	var pt string
+	call := c.raw.AcceleratorTypes.List(project, zone)
+	for _, opt := range opts {
+		call = opt.listCallOptionApply(call).(*compute.AcceleratorTypesListCall)
+	}
+	limit := listLimit(opts)
+	for atl, err := call.PageToken(pt).Do(); ; atl, err = call.PageToken(pt).Do() {
+		if c.shouldRetry(err, 2) {
+			atl, err = call.PageToken(pt).Do()
+		}
+		if err != nil {
+			return nil, err
+		}
+		ats = append(ats, atl.Items...)
+
+		if limit > 0 && len(ats) >= limit {
+			return ats[:limit], nil
+		}
+		if atl.NextPageToken == "" {
+			return ats, nil
+		}
+		pt = atl.NextPageToken
+	}
+}
+
 // GetProject gets a GCE Project.
-func (c *client) GetProject(project string) (*compute.Project, error) {
+func (c *client) GetProject(project string) (_ *compute.Project, err error) {
+	defer wrapResourceError(&err, "get project", project)
 	p, err := c.raw.Projects.Get(project).Do()
-	if shouldRetryWithWait(c.hc.Transport, err, 2) {
+	if c.shouldRetry(err, 2) {
 		return c.raw.Projects.Get(project).Do()
 	}
 	return p, err
 }
 
 // GetSerialPortOutput gets the serial port output of a GCE instance.
-func (c *client) GetSerialPortOutput(project, zone, name string, port, start int64) (*compute.SerialPortOutput, error) {
+func (c *client) GetSerialPortOutput(project, zone, name string, port, start int64) (_ *compute.SerialPortOutput, err error) {
+	defer wrapResourceError(&err, "get serial port output of instance", project, zone, name)
 	sp, err := c.raw.Instances.GetSerialPortOutput(project, zone, name).Start(start).Port(port).Do()
-	if shouldRetryWithWait(c.hc.Transport, err, 2) {
+	if c.shouldRetry(err, 2) {
 		return c.raw.Instances.GetSerialPortOutput(project, zone, name).Start(start).Port(port).Do()
 	}
 	return sp, err
 }
 
+// SendDiagnosticInterrupt sends a diagnostic interrupt (NMI) to a GCE
+// instance, e.g. to make a hung guest write a crash dump. It is not retried,
+// since a second interrupt could disturb the dump.
+func (c *client) SendDiagnosticInterrupt(project, zone, instance string) (err error) {
+	defer wrapResourceError(&err, "send diagnostic interrupt to instance", project, zone, instance)
+	return c.raw.Instances.SendDiagnosticInterrupt(project, zone, instance).Do()
+}
+
 // GetZone gets a GCE Zone.
-func (c *client) GetZone(project, zone string) (*compute.Zone, error) {
+func (c *client) GetZone(project, zone string) (_ *compute.Zone, err error) {
+	defer wrapResourceError(&err, "get zone", project, zone)
 	z, err := c.raw.Zones.Get(project, zone).Do()
-	if shouldRetryWithWait(c.hc.Transport, err, 2) {
+	if c.shouldRetry(err, 2) {
 		return c.raw.Zones.Get(project, zone).Do()
 	}
 	return z, err
 }
 
-// ListZones gets a list GCE Zones.
+// ListZones gets a list GCE Zones. Zones that are unavailable can be excluded
+// with a Filter on their status, e.g. Filter("status = UP").
 func (c *client) ListZones(project string, opts ...ListCallOption) ([]*compute.Zone, error) {
 	var zs []*compute.Zone
 	var pt string
@@ -1241,8 +3630,9 @@ func (c *client) ListZones(project string, opts ...ListCallOption) ([]*compute.Z
 	for _, opt := range opts {
 		call = opt.listCallOptionApply(call).(*compute.ZonesListCall)
 	}
+	limit := listLimit(opts)
 	for zl, err := call.PageToken(pt).Do(); ; zl, err = call.PageToken(pt).Do() {
-		if shouldRetryWithWait(c.hc.Transport, err, 2) {
+		if c.shouldRetry(err, 2) {
 			zl, err = call.PageToken(pt).Do()
 		}
 		if err != nil {
@@ -1250,6 +3640,9 @@ func (c *client) ListZones(project string, opts ...ListCallOption) ([]*compute.Z
 		}
 		zs = append(zs, zl.Items...)
 
+		if limit > 0 && len(zs) >= limit {
+			return zs[:limit], nil
+		}
 		if zl.NextPageToken == "" {
 			return zs, nil
 		}
@@ -1257,6 +3650,27 @@ func (c *client) ListZones(project string, opts ...ListCallOption) ([]*compute.Z
 	}
 }
 
+// PickZone returns the first zone, by name, in region that is UP and offers
+// machineType.
+func (c *client) PickZone(project, region, machineType string) (string, error) {
+	zs, err := c.i.ListZones(project, Filter("status = UP"))
+	if err != nil {
+		return "", err
+	}
+	sort.Slice(zs, func(i, j int) bool { return zs[i].Name < zs[j].Name })
+	for _, z := range zs {
+		if path.Base(z.Region) != region {
+			continue
+		}
+		if _, err := c.i.GetMachineType(project, z.Name, machineType); err == nil {
+			return z.Name, nil
+		} else if !IsNotFound(err) {
+			return "", err
+		}
+	}
+	return "", fmt.Errorf("no zone in region %q is UP and offers machine type %q", region, machineType)
+}
+
 // ListRegions gets a list GCE Regions.
 func (c *client) ListRegions(project string, opts ...ListCallOption) ([]*compute.Region, error) {
 	var rs []*compute.Region
@@ -1265,8 +3679,9 @@ func (c *client) ListRegions(project string, opts ...ListCallOption) ([]*compute
 	for _, opt := range opts {
 		call = opt.listCallOptionApply(call).(*compute.RegionsListCall)
 	}
+	limit := listLimit(opts)
 	for rl, err := call.PageToken(pt).Do(); ; rl, err = call.PageToken(pt).Do() {
-		if shouldRetryWithWait(c.hc.Transport, err, 2) {
+		if c.shouldRetry(err, 2) {
 			rl, err = call.PageToken(pt).Do()
 		}
 		if err != nil {
@@ -1274,6 +3689,9 @@ func (c *client) ListRegions(project string, opts ...ListCallOption) ([]*compute
 		}
 		rs = append(rs, rl.Items...)
 
+		if limit > 0 && len(rs) >= limit {
+			return rs[:limit], nil
+		}
 		if rl.NextPageToken == "" {
 			return rs, nil
 		}
@@ -1282,27 +3700,30 @@ func (c *client) ListRegions(project string, opts ...ListCallOption) ([]*compute
 }
 
 // GetInstance gets a GCE Instance using GA API.
-func (c *client) GetInstance(project, zone, name string) (*compute.Instance, error) {
+func (c *client) GetInstance(project, zone, name string) (_ *compute.Instance, err error) {
+	defer wrapResourceError(&err, "get instance", project, zone, name)
 	i, err := c.raw.Instances.Get(project, zone, name).Do()
-	if shouldRetryWithWait(c.hc.Transport, err, 2) {
+	if c.shouldRetry(err, 2) {
 		return c.raw.Instances.Get(project, zone, name).Do()
 	}
 	return i, err
 }
 
 // GetInstanceAlpha gets a GCE Instance using Alpha API.
-func (c *client) GetInstanceAlpha(project, zone, name string) (*computeAlpha.Instance, error) {
+func (c *client) GetInstanceAlpha(project, zone, name string) (_ *computeAlpha.Instance, err error) {
+	defer wrapResourceError(&err, "get instance", project, zone, name)
 	i, err := c.rawAlpha.Instances.Get(project, zone, name).Do()
-	if shouldRetryWithWait(c.hc.Transport, err, 2) {
+	if c.shouldRetry(err, 2) {
 		return c.rawAlpha.Instances.Get(project, zone, name).Do()
 	}
 	return i, err
 }
 
 // GetInstanceBeta gets a GCE Instance using Beta API.
-func (c *client) GetInstanceBeta(project, zone, name string) (*computeBeta.Instance, error) {
+func (c *client) GetInstanceBeta(project, zone, name string) (_ *computeBeta.Instance, err error) {
+	defer wrapResourceError(&err, "get instance", project, zone, name)
 	i, err := c.rawBeta.Instances.Get(project, zone, name).Do()
-	if shouldRetryWithWait(c.hc.Transport, err, 2) {
+	if c.shouldRetry(err, 2) {
 		return c.rawBeta.Instances.Get(project, zone, name).Do()
 	}
 	return i, err
@@ -1316,8 +3737,9 @@ func (c *client) AggregatedListInstances(project string, opts ...ListCallOption)
 	for _, opt := range opts {
 		call = opt.listCallOptionApply(call).(*compute.InstancesAggregatedListCall)
 	}
+	limit := listLimit(opts)
 	for ial, err := call.PageToken(pt).Do(); ; ial, err = call.PageToken(pt).Do() {
-		if shouldRetryWithWait(c.hc.Transport, err, 2) {
+		if c.shouldRetry(err, 2) {
 			ial, err = call.PageToken(pt).Do()
 		}
 		if err != nil {
@@ -1326,6 +3748,48 @@ func (c *client) AggregatedListInstances(project string, opts ...ListCallOption)
 		for _, isl := range ial.Items {
 			is = append(is, isl.Instances...)
 		}
+		if limit > 0 && len(is) >= limit {
+			return is[:limit], nil
+		}
+		if ial.NextPageToken == "" {
+			return is, nil
+		}
+		pt = ial.NextPageToken
+	}
+}
+
+// AggregatedListInstancesByZone gets a list of GCE Instances in all zones,
+// keyed by zone name. Zones without instances are omitted.
+func (c *client) AggregatedListInstancesByZone(project string, opts ...ListCallOption) (map[string][]*compute.Instance, error) {
+	is := map[string][]*compute.Instance{}
+	var n int
+	var pt string
+	call := c.raw.Instances.AggregatedList(project)
+	for _, opt := range opts {
+		call = opt.listCallOptionApply(call).(*compute.InstancesAggregatedListCall)
+	}
+	limit := listLimit(opts)
+	for ial, err := call.PageToken(pt).Do(); ; ial, err = call.PageToken(pt).Do() {
+		if c.shouldRetry(err, 2) {
+			ial, err = call.PageToken(pt).Do()
+		}
+		if err != nil {
+			return nil, err
+		}
+		for scope, isl := range ial.Items {
+			items := isl.Instances
+			if limit > 0 && n+len(items) > limit {
+				items = items[:limit-n]
+			}
+			if len(items) > 0 {
+				zone := strings.TrimPrefix(scope, "zones/")
+				is[zone] = append(is[zone], items...)
+				n += len(items)
+			}
+		}
+		if limit > 0 && n >= limit {
+			return is, nil
+		}
 		if ial.NextPageToken == "" {
 			return is, nil
 		}
@@ -1341,8 +3805,9 @@ func (c *client) ListInstances(project, zone string, opts ...ListCallOption) ([]
 	for _, opt := range opts {
 		call = opt.listCallOptionApply(call).(*compute.InstancesListCall)
 	}
+	limit := listLimit(opts)
 	for il, err := call.PageToken(pt).Do(); ; il, err = call.PageToken(pt).Do() {
-		if shouldRetryWithWait(c.hc.Transport, err, 2) {
+		if c.shouldRetry(err, 2) {
 			il, err = call.PageToken(pt).Do()
 		}
 		if err != nil {
@@ -1350,6 +3815,9 @@ func (c *client) ListInstances(project, zone string, opts ...ListCallOption) ([]
 		}
 		is = append(is, il.Items...)
 
+		if limit > 0 && len(is) >= limit {
+			return is[:limit], nil
+		}
 		if il.NextPageToken == "" {
 			return is, nil
 		}
@@ -1357,28 +3825,64 @@ func (c *client) ListInstances(project, zone string, opts ...ListCallOption) ([]
 	}
 }
 
+// ListInstancesByStatus gets a list of GCE Instances in a zone with the given
+// status, such as StatusRunning, filtered by the API.
+func (c *client) ListInstancesByStatus(project, zone, status string) ([]*compute.Instance, error) {
+	return c.i.ListInstances(project, zone, Filter(fmt.Sprintf("status = %s", status)))
+}
+
+// ListAttachedAccelerators returns the guest accelerators attached to the
+// instances in a zone, keyed by instance name. Instances without accelerators
+// are omitted.
+func (c *client) ListAttachedAccelerators(project, zone string) (map[string][]*compute.AcceleratorConfig, error) {
+	is, err := c.i.ListInstances(project, zone)
+	if err != nil {
+		return nil, err
+	}
+	acs := map[string][]*compute.AcceleratorConfig{}
+	for _, i := range is {
+		if len(i.GuestAccelerators) > 0 {
+			acs[i.Name] = i.GuestAccelerators
+		}
+	}
+	return acs, nil
+}
+
 // GetDisk gets a GCE Disk.
-func (c *client) GetDisk(project, zone, name string) (*compute.Disk, error) {
+func (c *client) GetDisk(project, zone, name string) (_ *compute.Disk, err error) {
+	defer wrapResourceError(&err, "get disk", project, zone, name)
 	d, err := c.raw.Disks.Get(project, zone, name).Do()
-	if shouldRetryWithWait(c.hc.Transport, err, 2) {
+	if c.shouldRetry(err, 2) {
 		return c.raw.Disks.Get(project, zone, name).Do()
 	}
 	return d, err
 }
 
+// GetRegionDisk gets a regional GCE Disk.
+func (c *client) GetRegionDisk(project, region, name string) (_ *compute.Disk, err error) {
+	defer wrapResourceError(&err, "get regional disk", project, region, name)
+	d, err := c.raw.RegionDisks.Get(project, region, name).Do()
+	if c.shouldRetry(err, 2) {
+		return c.raw.RegionDisks.Get(project, region, name).Do()
+	}
+	return d, err
+}
+
 // GetDiskAlpha gets a GCE Disk.
-func (c *client) GetDiskAlpha(project, zone, name string) (*computeAlpha.Disk, error) {
+func (c *client) GetDiskAlpha(project, zone, name string) (_ *computeAlpha.Disk, err error) {
+	defer wrapResourceError(&err, "get disk", project, zone, name)
 	d, err := c.rawAlpha.Disks.Get(project, zone, name).Do()
-	if shouldRetryWithWait(c.hc.Transport, err, 2) {
+	if c.shouldRetry(err, 2) {
 		return c.rawAlpha.Disks.Get(project, zone, name).Do()
 	}
 	return d, err
 }
 
 // GetDiskBeta gets a GCE Disk.
-func (c *client) GetDiskBeta(project, zone, name string) (*computeBeta.Disk, error) {
+func (c *client) GetDiskBeta(project, zone, name string) (_ *computeBeta.Disk, err error) {
+	defer wrapResourceError(&err, "get disk", project, zone, name)
 	d, err := c.rawBeta.Disks.Get(project, zone, name).Do()
-	if shouldRetryWithWait(c.hc.Transport, err, 2) {
+	if c.shouldRetry(err, 2) {
 		return c.rawBeta.Disks.Get(project, zone, name).Do()
 	}
 	return d, err
@@ -1392,21 +3896,106 @@ func (c *client) AggregatedListDisks(project string, opts ...ListCallOption) ([]
 	for _, opt := range opts {
 		call = opt.listCallOptionApply(call).(*compute.DisksAggregatedListCall)
 	}
-	for ial, err := call.PageToken(pt).Do(); ; ial, err = call.PageToken(pt).Do() {
-		if shouldRetryWithWait(c.hc.Transport, err, 2) {
-			ial, err = call.PageToken(pt).Do()
+	limit := listLimit(opts)
+	for ial, err := call.PageToken(pt).Do(); ; ial, err = call.PageToken(pt).Do() {
+		if c.shouldRetry(err, 2) {
+			ial, err = call.PageToken(pt).Do()
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, isl := range ial.Items {
+			is = append(is, isl.Disks...)
+		}
+		if limit > 0 && len(is) >= limit {
+			return is[:limit], nil
+		}
+		if ial.NextPageToken == "" {
+			return is, nil
+		}
+		pt = ial.NextPageToken
+	}
+}
+
+// AggregatedListDisksByZone gets a list of GCE Disks in all zones, keyed by
+// zone name. Regional disks are keyed by their scope, e.g. "regions/us-east1".
+// Scopes without disks are omitted.
+func (c *client) AggregatedListDisksByZone(project string, opts ...ListCallOption) (map[string][]*compute.Disk, error) {
+	ds := map[string][]*compute.Disk{}
+	var n int
+	var pt string
+	call := c.raw.Disks.AggregatedList(project)
+	for _, opt := range opts {
+		call = opt.listCallOptionApply(call).(*compute.DisksAggregatedListCall)
+	}
+	limit := listLimit(opts)
+	for dal, err := call.PageToken(pt).Do(); ; dal, err = call.PageToken(pt).Do() {
+		if c.shouldRetry(err, 2) {
+			dal, err = call.PageToken(pt).Do()
+		}
+		if err != nil {
+			return nil, err
+		}
+		for scope, dsl := range dal.Items {
+			items := dsl.Disks
+			if limit > 0 && n+len(items) > limit {
+				items = items[:limit-n]
+			}
+			if len(items) > 0 {
+				zone := strings.TrimPrefix(scope, "zones/")
+				ds[zone] = append(ds[zone], items...)
+				n += len(items)
+			}
+		}
+		if limit > 0 && n >= limit {
+			return ds, nil
+		}
+		if dal.NextPageToken == "" {
+			return ds, nil
+		}
+		pt = dal.NextPageToken
+	}
+}
+
+// GetInstanceReferrers gets the resources, such as instance groups, that
+// refer to a GCE instance.
+func (c *client) GetInstanceReferrers(project, zone, instance string) ([]*compute.Reference, error) {
+	var rs []*compute.Reference
+	var pt string
+	call := c.raw.Instances.ListReferrers(project, zone, instance)
+	for rl, err := call.PageToken(pt).Do(); ; rl, err = call.PageToken(pt).Do() {
+		if c.shouldRetry(err, 2) {
+			rl, err = call.PageToken(pt).Do()
 		}
 		if err != nil {
 			return nil, err
 		}
-		for _, isl := range ial.Items {
-			is = append(is, isl.Disks...)
+		rs = append(rs, rl.Items...)
+
+		if rl.NextPageToken == "" {
+			return rs, nil
 		}
-		if ial.NextPageToken == "" {
-			return is, nil
+		pt = rl.NextPageToken
+	}
+}
+
+// ListDiskUsers gets the GCE instances in a zone that have the named disk
+// attached.
+func (c *client) ListDiskUsers(project, zone, disk string) ([]*compute.Instance, error) {
+	is, err := c.i.ListInstances(project, zone)
+	if err != nil {
+		return nil, err
+	}
+	var users []*compute.Instance
+	for _, i := range is {
+		for _, ad := range i.Disks {
+			if strings.HasSuffix(ad.Source, "/disks/"+disk) {
+				users = append(users, i)
+				break
+			}
 		}
-		pt = ial.NextPageToken
 	}
+	return users, nil
 }
 
 // ListDisks gets a list of GCE Disks.
@@ -1417,8 +4006,9 @@ func (c *client) ListDisks(project, zone string, opts ...ListCallOption) ([]*com
 	for _, opt := range opts {
 		call = opt.listCallOptionApply(call).(*compute.DisksListCall)
 	}
+	limit := listLimit(opts)
 	for dl, err := call.PageToken(pt).Do(); ; dl, err = call.PageToken(pt).Do() {
-		if shouldRetryWithWait(c.hc.Transport, err, 2) {
+		if c.shouldRetry(err, 2) {
 			dl, err = call.PageToken(pt).Do()
 		}
 		if err != nil {
@@ -1426,6 +4016,9 @@ func (c *client) ListDisks(project, zone string, opts ...ListCallOption) ([]*com
 		}
 		ds = append(ds, dl.Items...)
 
+		if limit > 0 && len(ds) >= limit {
+			return ds[:limit], nil
+		}
 		if dl.NextPageToken == "" {
 			return ds, nil
 		}
@@ -1433,10 +4026,46 @@ func (c *client) ListDisks(project, zone string, opts ...ListCallOption) ([]*com
 	}
 }
 
+// ListUnattachedDisks gets the GCE disks in a zone that are not attached to
+// any instance. Disk users are updated asynchronously, so a disk that was
+// just detached may not be listed yet, and one that was just attached may
+// still be listed.
+func (c *client) ListUnattachedDisks(project, zone string) ([]*compute.Disk, error) {
+	ds, err := c.i.ListDisks(project, zone)
+	if err != nil {
+		return nil, err
+	}
+	var unattached []*compute.Disk
+	for _, d := range ds {
+		if len(d.Users) == 0 {
+			unattached = append(unattached, d)
+		}
+	}
+	return unattached, nil
+}
+
+// DeleteUnattachedDisks deletes the disks listed by ListUnattachedDisks.
+// Disks are deleted concurrently and on a best-effort basis: disks that are
+// already gone are ignored and all other errors, including for disks that
+// were attached since they were listed, are returned together.
+func (c *client) DeleteUnattachedDisks(project, zone string) error {
+	ds, err := c.i.ListUnattachedDisks(project, zone)
+	if err != nil {
+		return err
+	}
+	deletes := map[string]func() error{}
+	for _, d := range ds {
+		name := d.Name
+		deletes[fmt.Sprintf("disk %q", name)] = func() error { return c.i.DeleteDisk(project, zone, name) }
+	}
+	return deleteAll(deletes)
+}
+
 // GetForwardingRule gets a GCE ForwardingRule.
-func (c *client) GetForwardingRule(project, region, name string) (*compute.ForwardingRule, error) {
+func (c *client) GetForwardingRule(project, region, name string) (_ *compute.ForwardingRule, err error) {
+	defer wrapResourceError(&err, "get forwarding rule", project, region, name)
 	n, err := c.raw.ForwardingRules.Get(project, region, name).Do()
-	if shouldRetryWithWait(c.hc.Transport, err, 2) {
+	if c.shouldRetry(err, 2) {
 		return c.raw.ForwardingRules.Get(project, region, name).Do()
 	}
 	return n, err
@@ -1450,8 +4079,9 @@ func (c *client) AggregatedListForwardingRules(project string, opts ...ListCallO
 	for _, opt := range opts {
 		call = opt.listCallOptionApply(call).(*compute.ForwardingRulesAggregatedListCall)
 	}
+	limit := listLimit(opts)
 	for ail, err := call.PageToken(pt).Do(); ; ail, err = call.PageToken(pt).Do() {
-		if shouldRetryWithWait(c.hc.Transport, err, 2) {
+		if c.shouldRetry(err, 2) {
 			ail, err = call.PageToken(pt).Do()
 		}
 		if err != nil {
@@ -1460,6 +4090,9 @@ func (c *client) AggregatedListForwardingRules(project string, opts ...ListCallO
 		for _, frl := range ail.Items {
 			frs = append(frs, frl.ForwardingRules...)
 		}
+		if limit > 0 && len(frs) >= limit {
+			return frs[:limit], nil
+		}
 		if ail.NextPageToken == "" {
 			return frs, nil
 		}
@@ -1475,8 +4108,9 @@ func (c *client) ListForwardingRules(project, region string, opts ...ListCallOpt
 	for _, opt := range opts {
 		call = opt.listCallOptionApply(call).(*compute.ForwardingRulesListCall)
 	}
+	limit := listLimit(opts)
 	for frl, err := call.PageToken(pt).Do(); ; frl, err = call.PageToken(pt).Do() {
-		if shouldRetryWithWait(c.hc.Transport, err, 2) {
+		if c.shouldRetry(err, 2) {
 			frl, err = call.PageToken(pt).Do()
 		}
 		if err != nil {
@@ -1484,6 +4118,9 @@ func (c *client) ListForwardingRules(project, region string, opts ...ListCallOpt
 		}
 		frs = append(frs, frl.Items...)
 
+		if limit > 0 && len(frs) >= limit {
+			return frs[:limit], nil
+		}
 		if frl.NextPageToken == "" {
 			return frs, nil
 		}
@@ -1492,14 +4129,25 @@ func (c *client) ListForwardingRules(project, region string, opts ...ListCallOpt
 }
 
 // GetFirewallRule gets a GCE FirewallRule.
-func (c *client) GetFirewallRule(project, name string) (*compute.Firewall, error) {
+func (c *client) GetFirewallRule(project, name string) (_ *compute.Firewall, err error) {
+	defer wrapResourceError(&err, "get firewall rule", project, name)
 	i, err := c.raw.Firewalls.Get(project, name).Do()
-	if shouldRetryWithWait(c.hc.Transport, err, 2) {
+	if c.shouldRetry(err, 2) {
 		return c.raw.Firewalls.Get(project, name).Do()
 	}
 	return i, err
 }
 
+// GetFirewallRuleBeta gets a GCE FirewallRule using Beta API.
+func (c *client) GetFirewallRuleBeta(project, name string) (_ *computeBeta.Firewall, err error) {
+	defer wrapResourceError(&err, "get firewall rule", project, name)
+	f, err := c.rawBeta.Firewalls.Get(project, name).Do()
+	if c.shouldRetry(err, 2) {
+		return c.rawBeta.Firewalls.Get(project, name).Do()
+	}
+	return f, err
+}
+
 // ListFirewallRules gets a list of GCE FirewallRules.
 func (c *client) ListFirewallRules(project string, opts ...ListCallOption) ([]*compute.Firewall, error) {
 	var is []*compute.Firewall
@@ -1508,8 +4156,9 @@ func (c *client) ListFirewallRules(project string, opts ...ListCallOption) ([]*c
 	for _, opt := range opts {
 		call = opt.listCallOptionApply(call).(*compute.FirewallsListCall)
 	}
+	limit := listLimit(opts)
 	for il, err := call.PageToken(pt).Do(); ; il, err = call.PageToken(pt).Do() {
-		if shouldRetryWithWait(c.hc.Transport, err, 2) {
+		if c.shouldRetry(err, 2) {
 			il, err = call.PageToken(pt).Do()
 		}
 		if err != nil {
@@ -1517,6 +4166,9 @@ func (c *client) ListFirewallRules(project string, opts ...ListCallOption) ([]*c
 		}
 		is = append(is, il.Items...)
 
+		if limit > 0 && len(is) >= limit {
+			return is[:limit], nil
+		}
 		if il.NextPageToken == "" {
 			return is, nil
 		}
@@ -1525,41 +4177,61 @@ func (c *client) ListFirewallRules(project string, opts ...ListCallOption) ([]*c
 }
 
 // GetImage gets a GCE Image.
-func (c *client) GetImage(project, name string) (*compute.Image, error) {
+func (c *client) GetImage(project, name string) (_ *compute.Image, err error) {
+	defer wrapResourceError(&err, "get image", project, name)
 	i, err := c.raw.Images.Get(project, name).Do()
-	if shouldRetryWithWait(c.hc.Transport, err, 2) {
+	if c.shouldRetry(err, 2) {
 		return c.raw.Images.Get(project, name).Do()
 	}
 	return i, err
 }
 
 // GetImageAlpha gets a GCE Image using Alpha API
-func (c *client) GetImageAlpha(project, name string) (*computeAlpha.Image, error) {
+func (c *client) GetImageAlpha(project, name string) (_ *computeAlpha.Image, err error) {
+	defer wrapResourceError(&err, "get image", project, name)
 	i, err := c.rawAlpha.Images.Get(project, name).Do()
-	if shouldRetryWithWait(c.hc.Transport, err, 2) {
+	if c.shouldRetry(err, 2) {
 		return c.rawAlpha.Images.Get(project, name).Do()
 	}
 	return i, err
 }
 
 // GetImageBeta gets a GCE Image using Beta API
-func (c *client) GetImageBeta(project, name string) (*computeBeta.Image, error) {
+func (c *client) GetImageBeta(project, name string) (_ *computeBeta.Image, err error) {
+	defer wrapResourceError(&err, "get image", project, name)
 	i, err := c.rawBeta.Images.Get(project, name).Do()
-	if shouldRetryWithWait(c.hc.Transport, err, 2) {
+	if c.shouldRetry(err, 2) {
 		return c.rawBeta.Images.Get(project, name).Do()
 	}
 	return i, err
 }
 
 // GetImageFromFamily gets a GCE Image from an image family.
-func (c *client) GetImageFromFamily(project, family string) (*compute.Image, error) {
+func (c *client) GetImageFromFamily(project, family string) (_ *compute.Image, err error) {
+	defer wrapResourceError(&err, "get image from family", project, family)
 	i, err := c.raw.Images.GetFromFamily(project, family).Do()
-	if shouldRetryWithWait(c.hc.Transport, err, 2) {
+	if c.shouldRetry(err, 2) {
 		return c.raw.Images.GetFromFamily(project, family).Do()
 	}
 	return i, err
 }
 
+// GetLatestImageFromFamilies gets the latest GCE Image in an image family
+// from the first of the given projects that has the family. Projects without
+// the family are skipped; any other error is returned immediately.
+func (c *client) GetLatestImageFromFamilies(projects []string, family string) (*compute.Image, error) {
+	for _, project := range projects {
+		i, err := c.i.GetImageFromFamily(project, family)
+		if err == nil {
+			return i, nil
+		}
+		if !IsNotFound(err) {
+			return nil, err
+		}
+	}
+	return nil, fmt.Errorf("image family %q not found in projects %q", family, projects)
+}
+
 // ListImages gets a list of GCE Images.
 func (c *client) ListImages(project string, opts ...ListCallOption) ([]*compute.Image, error) {
 	var is []*compute.Image
@@ -1568,8 +4240,9 @@ func (c *client) ListImages(project string, opts ...ListCallOption) ([]*compute.
 	for _, opt := range opts {
 		call = opt.listCallOptionApply(call).(*compute.ImagesListCall)
 	}
+	limit := listLimit(opts)
 	for il, err := call.PageToken(pt).Do(); ; il, err = call.PageToken(pt).Do() {
-		if shouldRetryWithWait(c.hc.Transport, err, 2) {
+		if c.shouldRetry(err, 2) {
 			il, err = call.PageToken(pt).Do()
 		}
 		if err != nil {
@@ -1577,6 +4250,9 @@ func (c *client) ListImages(project string, opts ...ListCallOption) ([]*compute.
 		}
 		is = append(is, il.Items...)
 
+		if limit > 0 && len(is) >= limit {
+			return is[:limit], nil
+		}
 		if il.NextPageToken == "" {
 			return is, nil
 		}
@@ -1593,8 +4269,9 @@ func (c *client) ListImagesAlpha(project string, opts ...ListCallOption) ([]*com
 	for _, opt := range opts {
 		call = opt.listCallOptionApply(call).(*computeAlpha.ImagesListCall)
 	}
+	limit := listLimit(opts)
 	for il, err := call.PageToken(pt).Do(); ; il, err = call.PageToken(pt).Do() {
-		if shouldRetryWithWait(c.hc.Transport, err, 2) {
+		if c.shouldRetry(err, 2) {
 			il, err = call.PageToken(pt).Do()
 		}
 		if err != nil {
@@ -1602,6 +4279,9 @@ func (c *client) ListImagesAlpha(project string, opts ...ListCallOption) ([]*com
 		}
 		is = append(is, il.Items...)
 
+		if limit > 0 && len(is) >= limit {
+			return is[:limit], nil
+		}
 		if il.NextPageToken == "" {
 			return is, nil
 		}
@@ -1611,7 +4291,13 @@ func (c *client) ListImagesAlpha(project string, opts ...ListCallOption) ([]*com
 
 // CreateSnapshot creates a GCE snapshot.
 // SourceDisk is the url (full or partial) to the source disk.
-func (c *client) CreateSnapshot(project, zone, disk string, s *compute.Snapshot) error {
+func (c *client) CreateSnapshot(project, zone, disk string, s *compute.Snapshot) (err error) {
+	defer wrapResourceError(&err, "create snapshot", project, s.Name)
+	if c.validate {
+		if err := ValidateLabels(s.Labels); err != nil {
+			return err
+		}
+	}
 	op, err := c.Retry(c.raw.Disks.CreateSnapshot(project, zone, disk, s).Do)
 	if err != nil {
 		return err
@@ -1621,6 +4307,10 @@ func (c *client) CreateSnapshot(project, zone, disk string, s *compute.Snapshot)
 		return err
 	}
 
+	if c.skipReadback {
+		return nil
+	}
+
 	var createdSnapshot *compute.Snapshot
 	if createdSnapshot, err = c.i.GetSnapshot(project, s.Name); err != nil {
 		return err
@@ -1630,16 +4320,30 @@ func (c *client) CreateSnapshot(project, zone, disk string, s *compute.Snapshot)
 }
 
 // GetSnapshot gets a GCE Snapshot.
-func (c *client) GetSnapshot(project, name string) (*compute.Snapshot, error) {
+func (c *client) GetSnapshot(project, name string) (_ *compute.Snapshot, err error) {
+	defer wrapResourceError(&err, "get snapshot", project, name)
 	n, err := c.raw.Snapshots.Get(project, name).Do()
-	if shouldRetryWithWait(c.hc.Transport, err, 2) {
+	if c.shouldRetry(err, 2) {
 		return c.raw.Snapshots.Get(project, name).Do()
 	}
 	return n, err
 }
 
+// SetSnapshotLabels replaces the labels of a GCE Snapshot. fingerprint must
+// be the snapshot's current LabelFingerprint.
+func (c *client) SetSnapshotLabels(project, name string, labels map[string]string, fingerprint string) error {
+	req := &compute.GlobalSetLabelsRequest{Labels: labels, LabelFingerprint: fingerprint}
+	op, err := c.Retry(c.raw.Snapshots.SetLabels(project, name, req).Do)
+	if err != nil {
+		return err
+	}
+
+	return c.i.globalOperationsWait(project, op.Name)
+}
+
 // DeleteSnapshot deletes a GCE Snapshot.
-func (c *client) DeleteSnapshot(project, name string) error {
+func (c *client) DeleteSnapshot(project, name string) (err error) {
+	defer wrapResourceError(&err, "delete snapshot", project, name)
 	op, err := c.Retry(c.raw.Snapshots.Delete(project, name).Do)
 	if err != nil {
 		return err
@@ -1656,8 +4360,9 @@ func (c *client) ListSnapshots(project string, opts ...ListCallOption) ([]*compu
 	for _, opt := range opts {
 		call = opt.listCallOptionApply(call).(*compute.SnapshotsListCall)
 	}
+	limit := listLimit(opts)
 	for sl, err := call.PageToken(pt).Do(); ; sl, err = call.PageToken(pt).Do() {
-		if shouldRetryWithWait(c.hc.Transport, err, 2) {
+		if c.shouldRetry(err, 2) {
 			sl, err = call.PageToken(pt).Do()
 		}
 		if err != nil {
@@ -1665,6 +4370,9 @@ func (c *client) ListSnapshots(project string, opts ...ListCallOption) ([]*compu
 		}
 		ss = append(ss, sl.Items...)
 
+		if limit > 0 && len(ss) >= limit {
+			return ss[:limit], nil
+		}
 		if sl.NextPageToken == "" {
 			return ss, nil
 		}
@@ -1672,19 +4380,22 @@ func (c *client) ListSnapshots(project string, opts ...ListCallOption) ([]*compu
 	}
 }
 
-// GetNetwork gets a GCE Network.
-func (c *client) GetNetwork(project, name string) (*compute.Network, error) {
+// GetNetwork gets a GCE Network. The network's Subnetworks field lists the
+// URLs of its subnetworks.
+func (c *client) GetNetwork(project, name string) (_ *compute.Network, err error) {
+	defer wrapResourceError(&err, "get network", project, name)
 	n, err := c.raw.Networks.Get(project, name).Do()
-	if shouldRetryWithWait(c.hc.Transport, err, 2) {
+	if c.shouldRetry(err, 2) {
 		return c.raw.Networks.Get(project, name).Do()
 	}
 	return n, err
 }
 
 // GetRegion gets a GCE Region
-func (c *client) GetRegion(project, name string) (*compute.Region, error) {
+func (c *client) GetRegion(project, name string) (_ *compute.Region, err error) {
+	defer wrapResourceError(&err, "get region", project, name)
 	n, err := c.raw.Regions.Get(project, name).Do()
-	if shouldRetryWithWait(c.hc.Transport, err, 2) {
+	if c.shouldRetry(err, 2) {
 		return c.raw.Regions.Get(project, name).Do()
 	}
 	return n, err
@@ -1695,7 +4406,7 @@ func (c *client) Suspend(project, zone, name string) error {
 	var op *compute.Operation
 	var err error
 	op, err = c.raw.Instances.Suspend(project, zone, name).Do()
-	if shouldRetryWithWait(c.hc.Transport, err, 2) {
+	if c.shouldRetry(err, 2) {
 		op, err = c.raw.Instances.Suspend(project, zone, name).Do()
 	}
 	if err != nil {
@@ -1704,12 +4415,27 @@ func (c *client) Suspend(project, zone, name string) error {
 	return c.i.zoneOperationsWait(project, zone, op.Name)
 }
 
-// Resume an instance
+// checkSuspended returns an error if an instance is not in a 'SUSPENDED' state.
+func (c *client) checkSuspended(project, zone, name string) error {
+	status, err := c.i.InstanceStatus(project, zone, name)
+	if err != nil {
+		return err
+	}
+	if status != StatusSuspended {
+		return fmt.Errorf("instance %q cannot be resumed, it is %s rather than SUSPENDED", name, status)
+	}
+	return nil
+}
+
+// Resume an instance. The instance must be in a 'SUSPENDED' state.
 func (c *client) Resume(project, zone, name string) error {
+	if err := c.checkSuspended(project, zone, name); err != nil {
+		return err
+	}
 	var op *compute.Operation
 	var err error
 	op, err = c.raw.Instances.Resume(project, zone, name).Do()
-	if shouldRetryWithWait(c.hc.Transport, err, 2) {
+	if c.shouldRetry(err, 2) {
 		op, err = c.raw.Instances.Resume(project, zone, name).Do()
 	}
 	if err != nil {
@@ -1718,6 +4444,116 @@ func (c *client) Resume(project, zone, name string) error {
 	return c.i.zoneOperationsWait(project, zone, op.Name)
 }
 
+// ResumeWithEncryptionKey resumes a suspended instance whose disks are
+// protected by customer-supplied encryption keys, using Beta API.
+func (c *client) ResumeWithEncryptionKey(project, zone, name string, req *computeBeta.InstancesResumeRequest) error {
+	if err := c.checkSuspended(project, zone, name); err != nil {
+		return err
+	}
+	op, err := c.RetryBeta(c.rawBeta.Instances.Resume(project, zone, name, req).Do)
+	if err != nil {
+		return err
+	}
+	return c.i.zoneOperationsWaitBeta(project, zone, op.Name)
+}
+
+// PerformMaintenance starts any pending host maintenance on an instance now,
+// instead of at a time chosen by GCE.
+func (c *client) PerformMaintenance(project, zone, instance string) error {
+	op, err := c.Retry(c.raw.Instances.PerformMaintenance(project, zone, instance).Do)
+	if err != nil {
+		return err
+	}
+	return c.i.zoneOperationsWait(project, zone, op.Name)
+}
+
+// SetInstanceName renames a GCE instance. The instance must be stopped.
+func (c *client) SetInstanceName(project, zone, instance, newName string) error {
+	req := &compute.InstancesSetNameRequest{CurrentName: instance, Name: newName}
+	op, err := c.Retry(c.raw.Instances.SetName(project, zone, instance, req).Do)
+	if err != nil {
+		return err
+	}
+	return c.i.zoneOperationsWait(project, zone, op.Name)
+}
+
+// UpdateInstance updates a GCE instance in place with instances.update. If
+// fields are given, only those top-level fields of i, named as in the API
+// (e.g. "scheduling" or "labels"), are updated and all other fields keep
+// their current values; otherwise the instance is replaced by i. Not all
+// fields can be updated in place, in which case the API's error is returned.
+func (c *client) UpdateInstance(project, zone string, i *compute.Instance, fields ...string) (err error) {
+	defer wrapResourceError(&err, "update instance", project, zone, i.Name)
+	update := i
+	if len(fields) > 0 {
+		cur, err := c.i.GetInstance(project, zone, i.Name)
+		if err != nil {
+			return err
+		}
+		if update, err = mergeInstanceFields(cur, i, fields); err != nil {
+			return err
+		}
+	}
+	op, err := c.Retry(c.raw.Instances.Update(project, zone, i.Name, update).Do)
+	if err != nil {
+		return err
+	}
+	return c.i.zoneOperationsWait(project, zone, op.Name)
+}
+
+// mergeInstanceFields returns a copy of cur with the given top-level fields,
+// by JSON name, taken from i. A field that is unset in i is cleared.
+func mergeInstanceFields(cur, i *compute.Instance, fields []string) (*compute.Instance, error) {
+	known := map[string]bool{}
+	t := reflect.TypeOf(compute.Instance{})
+	for n := 0; n < t.NumField(); n++ {
+		if name := strings.Split(t.Field(n).Tag.Get("json"), ",")[0]; name != "" && name != "-" {
+			known[name] = true
+		}
+	}
+
+	curFields, err := jsonFields(cur)
+	if err != nil {
+		return nil, err
+	}
+	newFields, err := jsonFields(i)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range fields {
+		if !known[f] {
+			return nil, fmt.Errorf("unknown instance field %q", f)
+		}
+		if v, ok := newFields[f]; ok {
+			curFields[f] = v
+		} else {
+			delete(curFields, f)
+		}
+	}
+
+	b, err := json.Marshal(curFields)
+	if err != nil {
+		return nil, err
+	}
+	merged := &compute.Instance{}
+	if err := json.Unmarshal(b, merged); err != nil {
+		return nil, err
+	}
+	return merged, nil
+}
+
+func jsonFields(v interface{}) (map[string]json.RawMessage, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // ListNetworks gets a list of GCE Networks.
 func (c *client) ListNetworks(project string, opts ...ListCallOption) ([]*compute.Network, error) {
 	var ns []*compute.Network
@@ -1726,8 +4562,9 @@ func (c *client) ListNetworks(project string, opts ...ListCallOption) ([]*comput
 	for _, opt := range opts {
 		call = opt.listCallOptionApply(call).(*compute.NetworksListCall)
 	}
+	limit := listLimit(opts)
 	for nl, err := call.PageToken(pt).Do(); ; nl, err = call.PageToken(pt).Do() {
-		if shouldRetryWithWait(c.hc.Transport, err, 2) {
+		if c.shouldRetry(err, 2) {
 			nl, err = call.PageToken(pt).Do()
 		}
 		if err != nil {
@@ -1735,6 +4572,9 @@ func (c *client) ListNetworks(project string, opts ...ListCallOption) ([]*comput
 		}
 		ns = append(ns, nl.Items...)
 
+		if limit > 0 && len(ns) >= limit {
+			return ns[:limit], nil
+		}
 		if nl.NextPageToken == "" {
 			return ns, nil
 		}
@@ -1743,9 +4583,10 @@ func (c *client) ListNetworks(project string, opts ...ListCallOption) ([]*comput
 }
 
 // GetSubnetwork gets a GCE subnetwork.
-func (c *client) GetSubnetwork(project, region, name string) (*compute.Subnetwork, error) {
+func (c *client) GetSubnetwork(project, region, name string) (_ *compute.Subnetwork, err error) {
+	defer wrapResourceError(&err, "get subnetwork", project, region, name)
 	n, err := c.raw.Subnetworks.Get(project, region, name).Do()
-	if shouldRetryWithWait(c.hc.Transport, err, 2) {
+	if c.shouldRetry(err, 2) {
 		return c.raw.Subnetworks.Get(project, region, name).Do()
 	}
 	return n, err
@@ -1759,8 +4600,9 @@ func (c *client) AggregatedListSubnetworks(project string, opts ...ListCallOptio
 	for _, opt := range opts {
 		call = opt.listCallOptionApply(call).(*compute.SubnetworksAggregatedListCall)
 	}
+	limit := listLimit(opts)
 	for sal, err := call.PageToken(pt).Do(); ; sal, err = call.PageToken(pt).Do() {
-		if shouldRetryWithWait(c.hc.Transport, err, 2) {
+		if c.shouldRetry(err, 2) {
 			sal, err = call.PageToken(pt).Do()
 		}
 		if err != nil {
@@ -1769,6 +4611,9 @@ func (c *client) AggregatedListSubnetworks(project string, opts ...ListCallOptio
 		for _, sl := range sal.Items {
 			ss = append(ss, sl.Subnetworks...)
 		}
+		if limit > 0 && len(ss) >= limit {
+			return ss[:limit], nil
+		}
 		if sal.NextPageToken == "" {
 			return ss, nil
 		}
@@ -1784,8 +4629,9 @@ func (c *client) ListSubnetworks(project, region string, opts ...ListCallOption)
 	for _, opt := range opts {
 		call = opt.listCallOptionApply(call).(*compute.SubnetworksListCall)
 	}
+	limit := listLimit(opts)
 	for nl, err := call.PageToken(pt).Do(); ; nl, err = call.PageToken(pt).Do() {
-		if shouldRetryWithWait(c.hc.Transport, err, 2) {
+		if c.shouldRetry(err, 2) {
 			nl, err = call.PageToken(pt).Do()
 		}
 		if err != nil {
@@ -1793,6 +4639,9 @@ func (c *client) ListSubnetworks(project, region string, opts ...ListCallOption)
 		}
 		ns = append(ns, nl.Items...)
 
+		if limit > 0 && len(ns) >= limit {
+			return ns[:limit], nil
+		}
 		if nl.NextPageToken == "" {
 			return ns, nil
 		}
@@ -1801,14 +4650,77 @@ func (c *client) ListSubnetworks(project, region string, opts ...ListCallOption)
 }
 
 // GetTargetInstance gets a GCE TargetInstance.
-func (c *client) GetTargetInstance(project, zone, name string) (*compute.TargetInstance, error) {
+func (c *client) GetTargetInstance(project, zone, name string) (_ *compute.TargetInstance, err error) {
+	defer wrapResourceError(&err, "get target instance", project, zone, name)
 	n, err := c.raw.TargetInstances.Get(project, zone, name).Do()
-	if shouldRetryWithWait(c.hc.Transport, err, 2) {
+	if c.shouldRetry(err, 2) {
 		return c.raw.TargetInstances.Get(project, zone, name).Do()
 	}
 	return n, err
 }
 
+// GetBackendService gets a GCE BackendService.
+func (c *client) GetBackendService(project, name string) (_ *compute.BackendService, err error) {
+	defer wrapResourceError(&err, "get backend service", project, name)
+	r, err := c.raw.BackendServices.Get(project, name).Do()
+	if c.shouldRetry(err, 2) {
+		return c.raw.BackendServices.Get(project, name).Do()
+	}
+	return r, err
+}
+
+// GetBackendServiceHealth gets the health of the backends in group, the URL of
+// an instance group or network endpoint group, of a global backend service.
+func (c *client) GetBackendServiceHealth(project, backendService, group string) (_ *compute.BackendServiceGroupHealth, err error) {
+	defer wrapResourceError(&err, "get health of backend service", project, backendService)
+	ref := &compute.ResourceGroupReference{Group: group}
+	h, err := c.raw.BackendServices.GetHealth(project, backendService, ref).Do()
+	if c.shouldRetry(err, 2) {
+		return c.raw.BackendServices.GetHealth(project, backendService, ref).Do()
+	}
+	return h, err
+}
+
+// GetHealthCheck gets a GCE HealthCheck.
+func (c *client) GetHealthCheck(project, name string) (_ *compute.HealthCheck, err error) {
+	defer wrapResourceError(&err, "get health check", project, name)
+	r, err := c.raw.HealthChecks.Get(project, name).Do()
+	if c.shouldRetry(err, 2) {
+		return c.raw.HealthChecks.Get(project, name).Do()
+	}
+	return r, err
+}
+
+// GetURLMap gets a GCE URLMap.
+func (c *client) GetURLMap(project, name string) (_ *compute.UrlMap, err error) {
+	defer wrapResourceError(&err, "get URL map", project, name)
+	r, err := c.raw.UrlMaps.Get(project, name).Do()
+	if c.shouldRetry(err, 2) {
+		return c.raw.UrlMaps.Get(project, name).Do()
+	}
+	return r, err
+}
+
+// GetTargetHTTPProxy gets a GCE TargetHTTPProxy.
+func (c *client) GetTargetHTTPProxy(project, name string) (_ *compute.TargetHttpProxy, err error) {
+	defer wrapResourceError(&err, "get target HTTP proxy", project, name)
+	r, err := c.raw.TargetHttpProxies.Get(project, name).Do()
+	if c.shouldRetry(err, 2) {
+		return c.raw.TargetHttpProxies.Get(project, name).Do()
+	}
+	return r, err
+}
+
+// GetNetworkEndpointGroup gets a zonal GCE NetworkEndpointGroup.
+func (c *client) GetNetworkEndpointGroup(project, zone, name string) (_ *compute.NetworkEndpointGroup, err error) {
+	defer wrapResourceError(&err, "get network endpoint group", project, zone, name)
+	r, err := c.raw.NetworkEndpointGroups.Get(project, zone, name).Do()
+	if c.shouldRetry(err, 2) {
+		return c.raw.NetworkEndpointGroups.Get(project, zone, name).Do()
+	}
+	return r, err
+}
+
 // ListTargetInstances gets a list of GCE TargetInstances.
 func (c *client) ListTargetInstances(project, zone string, opts ...ListCallOption) ([]*compute.TargetInstance, error) {
 	var tis []*compute.TargetInstance
@@ -1817,8 +4729,9 @@ func (c *client) ListTargetInstances(project, zone string, opts ...ListCallOptio
 	for _, opt := range opts {
 		call = opt.listCallOptionApply(call).(*compute.TargetInstancesListCall)
 	}
+	limit := listLimit(opts)
 	for til, err := call.PageToken(pt).Do(); ; til, err = call.PageToken(pt).Do() {
-		if shouldRetryWithWait(c.hc.Transport, err, 2) {
+		if c.shouldRetry(err, 2) {
 			til, err = call.PageToken(pt).Do()
 		}
 		if err != nil {
@@ -1826,6 +4739,9 @@ func (c *client) ListTargetInstances(project, zone string, opts ...ListCallOptio
 		}
 		tis = append(tis, til.Items...)
 
+		if limit > 0 && len(tis) >= limit {
+			return tis[:limit], nil
+		}
 		if til.NextPageToken == "" {
 			return tis, nil
 		}
@@ -1834,14 +4750,88 @@ func (c *client) ListTargetInstances(project, zone string, opts ...ListCallOptio
 }
 
 // GetLicense gets a GCE License.
-func (c *client) GetLicense(project, name string) (*compute.License, error) {
+func (c *client) GetLicense(project, name string) (_ *compute.License, err error) {
+	defer wrapResourceError(&err, "get license", project, name)
 	l, err := c.raw.Licenses.Get(project, name).Do()
-	if shouldRetryWithWait(c.hc.Transport, err, 2) {
+	if c.shouldRetry(err, 2) {
 		return c.raw.Licenses.Get(project, name).Do()
 	}
 	return l, err
 }
 
+// GetInstanceTemplate gets a global GCE InstanceTemplate.
+func (c *client) GetInstanceTemplate(project, name string) (_ *compute.InstanceTemplate, err error) {
+	defer wrapResourceError(&err, "get instance template", project, name)
+	it, err := c.raw.InstanceTemplates.Get(project, name).Do()
+	if c.shouldRetry(err, 2) {
+		return c.raw.InstanceTemplates.Get(project, name).Do()
+	}
+	return it, err
+}
+
+// GetInstanceGroupManager gets a zonal GCE InstanceGroupManager.
+func (c *client) GetInstanceGroupManager(project, zone, name string) (_ *compute.InstanceGroupManager, err error) {
+	defer wrapResourceError(&err, "get instance group manager", project, zone, name)
+	m, err := c.raw.InstanceGroupManagers.Get(project, zone, name).Do()
+	if c.shouldRetry(err, 2) {
+		return c.raw.InstanceGroupManagers.Get(project, zone, name).Do()
+	}
+	return m, err
+}
+
+// GetRegionInstanceGroupManager gets a regional GCE InstanceGroupManager.
+func (c *client) GetRegionInstanceGroupManager(project, region, name string) (_ *compute.InstanceGroupManager, err error) {
+	defer wrapResourceError(&err, "get instance group manager", project, region, name)
+	m, err := c.raw.RegionInstanceGroupManagers.Get(project, region, name).Do()
+	if c.shouldRetry(err, 2) {
+		return c.raw.RegionInstanceGroupManagers.Get(project, region, name).Do()
+	}
+	return m, err
+}
+
+// instanceGroupManagerPollInterval is how often
+// WaitForInstanceGroupManagerStable and
+// WaitForRegionInstanceGroupManagerStable check the group's status.
+var instanceGroupManagerPollInterval = 5 * time.Second
+
+// WaitForInstanceGroupManagerStable polls a zonal managed instance group until
+// its status is stable, i.e. no instances are being created, recreated or
+// deleted, or returns an error once the timeout has elapsed.
+func (c *client) WaitForInstanceGroupManagerStable(project, zone, name string, timeout time.Duration) error {
+	return c.waitForInstanceGroupManagerStable(name, timeout, func() (*compute.InstanceGroupManager, error) {
+		return c.i.GetInstanceGroupManager(project, zone, name)
+	})
+}
+
+// WaitForRegionInstanceGroupManagerStable is WaitForInstanceGroupManagerStable
+// for a regional managed instance group.
+func (c *client) WaitForRegionInstanceGroupManagerStable(project, region, name string, timeout time.Duration) error {
+	return c.waitForInstanceGroupManagerStable(name, timeout, func() (*compute.InstanceGroupManager, error) {
+		return c.i.GetRegionInstanceGroupManager(project, region, name)
+	})
+}
+
+func (c *client) waitForInstanceGroupManagerStable(name string, timeout time.Duration, get func() (*compute.InstanceGroupManager, error)) error {
+	deadline := c.clock.Now().Add(timeout)
+	for {
+		m, err := get()
+		if err != nil {
+			return err
+		}
+		if m.Status != nil && m.Status.IsStable {
+			return nil
+		}
+		if c.clock.Now().After(deadline) {
+			actions := "unknown"
+			if a := m.CurrentActions; a != nil {
+				actions = fmt.Sprintf("%d creating, %d recreating, %d deleting, %d restarting", a.Creating, a.Recreating, a.Deleting, a.Restarting)
+			}
+			return fmt.Errorf("instance group manager %q not stable after %s, current actions: %s", name, timeout, actions)
+		}
+		c.clock.Sleep(instanceGroupManagerPollInterval)
+	}
+}
+
 // ListLicenses gets a list GCE Licenses.
 func (c *client) ListLicenses(project string, opts ...ListCallOption) ([]*compute.License, error) {
 	var ls []*compute.License
@@ -1850,8 +4840,9 @@ func (c *client) ListLicenses(project string, opts ...ListCallOption) ([]*comput
 	for _, opt := range opts {
 		call = opt.listCallOptionApply(call).(*compute.LicensesListCall)
 	}
+	limit := listLimit(opts)
 	for ll, err := call.PageToken(pt).Do(); ; ll, err = call.PageToken(pt).Do() {
-		if shouldRetryWithWait(c.hc.Transport, err, 2) {
+		if c.shouldRetry(err, 2) {
 			ll, err = call.PageToken(pt).Do()
 		}
 		if err != nil {
@@ -1859,6 +4850,9 @@ func (c *client) ListLicenses(project string, opts ...ListCallOption) ([]*comput
 		}
 		ls = append(ls, ll.Items...)
 
+		if limit > 0 && len(ls) >= limit {
+			return ls[:limit], nil
+		}
 		if ll.NextPageToken == "" {
 			return ls, nil
 		}
@@ -1869,7 +4863,7 @@ func (c *client) ListLicenses(project string, opts ...ListCallOption) ([]*comput
 // InstanceStatus returns an instances Status.
 func (c *client) InstanceStatus(project, zone, name string) (string, error) {
 	is, err := c.raw.Instances.Get(project, zone, name).Do()
-	if shouldRetryWithWait(c.hc.Transport, err, 2) {
+	if c.shouldRetry(err, 2) {
 		is, err = c.raw.Instances.Get(project, zone, name).Do()
 	}
 
@@ -1886,15 +4880,100 @@ func (c *client) InstanceStopped(project, zone, name string) (bool, error) {
 		return false, err
 	}
 	switch status {
-	case "PROVISIONING", "REPAIRING", "RUNNING", "STAGING", "STOPPING":
+	case StatusProvisioning, StatusRepairing, StatusRunning, StatusStaging, StatusStopping:
 		return false, nil
-	case "TERMINATED", "STOPPED":
+	case StatusTerminated, StatusStopped:
 		return true, nil
 	default:
 		return false, fmt.Errorf("unexpected instance status %q", status)
 	}
 }
 
+// instancePollInterval is how often WaitForInstanceRunning and
+// WaitForInstanceStopped check the instance status.
+var instancePollInterval = 1 * time.Second
+
+// WaitForInstanceRunning polls a GCE instance until it is in a 'RUNNING'
+// state, or returns an error once the timeout has elapsed.
+func (c *client) WaitForInstanceRunning(project, zone, name string, timeout time.Duration) error {
+	return c.waitForInstanceStatus(project, zone, name, timeout, "running", StatusRunning)
+}
+
+// WaitForInstanceStopped polls a GCE instance until it is in a 'TERMINATED'
+// or 'STOPPED' state, or returns an error once the timeout has elapsed.
+func (c *client) WaitForInstanceStopped(project, zone, name string, timeout time.Duration) error {
+	return c.waitForInstanceStatus(project, zone, name, timeout, "stopped", StatusTerminated, StatusStopped)
+}
+
+// waitForInstanceStatus polls a GCE instance until its status is one of
+// want. desc describes the wanted state in the timeout error.
+func (c *client) waitForInstanceStatus(project, zone, name string, timeout time.Duration, desc string, want ...string) error {
+	deadline := c.clock.Now().Add(timeout)
+	for {
+		status, err := c.i.InstanceStatus(project, zone, name)
+		if err != nil {
+			return err
+		}
+		for _, w := range want {
+			if status == w {
+				return nil
+			}
+		}
+		if c.clock.Now().After(deadline) {
+			return fmt.Errorf("instance %q not %s after %s, last status %q", name, desc, timeout, status)
+		}
+		c.clock.Sleep(instancePollInterval)
+	}
+}
+
+// guestAttributePollInterval is the initial interval between guest attribute
+// queries, which are limited to 10 per minute per instance.
+const guestAttributePollInterval = 6 * time.Second
+
+// guestAttributeMaxPollInterval is the longest interval between guest
+// attribute queries.
+const guestAttributeMaxPollInterval = 30 * time.Second
+
+// WaitForGuestAttribute polls a GCE instance's guest attribute namespace/key
+// until it equals wantValue, or returns an error once the timeout has elapsed.
+func (c *client) WaitForGuestAttribute(project, zone, instance, namespace, key, wantValue string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return c.i.WaitForGuestAttributeContext(ctx, project, zone, instance, namespace, key, wantValue)
+}
+
+// WaitForGuestAttributeContext polls a GCE instance's guest attribute
+// namespace/key until it equals wantValue, backing off between queries, or
+// returns an error once ctx is done. An attribute that is not set yet is not
+// an error.
+func (c *client) WaitForGuestAttributeContext(ctx context.Context, project, zone, instance, namespace, key, wantValue string) error {
+	varkey := namespace + "/" + key
+	interval := guestAttributePollInterval
+	var last string
+	for {
+		ga, err := c.i.GetGuestAttributes(project, zone, instance, "", varkey)
+		if err != nil && !IsNotFound(err) {
+			return err
+		}
+		if err == nil {
+			if ga.VariableValue == wantValue {
+				return nil
+			}
+			last = ga.VariableValue
+		}
+
+		if err := sleepContext(ctx, c.clock, interval); err != nil {
+			if last == "" {
+				return fmt.Errorf("instance %q: guest attribute %q not set to %q: %v", instance, varkey, wantValue, err)
+			}
+			return fmt.Errorf("instance %q: guest attribute %q not set to %q, last value %q: %v", instance, varkey, wantValue, last, err)
+		}
+		if interval *= 2; interval > guestAttributeMaxPollInterval {
+			interval = guestAttributeMaxPollInterval
+		}
+	}
+}
+
 // ResizeDisk resizes a GCE persistent disk. You can only increase the size of the disk.
 func (c *client) ResizeDisk(project, zone, disk string, drr *compute.DisksResizeRequest) error {
 	op, err := c.Retry(c.raw.Disks.Resize(project, zone, disk, drr).Do)
@@ -1924,8 +5003,108 @@ func (c *client) SetCommonInstanceMetadata(project string, md *compute.Metadata)
 	return c.i.globalOperationsWait(project, op.Name)
 }
 
-// GetGuestAttributes gets a Guest Attributes.
-func (c *client) GetGuestAttributes(project, zone, name, queryPath, variableKey string) (*compute.GuestAttributes, error) {
+// SetProjectMetadataItem sets a single item in a project's common instance
+// metadata, leaving all other items in place. The update is guarded by the
+// metadata fingerprint and is retried if another writer changed the metadata
+// concurrently.
+func (c *client) SetProjectMetadataItem(project, key, value string) error {
+	var err error
+	for i := 0; i < 3; i++ {
+		var p *compute.Project
+		if p, err = c.i.GetProject(project); err != nil {
+			return err
+		}
+		md := &compute.Metadata{}
+		if p.CommonInstanceMetadata != nil {
+			md.Fingerprint = p.CommonInstanceMetadata.Fingerprint
+			for _, item := range p.CommonInstanceMetadata.Items {
+				if item.Key != key {
+					md.Items = append(md.Items, item)
+				}
+			}
+		}
+		md.Items = append(md.Items, &compute.MetadataItems{Key: key, Value: &value})
+
+		err = c.i.SetCommonInstanceMetadata(project, md)
+		var apiErr *googleapi.Error
+		if !errors.As(err, &apiErr) || apiErr.Code != http.StatusPreconditionFailed {
+			return err
+		}
+	}
+	return err
+}
+
+// SetUsageExportBucket sets the Cloud Storage bucket that a project's usage
+// reports are exported to. A nil or empty cfg disables usage export.
+func (c *client) SetUsageExportBucket(project string, cfg *compute.UsageExportLocation) error {
+	if cfg == nil {
+		cfg = &compute.UsageExportLocation{}
+	}
+	op, err := c.Retry(c.raw.Projects.SetUsageExportBucket(project, cfg).Do)
+	if err != nil {
+		return err
+	}
+	return c.i.globalOperationsWait(project, op.Name)
+}
+
+// GetXpnHost gets the shared VPC host project that a service project is
+// attached to.
+func (c *client) GetXpnHost(project string) (_ *compute.Project, err error) {
+	defer wrapResourceError(&err, "get XPN host of project", project)
+	p, err := c.raw.Projects.GetXpnHost(project).Do()
+	if c.shouldRetry(err, 2) {
+		return c.raw.Projects.GetXpnHost(project).Do()
+	}
+	return p, err
+}
+
+// ListXpnHosts gets the shared VPC host projects that project can attach to.
+// If organization is set, only host projects in that organization are listed.
+func (c *client) ListXpnHosts(project, organization string) ([]*compute.Project, error) {
+	var ps []*compute.Project
+	var pt string
+	call := c.raw.Projects.ListXpnHosts(project, &compute.ProjectsListXpnHostsRequest{Organization: organization})
+	for hl, err := call.PageToken(pt).Do(); ; hl, err = call.PageToken(pt).Do() {
+		if c.shouldRetry(err, 2) {
+			hl, err = call.PageToken(pt).Do()
+		}
+		if err != nil {
+			return nil, err
+		}
+		ps = append(ps, hl.Items...)
+
+		if hl.NextPageToken == "" {
+			return ps, nil
+		}
+		pt = hl.NextPageToken
+	}
+}
+
+// EnableXpnResource attaches a service project to the shared VPC host project
+// project.
+func (c *client) EnableXpnResource(project string, req *compute.ProjectsEnableXpnResourceRequest) error {
+	op, err := c.Retry(c.raw.Projects.EnableXpnResource(project, req).Do)
+	if err != nil {
+		return err
+	}
+	return c.i.globalOperationsWait(project, op.Name)
+}
+
+// DisableXpnResource detaches a service project from the shared VPC host
+// project project.
+func (c *client) DisableXpnResource(project string, req *compute.ProjectsDisableXpnResourceRequest) error {
+	op, err := c.Retry(c.raw.Projects.DisableXpnResource(project, req).Do)
+	if err != nil {
+		return err
+	}
+	return c.i.globalOperationsWait(project, op.Name)
+}
+
+// GetGuestAttributes gets a Guest Attributes. A query for a path or key the
+// guest has not written yet fails with a 404, which can be detected with
+// IsNotFound.
+func (c *client) GetGuestAttributes(project, zone, name, queryPath, variableKey string) (_ *compute.GuestAttributes, err error) {
+	defer wrapResourceError(&err, "get guest attributes of instance", project, zone, name)
 	call := c.raw.Instances.GetGuestAttributes(project, zone, name)
 	if queryPath != "" {
 		call = call.QueryPath(queryPath)
@@ -1934,7 +5113,7 @@ func (c *client) GetGuestAttributes(project, zone, name, queryPath, variableKey
 		call = call.VariableKey(variableKey)
 	}
 	a, err := call.Do()
-	if shouldRetryWithWait(c.hc.Transport, err, 2) {
+	if c.shouldRetry(err, 2) {
 		return call.Do()
 	}
 	return a, err
@@ -1948,8 +5127,9 @@ func (c *client) ListMachineImages(project string, opts ...ListCallOption) ([]*c
 	for _, opt := range opts {
 		call = opt.listCallOptionApply(call).(*compute.MachineImagesListCall)
 	}
+	limit := listLimit(opts)
 	for il, err := call.PageToken(pt).Do(); ; il, err = call.PageToken(pt).Do() {
-		if shouldRetryWithWait(c.hc.Transport, err, 2) {
+		if c.shouldRetry(err, 2) {
 			il, err = call.PageToken(pt).Do()
 		}
 		if err != nil {
@@ -1957,6 +5137,9 @@ func (c *client) ListMachineImages(project string, opts ...ListCallOption) ([]*c
 		}
 		is = append(is, il.Items...)
 
+		if limit > 0 && len(is) >= limit {
+			return is[:limit], nil
+		}
 		if il.NextPageToken == "" {
 			return is, nil
 		}
@@ -1965,7 +5148,8 @@ func (c *client) ListMachineImages(project string, opts ...ListCallOption) ([]*c
 }
 
 // DeleteMachineImage deletes a GCE machine image.
-func (c *client) DeleteMachineImage(project, name string) error {
+func (c *client) DeleteMachineImage(project, name string) (err error) {
+	defer wrapResourceError(&err, "delete machine image", project, name)
 	op, err := c.Retry(c.raw.MachineImages.Delete(project, name).Do)
 	if err != nil {
 		return err
@@ -1977,7 +5161,8 @@ func (c *client) DeleteMachineImage(project, name string) error {
 // CreateMachineImage creates a GCE machine image.
 // sourceInstance must be specified, which is the url (full or partial) to the
 // source instance
-func (c *client) CreateMachineImage(project string, mi *compute.MachineImage) error {
+func (c *client) CreateMachineImage(project string, mi *compute.MachineImage) (err error) {
+	defer wrapResourceError(&err, "create machine image", project, mi.Name)
 	op, err := c.Retry(c.raw.MachineImages.Insert(project, mi).Do)
 	if err != nil {
 		return err
@@ -1987,6 +5172,10 @@ func (c *client) CreateMachineImage(project string, mi *compute.MachineImage) er
 		return err
 	}
 
+	if c.skipReadback {
+		return nil
+	}
+
 	var createdMachineImage *compute.MachineImage
 	if createdMachineImage, err = c.i.GetMachineImage(project, mi.Name); err != nil {
 		return err
@@ -1996,9 +5185,10 @@ func (c *client) CreateMachineImage(project string, mi *compute.MachineImage) er
 }
 
 // GetMachineImage gets a GCE Machine Image.
-func (c *client) GetMachineImage(project, name string) (*compute.MachineImage, error) {
+func (c *client) GetMachineImage(project, name string) (_ *compute.MachineImage, err error) {
+	defer wrapResourceError(&err, "get machine image", project, name)
 	i, err := c.raw.MachineImages.Get(project, name).Do()
-	if shouldRetryWithWait(c.hc.Transport, err, 2) {
+	if c.shouldRetry(err, 2) {
 		return c.raw.MachineImages.Get(project, name).Do()
 	}
 	return i, err
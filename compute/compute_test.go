@@ -16,18 +16,27 @@ package compute
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"net/http/httptest"
 	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/kylelemons/godebug/pretty"
 	computeAlpha "google.golang.org/api/compute/v0.alpha"
 	computeBeta "google.golang.org/api/compute/v0.beta"
 	"google.golang.org/api/compute/v1"
 	"google.golang.org/api/googleapi"
+	"google.golang.org/api/option"
 )
 
 var (
@@ -50,10 +59,17 @@ var (
 	testSubnetwork                 = "test-subnetwork"
 	testTargetInstance             = "test-target-instance"
 	testTargetHTTPProxy            = "test-target-http-proxy"
+	testTargetHTTPSProxy           = "test-target-https-proxy"
+	testSSLCertificate             = "test-ssl-certificate"
+	testHTTPHealthCheck            = "test-http-health-check"
+	testHTTPSHealthCheck           = "test-https-health-check"
+	testSecurityPolicy             = "test-security-policy"
+	testPacketMirroring            = "test-packet-mirroring"
 	testURLMap                     = "test-url-map"
 	testBackendService             = "test-backend-service"
 	testHealthCheck                = "test-health-check"
 	testNetworkEndpointGroup       = "test-network-endpoint-group"
+	testInstanceGroup              = "test-instance-group"
 )
 
 func TestShouldRetryWithWait(t *testing.T) {
@@ -67,6 +83,10 @@ func TestShouldRetryWithWait(t *testing.T) {
 		{"400 error", &googleapi.Error{Code: 400}, false},
 		{"429 error", &googleapi.Error{Code: 429}, true},
 		{"500 error", &googleapi.Error{Code: 500}, true},
+		{"409 resource not ready", &googleapi.Error{Code: 409, Errors: []googleapi.ErrorItem{{Reason: "resourceNotReady"}}}, true},
+		{"409 operation in progress", &googleapi.Error{Code: 409, Errors: []googleapi.ErrorItem{{Reason: "OPERATION_IN_PROGRESS"}}}, true},
+		{"409 already exists", &googleapi.Error{Code: 409, Errors: []googleapi.ErrorItem{{Reason: "alreadyExists"}}}, false},
+		{"409 without reason", &googleapi.Error{Code: 409}, false},
 		{"connection reset", errors.New("read tcp 192.168.10.2:59590->74.125.135.95:443: read: connection reset by peer"), true},
 		{"EOF", errors.New("unexpected EOF"), true},
 	}
@@ -78,6 +98,293 @@ func TestShouldRetryWithWait(t *testing.T) {
 	}
 }
 
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		desc, header string
+		want         time.Duration
+	}{
+		{"absent", "", 0},
+		{"seconds", "30", 30 * time.Second},
+		{"http date", now.Add(time.Minute).Format(http.TimeFormat), time.Minute},
+		{"date in the past", now.Add(-time.Minute).Format(http.TimeFormat), 0},
+		{"capped", "86400", maxRetryAfter},
+		{"invalid", "soon", 0},
+	}
+	for _, tt := range tests {
+		apiErr := &googleapi.Error{Code: 429, Header: http.Header{}}
+		if tt.header != "" {
+			apiErr.Header.Set("Retry-After", tt.header)
+		}
+		if got := retryAfter(apiErr, now); got != tt.want {
+			t.Errorf("%s: got %s, want %s", tt.desc, got, tt.want)
+		}
+	}
+}
+
+func TestRetryUsesClock(t *testing.T) {
+	var calls int
+	svr, c, err := NewTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{"Name":"op"}`)
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer svr.Close()
+	clk := NewFakeClock(time.Now())
+	c.client.clock = clk
+
+	start := time.Now()
+	if _, err := c.Retry(c.client.raw.Instances.Delete(testProject, testZone, testInstance).Do); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("got %d calls, want 3", calls)
+	}
+	// The backoff is 1-2s times the attempt number, for attempts 1 and 2.
+	if slept := clk.Slept(); slept < 3*time.Second || slept > 6*time.Second {
+		t.Errorf("got %s of backoff, want between 3s and 6s", slept)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Retry took %s of real time with a fake clock", elapsed)
+	}
+}
+
+func TestRetryContextCancelledDuringBackoff(t *testing.T) {
+	var calls int
+	svr, c, err := NewTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer svr.Close()
+	// The backoff must be cancellable on the real clock.
+	c.client.clock = realClock{}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(100*time.Millisecond, cancel)
+	start := time.Now()
+	_, err = c.RetryContext(ctx, c.client.raw.Instances.Delete(testProject, testZone, testInstance).Do)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("got error %v, want %v", err, context.Canceled)
+	}
+	if calls != 1 {
+		t.Errorf("got %d calls, want 1", calls)
+	}
+	// The first backoff is at least 1s.
+	if elapsed := time.Since(start); elapsed > 900*time.Millisecond {
+		t.Errorf("RetryContext took %s to return after cancellation", elapsed)
+	}
+}
+
+// stuckClock is a Clock whose timers never fire.
+type stuckClock struct{ realClock }
+
+func (stuckClock) After(time.Duration) <-chan time.Time { return nil }
+
+func TestRetryContextCancelledOnInjectedClock(t *testing.T) {
+	svr, c, err := NewTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer svr.Close()
+	c.client.clock = stuckClock{}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err = c.RetryContext(ctx, c.client.raw.Instances.Delete(testProject, testZone, testInstance).Do)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("got error %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestWithUserAgent(t *testing.T) {
+	var uas []string
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		uas = append(uas, r.Header.Get("User-Agent"))
+		fmt.Fprint(w, `{}`)
+	}))
+	defer svr.Close()
+
+	c, err := NewClientWithOptions(context.Background(), []option.ClientOption{option.WithEndpoint(svr.URL), option.WithHTTPClient(http.DefaultClient)}, WithUserAgent("my-tool/1.0"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.GetProject("p")
+	c.GetInstanceBeta("p", "z", "i")
+	c.GetInstanceAlpha("p", "z", "i")
+
+	if len(uas) != 3 {
+		t.Fatalf("want 3 requests, got %d", len(uas))
+	}
+	for _, ua := range uas {
+		if !strings.Contains(ua, "google-api-go-client") || !strings.HasSuffix(ua, " my-tool/1.0") {
+			t.Errorf("User-Agent %q does not extend the default with my-tool/1.0", ua)
+		}
+	}
+}
+
+func TestOperationsWaitError(t *testing.T) {
+	svr, c, err := NewTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" && r.URL.String() == fmt.Sprintf("/projects/%s/zones/%s/operations/op/wait?alt=json&prettyPrint=false", testProject, testZone) {
+			fmt.Fprint(w, `{"Status":"DONE","Error":{"Errors":[{"Code":"ZONE_RESOURCE_POOL_EXHAUSTED","Message":"stockout","Location":"zone"}]}}`)
+		} else {
+			w.WriteHeader(500)
+			fmt.Fprintln(w, "URL and Method not recognized:", r.Method, r.URL)
+		}
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer svr.Close()
+
+	err = c.zoneOperationsWait(testProject, testZone, "op")
+	var opErr *OperationError
+	if !errors.As(err, &opErr) {
+		t.Fatalf("want *OperationError, got %T: %v", err, err)
+	}
+	want := []*compute.OperationErrorErrors{{Code: "ZONE_RESOURCE_POOL_EXHAUSTED", Message: "stockout", Location: "zone"}}
+	if diff := pretty.Compare(opErr.Errors(), want); diff != "" {
+		t.Errorf("operation errors do not match expectation: (-got +want)\n%s", diff)
+	}
+	if !opErr.HasCode("ZONE_RESOURCE_POOL_EXHAUSTED") {
+		t.Error("want HasCode(ZONE_RESOURCE_POOL_EXHAUSTED) to be true")
+	}
+	if opErr.HasCode("QUOTA_EXCEEDED") {
+		t.Error("want HasCode(QUOTA_EXCEEDED) to be false")
+	}
+	if !strings.Contains(err.Error(), "\nCode: ZONE_RESOURCE_POOL_EXHAUSTED\nMessage: stockout") {
+		t.Errorf("error string missing code and message: %q", err.Error())
+	}
+}
+
+func TestOrganizationOperationsWait(t *testing.T) {
+	var polls int
+	svr, c, err := NewTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" || r.URL.String() != "/locations/global/operations/op?alt=json&prettyPrint=false" {
+			w.WriteHeader(500)
+			fmt.Fprintln(w, "URL and Method not recognized:", r.Method, r.URL)
+			return
+		}
+		polls++
+		if polls < 3 {
+			fmt.Fprint(w, `{"status":"RUNNING"}`)
+			return
+		}
+		fmt.Fprint(w, `{"status":"DONE"}`)
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer svr.Close()
+
+	if err := c.organizationOperationsWait("op"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if polls != 3 {
+		t.Errorf("got %d polls, want 3", polls)
+	}
+}
+
+func TestOperationsWaitEmptyName(t *testing.T) {
+	svr, c, err := NewTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request: %s %s", r.Method, r.URL)
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer svr.Close()
+
+	for _, err := range []error{
+		c.zoneOperationsWait(testProject, testZone, ""),
+		c.regionOperationsWait(testProject, testRegion, ""),
+		c.globalOperationsWait(testProject, ""),
+		c.zoneOperationsWaitBeta(testProject, testZone, ""),
+		c.globalOperationsWaitAlpha(testProject, ""),
+		c.organizationOperationsWait(""),
+	} {
+		if err == nil || !strings.Contains(err.Error(), "operation name is empty") {
+			t.Errorf("want empty operation name error, got: %v", err)
+		}
+	}
+}
+
+func TestOperationsWaitBetaAlphaError(t *testing.T) {
+	svr, c, err := NewTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" && r.URL.String() == fmt.Sprintf("/projects/%s/zones/%s/operations/op/wait?alt=json&prettyPrint=false", testProject, testZone) {
+			fmt.Fprint(w, `{"Status":"DONE","Error":{"Errors":[{"Code":"QUOTA_EXCEEDED","Message":"quota"}]}}`)
+		} else if r.Method == "POST" && r.URL.String() == fmt.Sprintf("/projects/%s/global/operations/op/wait?alt=json&prettyPrint=false", testProject) {
+			fmt.Fprint(w, `{"Status":"DONE"}`)
+		} else {
+			w.WriteHeader(500)
+			fmt.Fprintln(w, "URL and Method not recognized:", r.Method, r.URL)
+		}
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer svr.Close()
+
+	for desc, wait := range map[string]func() error{
+		"beta":  func() error { return c.zoneOperationsWaitBeta(testProject, testZone, "op") },
+		"alpha": func() error { return c.zoneOperationsWaitAlpha(testProject, testZone, "op") },
+	} {
+		var opErr *OperationError
+		if err := wait(); !errors.As(err, &opErr) || !opErr.HasCode("QUOTA_EXCEEDED") {
+			t.Errorf("%s: want OperationError with QUOTA_EXCEEDED, got: %v", desc, err)
+		}
+	}
+	if err := c.globalOperationsWaitBeta(testProject, "op"); err != nil {
+		t.Errorf("beta global wait: unexpected error: %v", err)
+	}
+	if err := c.globalOperationsWaitAlpha(testProject, "op"); err != nil {
+		t.Errorf("alpha global wait: unexpected error: %v", err)
+	}
+}
+
+func TestWaitForOperationWithProgress(t *testing.T) {
+	polls := 0
+	svr, c, err := NewTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" && r.URL.String() == fmt.Sprintf("/projects/%s/regions/%s/operations/op?alt=json&prettyPrint=false", testProject, testRegion) {
+			polls++
+			if polls == 1 {
+				fmt.Fprint(w, `{"Status":"RUNNING","Progress":40}`)
+				return
+			}
+			fmt.Fprint(w, `{"Status":"DONE","Progress":100}`)
+		} else {
+			w.WriteHeader(500)
+			fmt.Fprintln(w, "URL and Method not recognized:", r.Method, r.URL)
+		}
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer svr.Close()
+
+	var got []int
+	selfLink := fmt.Sprintf("https://www.googleapis.com/compute/v1/projects/%s/regions/%s/operations/op", testProject, testRegion)
+	if err := c.WaitForOperationWithProgress(testProject, selfLink, func(p int) { got = append(got, p) }); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []int{40, 100}; !reflect.DeepEqual(got, want) {
+		t.Errorf("progress: got %v, want %v", got, want)
+	}
+
+	if err := c.WaitForOperationWithProgress(testProject, "bad", nil); err == nil {
+		t.Error("want error for invalid self link")
+	}
+}
+
 func TestCreates(t *testing.T) {
 	var getURL, insertURL *string
 	var getErr, insertErr, waitErr error
@@ -115,6 +422,10 @@ func TestCreates(t *testing.T) {
 	c.zoneOperationsWaitFn = func(_, _, _ string) error { return waitErr }
 	c.regionOperationsWaitFn = func(_, _, _ string) error { return waitErr }
 	c.globalOperationsWaitFn = func(_, _ string) error { return waitErr }
+	c.zoneOperationsWaitBetaFn = func(_, _, _ string) error { return waitErr }
+	c.globalOperationsWaitBetaFn = func(_, _ string) error { return waitErr }
+	c.zoneOperationsWaitAlphaFn = func(_, _, _ string) error { return waitErr }
+	c.globalOperationsWaitAlphaFn = func(_, _ string) error { return waitErr }
 
 	tests := []struct {
 		desc                       string
@@ -133,6 +444,7 @@ func TestCreates(t *testing.T) {
 	im := &compute.Image{Name: testImage}
 	imAlpha := &computeAlpha.Image{Name: testImageAlpha}
 	imBeta := &computeBeta.Image{Name: testImageBeta}
+	firBeta := &computeBeta.Firewall{Name: testFirewallRule}
 	mi := &compute.MachineImage{Name: testMachineImage, SourceInstance: testInstance}
 	in := &compute.Instance{Name: testInstance}
 	inAlpha := &computeAlpha.Instance{Name: testInstanceAlpha}
@@ -141,10 +453,17 @@ func TestCreates(t *testing.T) {
 	sn := &compute.Subnetwork{Name: testSubnetwork}
 	ti := &compute.TargetInstance{Name: testTargetInstance}
 	hp := &compute.TargetHttpProxy{Name: testTargetHTTPProxy}
+	hsp := &compute.TargetHttpsProxy{Name: testTargetHTTPSProxy}
+	sc := &compute.SslCertificate{Name: testSSLCertificate}
+	hhc := &compute.HttpHealthCheck{Name: testHTTPHealthCheck}
+	hshc := &compute.HttpsHealthCheck{Name: testHTTPSHealthCheck}
+	sp := &compute.SecurityPolicy{Name: testSecurityPolicy}
+	pm := &compute.PacketMirroring{Name: testPacketMirroring}
 	um := &compute.UrlMap{Name: testURLMap}
 	bs := &compute.BackendService{Name: testBackendService}
 	hc := &compute.HealthCheck{Name: testHealthCheck}
 	neg := &compute.NetworkEndpointGroup{Name: testNetworkEndpointGroup}
+	ig := &compute.InstanceGroup{Name: testInstanceGroup}
 	creates := []struct {
 		name              string
 		do                func() error
@@ -175,6 +494,14 @@ func TestCreates(t *testing.T) {
 			&compute.Firewall{Name: testFirewallRule},
 			fir,
 		},
+		{
+			"FirewallRulesBeta",
+			func() error { return c.CreateFirewallRuleBeta(testProject, firBeta) },
+			fmt.Sprintf("/%s/global/firewalls/%s?alt=json&prettyPrint=false", testProject, testFirewallRule),
+			fmt.Sprintf("/%s/global/firewalls?alt=json&prettyPrint=false", testProject),
+			&computeBeta.Firewall{Name: testFirewallRule},
+			firBeta,
+		},
 		{
 			"images",
 			func() error { return c.CreateImage(testProject, im) },
@@ -295,6 +622,62 @@ func TestCreates(t *testing.T) {
 			&compute.NetworkEndpointGroup{Name: testNetworkEndpointGroup},
 			neg,
 		},
+		{
+			"httpHealthChecks",
+			func() error { return c.CreateHTTPHealthCheck(testProject, hhc) },
+			fmt.Sprintf("/%s/global/httpHealthChecks/%s?alt=json&prettyPrint=false", testProject, testHTTPHealthCheck),
+			fmt.Sprintf("/%s/global/httpHealthChecks?alt=json&prettyPrint=false", testProject),
+			&compute.HttpHealthCheck{Name: testHTTPHealthCheck},
+			hhc,
+		},
+		{
+			"httpsHealthChecks",
+			func() error { return c.CreateHTTPSHealthCheck(testProject, hshc) },
+			fmt.Sprintf("/%s/global/httpsHealthChecks/%s?alt=json&prettyPrint=false", testProject, testHTTPSHealthCheck),
+			fmt.Sprintf("/%s/global/httpsHealthChecks?alt=json&prettyPrint=false", testProject),
+			&compute.HttpsHealthCheck{Name: testHTTPSHealthCheck},
+			hshc,
+		},
+		{
+			"securityPolicies",
+			func() error { return c.CreateSecurityPolicy(testProject, sp) },
+			fmt.Sprintf("/%s/global/securityPolicies/%s?alt=json&prettyPrint=false", testProject, testSecurityPolicy),
+			fmt.Sprintf("/%s/global/securityPolicies?alt=json&prettyPrint=false", testProject),
+			&compute.SecurityPolicy{Name: testSecurityPolicy},
+			sp,
+		},
+		{
+			"packetMirrorings",
+			func() error { return c.CreatePacketMirroring(testProject, testRegion, pm) },
+			fmt.Sprintf("/%s/regions/%s/packetMirrorings/%s?alt=json&prettyPrint=false", testProject, testRegion, testPacketMirroring),
+			fmt.Sprintf("/%s/regions/%s/packetMirrorings?alt=json&prettyPrint=false", testProject, testRegion),
+			&compute.PacketMirroring{Name: testPacketMirroring},
+			pm,
+		},
+		{
+			"regionSslCertificates",
+			func() error { return c.CreateRegionSSLCertificate(testProject, testRegion, sc) },
+			fmt.Sprintf("/%s/regions/%s/sslCertificates/%s?alt=json&prettyPrint=false", testProject, testRegion, testSSLCertificate),
+			fmt.Sprintf("/%s/regions/%s/sslCertificates?alt=json&prettyPrint=false", testProject, testRegion),
+			&compute.SslCertificate{Name: testSSLCertificate},
+			sc,
+		},
+		{
+			"regionTargetHttpsProxies",
+			func() error { return c.CreateRegionTargetHTTPSProxy(testProject, testRegion, hsp) },
+			fmt.Sprintf("/%s/regions/%s/targetHttpsProxies/%s?alt=json&prettyPrint=false", testProject, testRegion, testTargetHTTPSProxy),
+			fmt.Sprintf("/%s/regions/%s/targetHttpsProxies?alt=json&prettyPrint=false", testProject, testRegion),
+			&compute.TargetHttpsProxy{Name: testTargetHTTPSProxy},
+			hsp,
+		},
+		{
+			"instanceGroups",
+			func() error { return c.CreateInstanceGroup(testProject, testZone, ig) },
+			fmt.Sprintf("/%s/zones/%s/instanceGroups/%s?alt=json&prettyPrint=false", testProject, testZone, testInstanceGroup),
+			fmt.Sprintf("/%s/zones/%s/instanceGroups?alt=json&prettyPrint=false", testProject, testZone),
+			&compute.InstanceGroup{Name: testInstanceGroup},
+			ig,
+		},
 	}
 
 	for _, create := range creates {
@@ -322,7 +705,7 @@ func TestStarts(t *testing.T) {
 	var startURL, opGetURL string
 	svr, c, err := NewTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method == "POST" && r.URL.String() == startURL {
-			fmt.Fprint(w, `{}`)
+			fmt.Fprint(w, `{"Name":"op"}`)
 		} else if r.Method == "POST" && r.URL.String() == opGetURL {
 			fmt.Fprint(w, `{"Status":"DONE"}`)
 		} else {
@@ -336,7 +719,7 @@ func TestStarts(t *testing.T) {
 	defer svr.Close()
 
 	startURL = fmt.Sprintf("/projects/%s/zones/%s/instances/%s/start?alt=json&prettyPrint=false", testProject, testZone, testInstance)
-	opGetURL = fmt.Sprintf("/projects/%s/zones/%s/operations//wait?alt=json&prettyPrint=false", testProject, testZone)
+	opGetURL = fmt.Sprintf("/projects/%s/zones/%s/operations/op/wait?alt=json&prettyPrint=false", testProject, testZone)
 	if err := c.StartInstance(testProject, testZone, testInstance); err != nil {
 		t.Errorf("error running Start: %v", err)
 	}
@@ -346,7 +729,7 @@ func TestStops(t *testing.T) {
 	var stopURL, opGetURL string
 	svr, c, err := NewTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method == "POST" && r.URL.String() == stopURL {
-			fmt.Fprint(w, `{}`)
+			fmt.Fprint(w, `{"Name":"op"}`)
 		} else if r.Method == "POST" && r.URL.String() == opGetURL {
 			fmt.Fprint(w, `{"Status":"DONE"}`)
 		} else {
@@ -360,7 +743,7 @@ func TestStops(t *testing.T) {
 	defer svr.Close()
 
 	stopURL = fmt.Sprintf("/projects/%s/zones/%s/instances/%s/stop?alt=json&prettyPrint=false", testProject, testZone, testInstance)
-	opGetURL = fmt.Sprintf("/projects/%s/zones/%s/operations//wait?alt=json&prettyPrint=false", testProject, testZone)
+	opGetURL = fmt.Sprintf("/projects/%s/zones/%s/operations/op/wait?alt=json&prettyPrint=false", testProject, testZone)
 	if err := c.StopInstance(testProject, testZone, testInstance); err != nil {
 		t.Errorf("error running Stop: %v", err)
 	}
@@ -370,7 +753,7 @@ func TestDeletes(t *testing.T) {
 	var deleteURL, opGetURL *string
 	svr, c, err := NewTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method == "DELETE" && r.URL.String() == *deleteURL {
-			fmt.Fprint(w, `{}`)
+			fmt.Fprint(w, `{"Name":"op"}`)
 		} else if r.Method == "POST" && r.URL.String() == *opGetURL {
 			fmt.Fprint(w, `{"Status":"DONE"}`)
 		} else {
@@ -392,79 +775,85 @@ func TestDeletes(t *testing.T) {
 			"disks",
 			func() error { return c.DeleteDisk(testProject, testZone, testDisk) },
 			fmt.Sprintf("/projects/%s/zones/%s/disks/%s?alt=json&prettyPrint=false", testProject, testZone, testDisk),
-			fmt.Sprintf("/projects/%s/zones/%s/operations//wait?alt=json&prettyPrint=false", testProject, testZone),
+			fmt.Sprintf("/projects/%s/zones/%s/operations/op/wait?alt=json&prettyPrint=false", testProject, testZone),
+		},
+		{
+			"regionDisks",
+			func() error { return c.DeleteRegionDisk(testProject, testRegion, testDisk) },
+			fmt.Sprintf("/projects/%s/regions/%s/disks/%s?alt=json&prettyPrint=false", testProject, testRegion, testDisk),
+			fmt.Sprintf("/projects/%s/regions/%s/operations/op/wait?alt=json&prettyPrint=false", testProject, testRegion),
 		},
 		{
 			"forwardingRules",
 			func() error { return c.DeleteForwardingRule(testProject, testRegion, testForwardingRule) },
 			fmt.Sprintf("/projects/%s/regions/%s/forwardingRules/%s?alt=json&prettyPrint=false", testProject, testRegion, testForwardingRule),
-			fmt.Sprintf("/projects/%s/regions/%s/operations//wait?alt=json&prettyPrint=false", testProject, testRegion),
+			fmt.Sprintf("/projects/%s/regions/%s/operations/op/wait?alt=json&prettyPrint=false", testProject, testRegion),
 		},
 		{
 			"FirewallRules",
 			func() error { return c.DeleteFirewallRule(testProject, testFirewallRule) },
 			fmt.Sprintf("/projects/%s/global/firewalls/%s?alt=json&prettyPrint=false", testProject, testFirewallRule),
-			fmt.Sprintf("/projects/%s/global/operations//wait?alt=json&prettyPrint=false", testProject),
+			fmt.Sprintf("/projects/%s/global/operations/op/wait?alt=json&prettyPrint=false", testProject),
 		},
 		{
 			"images",
 			func() error { return c.DeleteImage(testProject, testImage) },
 			fmt.Sprintf("/projects/%s/global/images/%s?alt=json&prettyPrint=false", testProject, testImage),
-			fmt.Sprintf("/projects/%s/global/operations//wait?alt=json&prettyPrint=false", testProject),
+			fmt.Sprintf("/projects/%s/global/operations/op/wait?alt=json&prettyPrint=false", testProject),
 		},
 		{
 			"machineImages",
 			func() error { return c.DeleteMachineImage(testProject, testMachineImage) },
 			fmt.Sprintf("/projects/%s/global/machineImages/%s?alt=json&prettyPrint=false", testProject, testMachineImage),
-			fmt.Sprintf("/projects/%s/global/operations//wait?alt=json&prettyPrint=false", testProject),
+			fmt.Sprintf("/projects/%s/global/operations/op/wait?alt=json&prettyPrint=false", testProject),
 		},
 		{
 			"instances",
 			func() error { return c.DeleteInstance(testProject, testZone, testInstance) },
 			fmt.Sprintf("/projects/%s/zones/%s/instances/%s?alt=json&prettyPrint=false", testProject, testZone, testInstance),
-			fmt.Sprintf("/projects/%s/zones/%s/operations//wait?alt=json&prettyPrint=false", testProject, testZone),
+			fmt.Sprintf("/projects/%s/zones/%s/operations/op/wait?alt=json&prettyPrint=false", testProject, testZone),
 		},
 		{
 			"networks",
 			func() error { return c.DeleteNetwork(testProject, testNetwork) },
 			fmt.Sprintf("/projects/%s/global/networks/%s?alt=json&prettyPrint=false", testProject, testNetwork),
-			fmt.Sprintf("/projects/%s/global/operations//wait?alt=json&prettyPrint=false", testProject),
+			fmt.Sprintf("/projects/%s/global/operations/op/wait?alt=json&prettyPrint=false", testProject),
 		},
 		{
 			"subnetworks",
 			func() error { return c.DeleteSubnetwork(testProject, testRegion, testSubnetwork) },
 			fmt.Sprintf("/projects/%s/regions/%s/subnetworks/%s?alt=json&prettyPrint=false", testProject, testRegion, testSubnetwork),
-			fmt.Sprintf("/projects/%s/regions/%s/operations//wait?alt=json&prettyPrint=false", testProject, testRegion),
+			fmt.Sprintf("/projects/%s/regions/%s/operations/op/wait?alt=json&prettyPrint=false", testProject, testRegion),
 		},
 		{
 			"targetInstances",
 			func() error { return c.DeleteTargetInstance(testProject, testZone, testTargetInstance) },
 			fmt.Sprintf("/projects/%s/zones/%s/targetInstances/%s?alt=json&prettyPrint=false", testProject, testZone, testTargetInstance),
-			fmt.Sprintf("/projects/%s/zones/%s/operations//wait?alt=json&prettyPrint=false", testProject, testZone),
+			fmt.Sprintf("/projects/%s/zones/%s/operations/op/wait?alt=json&prettyPrint=false", testProject, testZone),
 		},
 		{
 			"regionTargetHttpProxies",
 			func() error { return c.DeleteRegionTargetHTTPProxy(testProject, testRegion, testTargetHTTPProxy) },
 			fmt.Sprintf("/projects/%s/regions/%s/targetHttpProxies/%s?alt=json&prettyPrint=false", testProject, testRegion, testTargetHTTPProxy),
-			fmt.Sprintf("/projects/%s/regions/%s/operations//wait?alt=json&prettyPrint=false", testProject, testRegion),
+			fmt.Sprintf("/projects/%s/regions/%s/operations/op/wait?alt=json&prettyPrint=false", testProject, testRegion),
 		},
 		{
 			"regionUrlMaps",
 			func() error { return c.DeleteRegionURLMap(testProject, testRegion, testURLMap) },
 			fmt.Sprintf("/projects/%s/regions/%s/urlMaps/%s?alt=json&prettyPrint=false", testProject, testRegion, testURLMap),
-			fmt.Sprintf("/projects/%s/regions/%s/operations//wait?alt=json&prettyPrint=false", testProject, testRegion),
+			fmt.Sprintf("/projects/%s/regions/%s/operations/op/wait?alt=json&prettyPrint=false", testProject, testRegion),
 		},
 		{
 			"regionBackendServices",
 			func() error { return c.DeleteRegionBackendService(testProject, testRegion, testBackendService) },
 			fmt.Sprintf("/projects/%s/regions/%s/backendServices/%s?alt=json&prettyPrint=false", testProject, testRegion, testBackendService),
-			fmt.Sprintf("/projects/%s/regions/%s/operations//wait?alt=json&prettyPrint=false", testProject, testRegion),
+			fmt.Sprintf("/projects/%s/regions/%s/operations/op/wait?alt=json&prettyPrint=false", testProject, testRegion),
 		},
 		{
 			"regionHealthChecks",
 			func() error { return c.DeleteRegionHealthCheck(testProject, testRegion, testHealthCheck) },
 			fmt.Sprintf("/projects/%s/regions/%s/healthChecks/%s?alt=json&prettyPrint=false", testProject, testRegion, testHealthCheck),
-			fmt.Sprintf("/projects/%s/regions/%s/operations//wait?alt=json&prettyPrint=false", testProject, testRegion),
+			fmt.Sprintf("/projects/%s/regions/%s/operations/op/wait?alt=json&prettyPrint=false", testProject, testRegion),
 		},
 		{
 			"regionNetworkEndpointGroups",
@@ -472,7 +861,49 @@ func TestDeletes(t *testing.T) {
 				return c.DeleteRegionNetworkEndpointGroup(testProject, testRegion, testNetworkEndpointGroup)
 			},
 			fmt.Sprintf("/projects/%s/regions/%s/networkEndpointGroups/%s?alt=json&prettyPrint=false", testProject, testRegion, testNetworkEndpointGroup),
-			fmt.Sprintf("/projects/%s/regions/%s/operations//wait?alt=json&prettyPrint=false", testProject, testRegion),
+			fmt.Sprintf("/projects/%s/regions/%s/operations/op/wait?alt=json&prettyPrint=false", testProject, testRegion),
+		},
+		{
+			"httpHealthChecks",
+			func() error { return c.DeleteHTTPHealthCheck(testProject, testHTTPHealthCheck) },
+			fmt.Sprintf("/projects/%s/global/httpHealthChecks/%s?alt=json&prettyPrint=false", testProject, testHTTPHealthCheck),
+			fmt.Sprintf("/projects/%s/global/operations/op/wait?alt=json&prettyPrint=false", testProject),
+		},
+		{
+			"httpsHealthChecks",
+			func() error { return c.DeleteHTTPSHealthCheck(testProject, testHTTPSHealthCheck) },
+			fmt.Sprintf("/projects/%s/global/httpsHealthChecks/%s?alt=json&prettyPrint=false", testProject, testHTTPSHealthCheck),
+			fmt.Sprintf("/projects/%s/global/operations/op/wait?alt=json&prettyPrint=false", testProject),
+		},
+		{
+			"securityPolicies",
+			func() error { return c.DeleteSecurityPolicy(testProject, testSecurityPolicy) },
+			fmt.Sprintf("/projects/%s/global/securityPolicies/%s?alt=json&prettyPrint=false", testProject, testSecurityPolicy),
+			fmt.Sprintf("/projects/%s/global/operations/op/wait?alt=json&prettyPrint=false", testProject),
+		},
+		{
+			"packetMirrorings",
+			func() error { return c.DeletePacketMirroring(testProject, testRegion, testPacketMirroring) },
+			fmt.Sprintf("/projects/%s/regions/%s/packetMirrorings/%s?alt=json&prettyPrint=false", testProject, testRegion, testPacketMirroring),
+			fmt.Sprintf("/projects/%s/regions/%s/operations/op/wait?alt=json&prettyPrint=false", testProject, testRegion),
+		},
+		{
+			"regionSslCertificates",
+			func() error { return c.DeleteRegionSSLCertificate(testProject, testRegion, testSSLCertificate) },
+			fmt.Sprintf("/projects/%s/regions/%s/sslCertificates/%s?alt=json&prettyPrint=false", testProject, testRegion, testSSLCertificate),
+			fmt.Sprintf("/projects/%s/regions/%s/operations/op/wait?alt=json&prettyPrint=false", testProject, testRegion),
+		},
+		{
+			"regionTargetHttpsProxies",
+			func() error { return c.DeleteRegionTargetHTTPSProxy(testProject, testRegion, testTargetHTTPSProxy) },
+			fmt.Sprintf("/projects/%s/regions/%s/targetHttpsProxies/%s?alt=json&prettyPrint=false", testProject, testRegion, testTargetHTTPSProxy),
+			fmt.Sprintf("/projects/%s/regions/%s/operations/op/wait?alt=json&prettyPrint=false", testProject, testRegion),
+		},
+		{
+			"instanceGroups",
+			func() error { return c.DeleteInstanceGroup(testProject, testZone, testInstanceGroup) },
+			fmt.Sprintf("/projects/%s/zones/%s/instanceGroups/%s?alt=json&prettyPrint=false", testProject, testZone, testInstanceGroup),
+			fmt.Sprintf("/projects/%s/zones/%s/operations/op/wait?alt=json&prettyPrint=false", testProject, testZone),
 		},
 	}
 
@@ -488,8 +919,8 @@ func TestDeletes(t *testing.T) {
 func TestDeprecateImage(t *testing.T) {
 	svr, c, err := NewTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method == "POST" && r.URL.String() == fmt.Sprintf("/projects/%s/global/images/%s/deprecate?alt=json&prettyPrint=false", testProject, testImage) {
-			fmt.Fprint(w, `{}`)
-		} else if r.Method == "POST" && r.URL.String() == fmt.Sprintf("/projects/%s/global/operations//wait?alt=json&prettyPrint=false", testProject) {
+			fmt.Fprint(w, `{"Name":"op"}`)
+		} else if r.Method == "POST" && r.URL.String() == fmt.Sprintf("/projects/%s/global/operations/op/wait?alt=json&prettyPrint=false", testProject) {
 			fmt.Fprint(w, `{"Status":"DONE"}`)
 		} else {
 			w.WriteHeader(500)
@@ -509,8 +940,8 @@ func TestDeprecateImage(t *testing.T) {
 func TestDeprecateImageAlpha(t *testing.T) {
 	svr, c, err := NewTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method == "POST" && r.URL.String() == fmt.Sprintf("/projects/%s/global/images/%s/deprecate?alt=json&prettyPrint=false", testProject, testImageAlpha) {
-			fmt.Fprint(w, `{}`)
-		} else if r.Method == "POST" && r.URL.String() == fmt.Sprintf("/projects/%s/global/operations//wait?alt=json&prettyPrint=false", testProject) {
+			fmt.Fprint(w, `{"Name":"op"}`)
+		} else if r.Method == "POST" && r.URL.String() == fmt.Sprintf("/projects/%s/global/operations/op/wait?alt=json&prettyPrint=false", testProject) {
 			fmt.Fprint(w, `{"Status":"DONE"}`)
 		} else {
 			w.WriteHeader(500)
@@ -529,8 +960,8 @@ func TestDeprecateImageAlpha(t *testing.T) {
 func TestAttachDisk(t *testing.T) {
 	svr, c, err := NewTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method == "POST" && r.URL.String() == fmt.Sprintf("/projects/%s/zones/%s/instances/%s/attachDisk?alt=json&prettyPrint=false", testProject, testZone, testInstance) {
-			fmt.Fprint(w, `{}`)
-		} else if r.Method == "POST" && r.URL.String() == fmt.Sprintf("/projects/%s/zones/%s/operations//wait?alt=json&prettyPrint=false", testProject, testZone) {
+			fmt.Fprint(w, `{"Name":"op"}`)
+		} else if r.Method == "POST" && r.URL.String() == fmt.Sprintf("/projects/%s/zones/%s/operations/op/wait?alt=json&prettyPrint=false", testProject, testZone) {
 			fmt.Fprint(w, `{"Status":"DONE"}`)
 		} else {
 			w.WriteHeader(500)
@@ -550,8 +981,8 @@ func TestAttachDisk(t *testing.T) {
 func TestDetachDisk(t *testing.T) {
 	svr, c, err := NewTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method == "POST" && r.URL.String() == fmt.Sprintf("/projects/%s/zones/%s/instances/%s/detachDisk?alt=json&deviceName=%s&prettyPrint=false", testProject, testZone, testInstance, testDisk) {
-			fmt.Fprint(w, `{}`)
-		} else if r.Method == "POST" && r.URL.String() == fmt.Sprintf("/projects/%s/zones/%s/operations//wait?alt=json&prettyPrint=false", testProject, testZone) {
+			fmt.Fprint(w, `{"Name":"op"}`)
+		} else if r.Method == "POST" && r.URL.String() == fmt.Sprintf("/projects/%s/zones/%s/operations/op/wait?alt=json&prettyPrint=false", testProject, testZone) {
 			fmt.Fprint(w, `{"Status":"DONE"}`)
 		} else {
 			w.WriteHeader(500)
@@ -571,11 +1002,13 @@ func TestDetachDisk(t *testing.T) {
 func TestSuspendResume(t *testing.T) {
 	svr, c, err := NewTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method == "POST" && r.URL.String() == fmt.Sprintf("/projects/%s/zones/%s/instances/%s/suspend?alt=json&prettyPrint=false", testProject, testZone, testInstance) {
-			fmt.Fprint(w, `{}`)
-		} else if r.Method == "POST" && r.URL.String() == fmt.Sprintf("/projects/%s/zones/%s/operations//wait?alt=json&prettyPrint=false", testProject, testZone) {
+			fmt.Fprint(w, `{"Name":"op"}`)
+		} else if r.Method == "POST" && r.URL.String() == fmt.Sprintf("/projects/%s/zones/%s/operations/op/wait?alt=json&prettyPrint=false", testProject, testZone) {
 			fmt.Fprint(w, `{"Status":"DONE"}`)
+		} else if r.Method == "GET" && r.URL.String() == fmt.Sprintf("/projects/%s/zones/%s/instances/%s?alt=json&prettyPrint=false", testProject, testZone, testInstance) {
+			fmt.Fprint(w, `{"Status":"SUSPENDED"}`)
 		} else if r.Method == "POST" && r.URL.String() == fmt.Sprintf("/projects/%s/zones/%s/instances/%s/resume?alt=json&prettyPrint=false", testProject, testZone, testInstance) {
-			fmt.Fprint(w, `{}`)
+			fmt.Fprint(w, `{"Name":"op"}`)
 		} else {
 			w.WriteHeader(500)
 			fmt.Fprintln(w, "URL and Method not recognized:", r.Method, r.URL)
@@ -593,3 +1026,2121 @@ func TestSuspendResume(t *testing.T) {
 		t.Fatalf("error running Resume: %v", err)
 	}
 }
+
+func TestResumeNotSuspended(t *testing.T) {
+	var resumeCalled bool
+	svr, c, err := NewTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" {
+			resumeCalled = true
+		}
+		w.WriteHeader(500)
+		fmt.Fprintln(w, "URL and Method not recognized:", r.Method, r.URL)
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer svr.Close()
+	c.InstanceStatusFn = func(_, _, _ string) (string, error) { return "RUNNING", nil }
+
+	if err := c.Resume(testProject, testZone, testInstance); err == nil {
+		t.Error("expected error resuming an instance that is not SUSPENDED")
+	}
+	req := &computeBeta.InstancesResumeRequest{}
+	if err := c.ResumeWithEncryptionKey(testProject, testZone, testInstance, req); err == nil {
+		t.Error("expected error resuming an instance that is not SUSPENDED")
+	}
+	if resumeCalled {
+		t.Error("resume should not be called for an instance that is not SUSPENDED")
+	}
+}
+
+func TestResumeWithEncryptionKey(t *testing.T) {
+	var gotReq computeBeta.InstancesResumeRequest
+	svr, c, err := NewTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" && r.URL.String() == fmt.Sprintf("/projects/%s/zones/%s/instances/%s/resume?alt=json&prettyPrint=false", testProject, testZone, testInstance) {
+			if err := json.NewDecoder(r.Body).Decode(&gotReq); err != nil {
+				w.WriteHeader(400)
+				fmt.Fprintln(w, err)
+				return
+			}
+			fmt.Fprint(w, `{"Name":"op"}`)
+		} else if r.Method == "POST" && r.URL.String() == fmt.Sprintf("/projects/%s/zones/%s/operations/op/wait?alt=json&prettyPrint=false", testProject, testZone) {
+			fmt.Fprint(w, `{"Status":"DONE"}`)
+		} else {
+			w.WriteHeader(500)
+			fmt.Fprintln(w, "URL and Method not recognized:", r.Method, r.URL)
+		}
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer svr.Close()
+	c.InstanceStatusFn = func(_, _, _ string) (string, error) { return "SUSPENDED", nil }
+
+	req := &computeBeta.InstancesResumeRequest{
+		Disks: []*computeBeta.CustomerEncryptionKeyProtectedDisk{
+			{Source: "disk", DiskEncryptionKey: &computeBeta.CustomerEncryptionKey{RawKey: "key"}},
+		},
+	}
+	if err := c.ResumeWithEncryptionKey(testProject, testZone, testInstance, req); err != nil {
+		t.Fatalf("error running ResumeWithEncryptionKey: %v", err)
+	}
+	if diff := pretty.Compare(&gotReq, req); diff != "" {
+		t.Errorf("resume request does not match expectation: (-got +want)\n%s", diff)
+	}
+}
+
+func TestPatchFirewallRuleBeta(t *testing.T) {
+	var got map[string]interface{}
+	svr, c, err := NewTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PATCH" && r.URL.Path == fmt.Sprintf("/projects/%s/global/firewalls/%s", testProject, testFirewallRule) {
+			got = nil
+			if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+				w.WriteHeader(400)
+				fmt.Fprintln(w, err)
+				return
+			}
+			fmt.Fprint(w, `{"Name":"op"}`)
+		} else {
+			w.WriteHeader(500)
+			fmt.Fprintln(w, "URL and Method not recognized:", r.Method, r.URL)
+		}
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer svr.Close()
+	c.globalOperationsWaitBetaFn = func(_, _ string) error { return nil }
+
+	tests := []struct {
+		desc string
+		fw   *computeBeta.Firewall
+		want map[string]interface{}
+	}{
+		{
+			"set fields only",
+			&computeBeta.Firewall{Disabled: false, LogConfig: &computeBeta.FirewallLogConfig{Enable: true}},
+			map[string]interface{}{"logConfig": map[string]interface{}{"enable": true}},
+		},
+		{
+			"forced zero value",
+			&computeBeta.Firewall{Disabled: false, ForceSendFields: []string{"Disabled"}},
+			map[string]interface{}{"disabled": false},
+		},
+	}
+	for _, tt := range tests {
+		if err := c.PatchFirewallRuleBeta(testProject, testFirewallRule, tt.fw); err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.desc, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got patch body %v, want %v", tt.desc, got, tt.want)
+		}
+	}
+}
+
+func TestListZones(t *testing.T) {
+	svr, c, err := NewTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" || r.URL.Path != fmt.Sprintf("/projects/%s/zones", testProject) {
+			w.WriteHeader(500)
+			fmt.Fprintln(w, "URL and Method not recognized:", r.Method, r.URL)
+			return
+		}
+		if got := r.URL.Query().Get("filter"); got != "status = UP" {
+			w.WriteHeader(400)
+			fmt.Fprintln(w, "unexpected filter:", got)
+			return
+		}
+		if r.URL.Query().Get("pageToken") == "" {
+			fmt.Fprint(w, `{"items":[{"name":"zone-a","status":"UP"}],"nextPageToken":"next"}`)
+			return
+		}
+		fmt.Fprint(w, `{"items":[{"name":"zone-b","status":"UP"}]}`)
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer svr.Close()
+
+	zs, err := c.ListZones(testProject, Filter("status = UP"))
+	if err != nil {
+		t.Fatalf("error running ListZones: %v", err)
+	}
+	var got []string
+	for _, z := range zs {
+		got = append(got, z.Name)
+	}
+	if want := []string{"zone-a", "zone-b"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ListZones returned %v, want %v", got, want)
+	}
+}
+
+func TestListImagesMaxResults(t *testing.T) {
+	var pages int
+	svr, c, err := NewTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" || r.URL.Path != fmt.Sprintf("/projects/%s/global/images", testProject) {
+			w.WriteHeader(500)
+			fmt.Fprintln(w, "URL and Method not recognized:", r.Method, r.URL)
+			return
+		}
+		q := r.URL.Query()
+		if q.Get("maxResults") != "3" || q.Get("orderBy") != "creationTimestamp desc" {
+			w.WriteHeader(400)
+			fmt.Fprintln(w, "unexpected query:", q)
+			return
+		}
+		pages++
+		switch q.Get("pageToken") {
+		case "":
+			fmt.Fprint(w, `{"items":[{"name":"i1"},{"name":"i2"}],"nextPageToken":"2"}`)
+		case "2":
+			fmt.Fprint(w, `{"items":[{"name":"i3"},{"name":"i4"}],"nextPageToken":"3"}`)
+		default:
+			fmt.Fprint(w, `{"items":[{"name":"i5"}]}`)
+		}
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer svr.Close()
+
+	is, err := c.ListImages(testProject, OrderBy("creationTimestamp desc"), MaxResults(3))
+	if err != nil {
+		t.Fatalf("error running ListImages: %v", err)
+	}
+	var got []string
+	for _, i := range is {
+		got = append(got, i.Name)
+	}
+	if want := []string{"i1", "i2", "i3"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ListImages returned %v, want %v", got, want)
+	}
+	if pages != 2 {
+		t.Errorf("ListImages fetched %d pages, want 2", pages)
+	}
+}
+
+func TestListMaxResults(t *testing.T) {
+	var pages int
+	svr, c, err := NewTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if q := r.URL.Query(); q.Get("maxResults") != "3" {
+			w.WriteHeader(400)
+			fmt.Fprintln(w, "unexpected query:", q)
+			return
+		}
+		pages++
+		p := strconv.Itoa(pages)
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/aggregated/disks"):
+			fmt.Fprintf(w, `{"items":{"zones/z%s":{"disks":[{"name":"a%s"},{"name":"b%s"}]}},"nextPageToken":"%s"}`, p, p, p, p)
+		default:
+			fmt.Fprintf(w, `{"items":[{"name":"a%s"},{"name":"b%s"}],"nextPageToken":"%s"}`, p, p, p)
+		}
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer svr.Close()
+
+	tests := []struct {
+		desc string
+		list func() ([]string, error)
+		want []string
+	}{
+		{"instances", func() ([]string, error) {
+			is, err := c.ListInstances(testProject, testZone, MaxResults(3))
+			var names []string
+			for _, i := range is {
+				names = append(names, i.Name)
+			}
+			return names, err
+		}, []string{"a1", "b1", "a2"}},
+		{"zone operations", func() ([]string, error) {
+			ops, err := c.ListZoneOperations(testProject, testZone, MaxResults(3))
+			var names []string
+			for _, op := range ops {
+				names = append(names, op.Name)
+			}
+			return names, err
+		}, []string{"a1", "b1", "a2"}},
+		{"disks by zone", func() ([]string, error) {
+			ds, err := c.AggregatedListDisksByZone(testProject, MaxResults(3))
+			var names []string
+			for zone, zds := range ds {
+				for _, d := range zds {
+					names = append(names, zone+"/"+d.Name)
+				}
+			}
+			sort.Strings(names)
+			return names, err
+		}, []string{"z1/a1", "z1/b1", "z2/a2"}},
+	}
+	for _, tt := range tests {
+		pages = 0
+		got, err := tt.list()
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.desc, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.desc, got, tt.want)
+		}
+		if pages != 2 {
+			t.Errorf("%s: fetched %d pages, want 2", tt.desc, pages)
+		}
+	}
+}
+
+func TestListOrderBy(t *testing.T) {
+	svr, c, err := NewTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			w.WriteHeader(500)
+			fmt.Fprintln(w, "Method not recognized:", r.Method, r.URL)
+			return
+		}
+		if got := r.URL.Query().Get("orderBy"); got != "creationTimestamp desc" {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintln(w, "unsupported orderBy:", got)
+			return
+		}
+		fmt.Fprint(w, `{}`)
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer svr.Close()
+
+	for desc, list := range map[string]func(OrderBy) error{
+		"instances": func(o OrderBy) error { _, err := c.ListInstances(testProject, testZone, o); return err },
+		"images":    func(o OrderBy) error { _, err := c.ListImages(testProject, o); return err },
+		"disks":     func(o OrderBy) error { _, err := c.ListDisks(testProject, testZone, o); return err },
+		"licenses":  func(o OrderBy) error { _, err := c.ListLicenses(testProject, o); return err },
+		"forwarding rules": func(o OrderBy) error {
+			_, err := c.ListForwardingRules(testProject, testRegion, o)
+			return err
+		},
+		"target instances": func(o OrderBy) error {
+			_, err := c.ListTargetInstances(testProject, testZone, o)
+			return err
+		},
+		"region URL maps": func(o OrderBy) error {
+			_, err := c.ListRegionURLMaps(testProject, testRegion, o)
+			return err
+		},
+	} {
+		if err := list("creationTimestamp desc"); err != nil {
+			t.Errorf("%s: unexpected error: %v", desc, err)
+		}
+		var apiErr *googleapi.Error
+		if err := list("bogus"); !errors.As(err, &apiErr) || apiErr.Code != http.StatusBadRequest {
+			t.Errorf("%s: want API error for unsupported orderBy, got %v", desc, err)
+		}
+	}
+}
+
+func TestListAttachedAccelerators(t *testing.T) {
+	_, c, err := NewTestClient(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gpu := &compute.AcceleratorConfig{AcceleratorType: "nvidia-tesla-t4", AcceleratorCount: 2}
+	c.ListInstancesFn = func(project, zone string, _ ...ListCallOption) ([]*compute.Instance, error) {
+		if project != testProject || zone != testZone {
+			return nil, fmt.Errorf("unexpected project/zone %s/%s", project, zone)
+		}
+		return []*compute.Instance{
+			{Name: "with-gpu", GuestAccelerators: []*compute.AcceleratorConfig{gpu}},
+			{Name: "without-gpu"},
+		}, nil
+	}
+
+	got, err := c.ListAttachedAccelerators(testProject, testZone)
+	if err != nil {
+		t.Fatalf("error running ListAttachedAccelerators: %v", err)
+	}
+	want := map[string][]*compute.AcceleratorConfig{"with-gpu": {gpu}}
+	if diff := pretty.Compare(got, want); diff != "" {
+		t.Errorf("ListAttachedAccelerators does not match expectation: (-got +want)\n%s", diff)
+	}
+
+	c.ListInstancesFn = func(_, _ string, _ ...ListCallOption) ([]*compute.Instance, error) {
+		return nil, errors.New("list err")
+	}
+	if _, err := c.ListAttachedAccelerators(testProject, testZone); err == nil {
+		t.Error("expected error from ListAttachedAccelerators when listing fails")
+	}
+}
+
+func TestUpdateNetworkInterfaceStaleFingerprint(t *testing.T) {
+	svr, c, err := NewTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PATCH" && r.URL.String() == fmt.Sprintf("/projects/%s/zones/%s/instances/%s/updateNetworkInterface?alt=json&networkInterface=nic0&prettyPrint=false", testProject, testZone, testInstance) {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			fmt.Fprint(w, `{"error":{"code":412,"message":"fingerprint mismatch"}}`)
+		} else {
+			w.WriteHeader(500)
+			fmt.Fprintln(w, "URL and Method not recognized:", r.Method, r.URL)
+		}
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer svr.Close()
+
+	err = c.UpdateNetworkInterface(testProject, testZone, testInstance, "nic0", &compute.NetworkInterface{Fingerprint: "stale"})
+	var apiErr *googleapi.Error
+	if !errors.As(err, &apiErr) || apiErr.Code != http.StatusPreconditionFailed {
+		t.Errorf("want 412 error, got %v", err)
+	}
+}
+
+func TestAggregatedListInstancesByZone(t *testing.T) {
+	svr, c, err := NewTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" || r.URL.Path != fmt.Sprintf("/projects/%s/aggregated/instances", testProject) {
+			w.WriteHeader(500)
+			fmt.Fprintln(w, "URL and Method not recognized:", r.Method, r.URL)
+			return
+		}
+		if r.URL.Query().Get("pageToken") == "" {
+			fmt.Fprint(w, `{"items":{"zones/a":{"instances":[{"name":"i1"}]},"zones/b":{"warning":{"code":"NO_RESULTS_ON_PAGE"}}},"nextPageToken":"p2"}`)
+			return
+		}
+		fmt.Fprint(w, `{"items":{"zones/a":{"instances":[{"name":"i2"}]},"zones/c":{"instances":[{"name":"i3"}]}}}`)
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer svr.Close()
+
+	got, err := c.AggregatedListInstancesByZone(testProject)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string][]*compute.Instance{
+		"a": {{Name: "i1"}, {Name: "i2"}},
+		"c": {{Name: "i3"}},
+	}
+	if diff := pretty.Compare(got, want); diff != "" {
+		t.Errorf("instances do not match expectation: (-got +want)\n%s", diff)
+	}
+}
+
+func TestGetLatestImageFromFamilies(t *testing.T) {
+	_, c, err := NewTestClient(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var tried []string
+	c.GetImageFromFamilyFn = func(project, family string) (*compute.Image, error) {
+		tried = append(tried, project)
+		switch project {
+		case "has-family", "also-has-family":
+			return &compute.Image{Name: project + "-" + family}, nil
+		case "forbidden":
+			return nil, &googleapi.Error{Code: 403}
+		}
+		return nil, &googleapi.Error{Code: 404}
+	}
+
+	tests := []struct {
+		desc      string
+		projects  []string
+		want      string
+		wantTried []string
+		wantErr   bool
+	}{
+		{"first project", []string{"has-family", "also-has-family"}, "has-family-fam", []string{"has-family"}, false},
+		{"falls back", []string{"missing", "has-family"}, "has-family-fam", []string{"missing", "has-family"}, false},
+		{"not found anywhere", []string{"missing", "missing-too"}, "", []string{"missing", "missing-too"}, true},
+		{"other error", []string{"forbidden", "has-family"}, "", []string{"forbidden"}, true},
+	}
+	for _, tt := range tests {
+		tried = nil
+		got, err := c.GetLatestImageFromFamilies(tt.projects, "fam")
+		if tt.wantErr != (err != nil) {
+			t.Errorf("%s: unexpected error: %v", tt.desc, err)
+		}
+		if err == nil && got.Name != tt.want {
+			t.Errorf("%s: got image %q, want %q", tt.desc, got.Name, tt.want)
+		}
+		if !reflect.DeepEqual(tried, tt.wantTried) {
+			t.Errorf("%s: tried projects %q, want %q", tt.desc, tried, tt.wantTried)
+		}
+	}
+}
+
+func TestCreateInstanceFromMachineImage(t *testing.T) {
+	var got *compute.Instance
+	svr, c, err := NewTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" && r.URL.String() == fmt.Sprintf("/projects/%s/zones/%s/instances?alt=json&prettyPrint=false", testProject, testZone) {
+			body, _ := io.ReadAll(r.Body)
+			json.Unmarshal(body, &got)
+			fmt.Fprint(w, `{}`)
+		} else {
+			w.WriteHeader(500)
+			fmt.Fprintln(w, "URL and Method not recognized:", r.Method, r.URL)
+		}
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer svr.Close()
+	c.zoneOperationsWaitFn = func(_, _, _ string) error { return nil }
+	c.GetInstanceFn = func(_, _, _ string) (*compute.Instance, error) { return &compute.Instance{}, nil }
+
+	mi := fmt.Sprintf("projects/%s/global/machineImages/mi", testProject)
+	i := &compute.Instance{Name: testInstance, MachineType: "zones/z/machineTypes/n1-standard-2"}
+	if err := c.CreateInstanceFromMachineImage(testProject, testZone, mi, i); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := &compute.Instance{Name: testInstance, MachineType: "zones/z/machineTypes/n1-standard-2", SourceMachineImage: mi}
+	if diff := pretty.Compare(got, want); diff != "" {
+		t.Errorf("inserted instance does not match expectation: (-got +want)\n%s", diff)
+	}
+}
+
+func TestCreateDiskFromSnapshot(t *testing.T) {
+	_, c, err := NewTestClient(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.GetSnapshotFn = func(project, name string) (*compute.Snapshot, error) {
+		if project != "snap-project" || name != "snap" {
+			t.Errorf("got snapshot %s/%s, want snap-project/snap", project, name)
+		}
+		return &compute.Snapshot{Name: name, DiskSizeGb: 10}, nil
+	}
+	var got *compute.Disk
+	c.CreateDiskFn = func(_, _ string, d *compute.Disk) error {
+		got = d
+		return nil
+	}
+	snapshot := "https://www.googleapis.com/compute/v1/projects/snap-project/global/snapshots/snap"
+
+	if err := c.CreateDiskFromSnapshot(testProject, testZone, testDisk, snapshot, 20, "pd-ssd"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := &compute.Disk{Name: testDisk, SourceSnapshot: snapshot, SizeGb: 20, Type: DiskTypeURL(testProject, testZone, "pd-ssd")}
+	if diff := pretty.Compare(got, want); diff != "" {
+		t.Errorf("created disk does not match expectation: (-got +want)\n%s", diff)
+	}
+
+	got = nil
+	if err := c.CreateDiskFromSnapshot(testProject, testZone, testDisk, snapshot, 5, ""); err == nil || !strings.Contains(err.Error(), "cannot be smaller") {
+		t.Errorf("want error on shrink, got %v", err)
+	}
+	if got != nil {
+		t.Error("disk created despite shrink")
+	}
+	if err := c.CreateDiskFromSnapshot(testProject, testZone, testDisk, "snap", 0, ""); err == nil {
+		t.Error("want error for invalid snapshot URL")
+	}
+}
+
+func TestCloneDiskReencrypt(t *testing.T) {
+	_, c, err := NewTestClient(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var src *compute.Disk
+	c.GetDiskFn = func(_, _, _ string) (*compute.Disk, error) { return src, nil }
+	var got *compute.Disk
+	c.CreateDiskFn = func(_, _ string, d *compute.Disk) error {
+		got = d
+		return nil
+	}
+	newKey := CMEK("projects/p/locations/l/keyRings/r/cryptoKeys/new")
+
+	tests := []struct {
+		desc    string
+		srcKey  *compute.CustomerEncryptionKey
+		key     *compute.CustomerEncryptionKey
+		wantErr bool
+	}{
+		{"google managed", nil, nil, false},
+		{"google managed to CMEK", nil, newKey, false},
+		{"CMEK to CMEK", CMEK("projects/p/locations/l/keyRings/r/cryptoKeys/old"), newKey, false},
+		{"CMEK without new key", CMEK("projects/p/locations/l/keyRings/r/cryptoKeys/old"), nil, true},
+		{"CSEK", &compute.CustomerEncryptionKey{Sha256: "abc"}, newKey, true},
+	}
+	for _, tt := range tests {
+		src = &compute.Disk{Name: "src", SelfLink: "projects/p/zones/z/disks/src", DiskEncryptionKey: tt.srcKey}
+		got = nil
+		err := c.CloneDiskReencrypt(testProject, testZone, "src", testDisk, tt.key)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: got error %v, want error %t", tt.desc, err, tt.wantErr)
+		}
+		if tt.wantErr {
+			if got != nil {
+				t.Errorf("%s: disk created despite error", tt.desc)
+			}
+			continue
+		}
+		want := &compute.Disk{Name: testDisk, SourceDisk: "projects/p/zones/z/disks/src", DiskEncryptionKey: tt.key}
+		if diff := pretty.Compare(got, want); diff != "" {
+			t.Errorf("%s: created disk does not match expectation: (-got +want)\n%s", tt.desc, diff)
+		}
+	}
+}
+
+func TestCreateImageFromEncryptedDisk(t *testing.T) {
+	_, c, err := NewTestClient(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var srcKey *compute.CustomerEncryptionKey
+	var gotDisk string
+	c.GetDiskFn = func(project, zone, name string) (*compute.Disk, error) {
+		gotDisk = project + "/" + zone + "/" + name
+		return &compute.Disk{Name: name, DiskEncryptionKey: srcKey}, nil
+	}
+	c.GetRegionDiskFn = func(project, region, name string) (*compute.Disk, error) {
+		gotDisk = project + "/regions/" + region + "/" + name
+		return &compute.Disk{Name: name, DiskEncryptionKey: srcKey}, nil
+	}
+	var got *compute.Image
+	c.CreateImageFn = func(_ string, im *compute.Image) error {
+		got = im
+		return nil
+	}
+	key := CMEK("projects/p/locations/l/keyRings/r/cryptoKeys/k")
+
+	tests := []struct {
+		desc, sourceDisk, wantDisk string
+		srcKey, key                *compute.CustomerEncryptionKey
+		wantErr                    bool
+	}{
+		{"CMEK", "projects/p/zones/z/disks/d", "p/z/d", key, key, false},
+		{"partial URL", "zones/z/disks/d", testProject + "/z/d", key, key, false},
+		{"full URL", "https://www.googleapis.com/compute/v1/projects/p/zones/z/disks/d", "p/z/d", key, key, false},
+		{"regional disk", "projects/p/regions/r/disks/d", "p/regions/r/d", key, key, false},
+		{"regional disk without key", "regions/r/disks/d", testProject + "/regions/r/d", key, nil, true},
+		{"unencrypted", "zones/z/disks/d", testProject + "/z/d", nil, nil, false},
+		{"CMEK without key", "zones/z/disks/d", testProject + "/z/d", key, nil, true},
+		{"CSEK without key", "zones/z/disks/d", testProject + "/z/d", &compute.CustomerEncryptionKey{Sha256: "abc"}, nil, true},
+		{"bad source disk", "global/images/i", "", nil, key, true},
+	}
+	for _, tt := range tests {
+		srcKey, gotDisk, got = tt.srcKey, "", nil
+		im := &compute.Image{Name: testImage, SourceDisk: tt.sourceDisk}
+		err := c.CreateImageFromEncryptedDisk(testProject, im, tt.key)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: got error %v, want error %t", tt.desc, err, tt.wantErr)
+		}
+		if gotDisk != tt.wantDisk {
+			t.Errorf("%s: got source disk %q, want %q", tt.desc, gotDisk, tt.wantDisk)
+		}
+		if tt.wantErr {
+			if got != nil {
+				t.Errorf("%s: image created despite error", tt.desc)
+			}
+			continue
+		}
+		want := &compute.Image{Name: testImage, SourceDisk: tt.sourceDisk, SourceDiskEncryptionKey: tt.key}
+		if diff := pretty.Compare(got, want); diff != "" {
+			t.Errorf("%s: created image does not match expectation: (-got +want)\n%s", tt.desc, diff)
+		}
+	}
+}
+
+func TestCopyImageToLocation(t *testing.T) {
+	_, c, err := NewTestClient(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var gotProject string
+	var got *compute.Image
+	var createErr error
+	c.CreateImageFn = func(project string, im *compute.Image) error {
+		gotProject, got = project, im
+		return createErr
+	}
+
+	if err := c.CopyImageToLocation("src", "im", "dst", "im-eu", []string{"eu"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := &compute.Image{Name: "im-eu", SourceImage: "projects/src/global/images/im", StorageLocations: []string{"eu"}}
+	if diff := pretty.Compare(got, want); diff != "" || gotProject != "dst" {
+		t.Errorf("created image in %q does not match expectation: (-got +want)\n%s", gotProject, diff)
+	}
+
+	createErr = &googleapi.Error{Code: http.StatusForbidden}
+	err = c.CopyImageToLocation("src", "im", "dst", "im-eu", []string{"eu"})
+	if err == nil || !strings.Contains(err.Error(), "roles/compute.imageUser") {
+		t.Errorf("want permission hint, got %v", err)
+	}
+	var apiErr *googleapi.Error
+	if !errors.As(err, &apiErr) {
+		t.Errorf("error %v does not wrap the API error", err)
+	}
+}
+
+func TestGetOperationsForTarget(t *testing.T) {
+	_, c, err := NewTestClient(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got string
+	record := func(scope string, opts []ListCallOption) {
+		got = scope
+		for _, o := range opts {
+			got += " " + string(o.(Filter))
+		}
+	}
+	c.ListZoneOperationsFn = func(_, zone string, opts ...ListCallOption) ([]*compute.Operation, error) {
+		record("zones/"+zone, opts)
+		return nil, nil
+	}
+	c.ListRegionOperationsFn = func(_, region string, opts ...ListCallOption) ([]*compute.Operation, error) {
+		record("regions/"+region, opts)
+		return nil, nil
+	}
+	c.ListGlobalOperationsFn = func(_ string, opts ...ListCallOption) ([]*compute.Operation, error) {
+		record("global", opts)
+		return nil, nil
+	}
+
+	tests := []struct {
+		scope, target, want string
+	}{
+		{"zones/z", "https://www.googleapis.com/compute/v1/projects/p/zones/z/instances/i", `zones/z targetLink eq ".*/projects/p/zones/z/instances/i"`},
+		{"regions/r", "projects/p/regions/r/forwardingRules/fr", `regions/r targetLink eq ".*/projects/p/regions/r/forwardingRules/fr"`},
+		{"global", "projects/p/global/images/im.v1", `global targetLink eq ".*/projects/p/global/images/im\.v1"`},
+	}
+	for _, tt := range tests {
+		got = ""
+		if _, err := c.GetOperationsForTarget(testProject, tt.scope, tt.target); err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.scope, err)
+		}
+		if got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.scope, got, tt.want)
+		}
+	}
+	if _, err := c.GetOperationsForTarget(testProject, "zones", "projects/p/zones/z/instances/i"); err == nil {
+		t.Error("want error for invalid scope")
+	}
+}
+
+func TestDeprecateImageFamily(t *testing.T) {
+	_, c, err := NewTestClient(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.ListImagesFn = func(_ string, opts ...ListCallOption) ([]*compute.Image, error) {
+		if len(opts) != 1 || opts[0] != Filter("family = fam") {
+			t.Errorf("got list options %v, want family filter", opts)
+		}
+		return []*compute.Image{
+			{Name: "v2", CreationTimestamp: "2020-02-01T00:00:00.000-08:00"},
+			{Name: "v4", CreationTimestamp: "2020-04-01T00:00:00.000-07:00"},
+			{Name: "v1", CreationTimestamp: "2020-01-01T00:00:00.000-08:00"},
+			{Name: "v3", CreationTimestamp: "2020-03-01T00:00:00.000-08:00"},
+		}, nil
+	}
+	var deprecated []string
+	c.DeprecateImageFn = func(_, name string, ds *compute.DeprecationStatus) error {
+		deprecated = append(deprecated, name)
+		if name == "v2" {
+			return errors.New("deprecate failed")
+		}
+		return nil
+	}
+	status := &compute.DeprecationStatus{State: "DEPRECATED", Replacement: "v4"}
+
+	err = c.DeprecateImageFamily(testProject, "fam", 2, status)
+	if err == nil || !strings.Contains(err.Error(), `error deprecating image "v2"`) {
+		t.Errorf("want error for v2, got %v", err)
+	}
+	if want := []string{"v2", "v1"}; !reflect.DeepEqual(deprecated, want) {
+		t.Errorf("got deprecated images %q, want %q", deprecated, want)
+	}
+
+	deprecated = nil
+	if err := c.DeprecateImageFamily(testProject, "fam", 5, status); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if deprecated != nil {
+		t.Errorf("got deprecated images %q, want none", deprecated)
+	}
+}
+
+func TestCreateInstanceAndGet(t *testing.T) {
+	svr, c, err := NewTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" && r.URL.String() == fmt.Sprintf("/projects/%s/zones/%s/instances?alt=json&prettyPrint=false", testProject, testZone) {
+			fmt.Fprint(w, `{"Name":"op"}`)
+		} else {
+			w.WriteHeader(500)
+			fmt.Fprintln(w, "URL and Method not recognized:", r.Method, r.URL)
+		}
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer svr.Close()
+	c.zoneOperationsWaitFn = func(_, _, _ string) error { return nil }
+	created := &compute.Instance{
+		Name:              testInstance,
+		SelfLink:          "link",
+		NetworkInterfaces: []*compute.NetworkInterface{{NetworkIP: "10.0.0.2"}},
+	}
+	c.GetInstanceFn = func(_, _, _ string) (*compute.Instance, error) { return created, nil }
+
+	got, err := c.CreateInstanceAndGet(testProject, testZone, &compute.Instance{Name: testInstance})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff := pretty.Compare(got, created); diff != "" {
+		t.Errorf("returned instance does not match expectation: (-got +want)\n%s", diff)
+	}
+}
+
+func TestResourceErrors(t *testing.T) {
+	svr, c, err := NewTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprintln(w, "not found")
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer svr.Close()
+
+	tests := []struct {
+		desc, wantPrefix string
+		do               func() error
+	}{
+		{"create", fmt.Sprintf("create disk %s/%s/%s: ", testProject, testZone, testDisk), func() error {
+			return c.CreateDisk(testProject, testZone, &compute.Disk{Name: testDisk})
+		}},
+		{"get", fmt.Sprintf("get image %s/%s: ", testProject, testImage), func() error {
+			_, err := c.GetImage(testProject, testImage)
+			return err
+		}},
+		{"delete", fmt.Sprintf("delete forwarding rule %s/%s/%s: ", testProject, testRegion, testForwardingRule), func() error {
+			return c.DeleteForwardingRule(testProject, testRegion, testForwardingRule)
+		}},
+		{"update", fmt.Sprintf("update instance %s/%s/%s: ", testProject, testZone, testInstance), func() error {
+			return c.UpdateInstance(testProject, testZone, &compute.Instance{Name: testInstance})
+		}},
+		{"get IAM policy", fmt.Sprintf("get IAM policy of instance %s/%s/%s: ", testProject, testZone, testInstance), func() error {
+			_, err := c.GetInstanceIamPolicy(testProject, testZone, testInstance)
+			return err
+		}},
+		{"set IAM policy", fmt.Sprintf("set IAM policy of instance %s/%s/%s: ", testProject, testZone, testInstance), func() error {
+			return c.SetInstanceIamPolicy(testProject, testZone, testInstance, &compute.Policy{})
+		}},
+		{"test IAM permissions", fmt.Sprintf("test IAM permissions of instance %s/%s/%s: ", testProject, testZone, testInstance), func() error {
+			_, err := c.TestInstanceIamPermissions(testProject, testZone, testInstance, []string{"compute.instances.get"})
+			return err
+		}},
+		{"get firewall policy", "get firewall policy 123: ", func() error {
+			_, err := c.GetFirewallPolicy("123")
+			return err
+		}},
+		{"add firewall policy association", "add firewall policy association 123: ", func() error {
+			return c.AddFirewallPolicyAssociation("123", &compute.FirewallPolicyAssociation{AttachmentTarget: "folders/1"})
+		}},
+		{"add firewall policy rule", "add firewall policy rule 123: ", func() error {
+			return c.AddFirewallPolicyRule("123", &compute.FirewallPolicyRule{Priority: 1000})
+		}},
+		{"send diagnostic interrupt", fmt.Sprintf("send diagnostic interrupt to instance %s/%s/%s: ", testProject, testZone, testInstance), func() error {
+			return c.SendDiagnosticInterrupt(testProject, testZone, testInstance)
+		}},
+		{"create beta", fmt.Sprintf("create instance %s/%s/%s: ", testProject, testZone, testInstance), func() error {
+			return c.CreateInstanceBeta(testProject, testZone, &computeBeta.Instance{Name: testInstance})
+		}},
+		{"get alpha", fmt.Sprintf("get disk %s/%s/%s: ", testProject, testZone, testDisk), func() error {
+			_, err := c.GetDiskAlpha(testProject, testZone, testDisk)
+			return err
+		}},
+		{"get zone", fmt.Sprintf("get zone %s/%s: ", testProject, testZone), func() error {
+			_, err := c.GetZone(testProject, testZone)
+			return err
+		}},
+		{"get image from family", fmt.Sprintf("get image from family %s/fam: ", testProject), func() error {
+			_, err := c.GetImageFromFamily(testProject, "fam")
+			return err
+		}},
+	}
+	for _, tt := range tests {
+		err := tt.do()
+		if err == nil || !strings.HasPrefix(err.Error(), tt.wantPrefix) {
+			t.Errorf("%s: got error %v, want prefix %q", tt.desc, err, tt.wantPrefix)
+		}
+		if !IsNotFound(err) {
+			t.Errorf("%s: IsNotFound(%v) = false, want true", tt.desc, err)
+		}
+	}
+}
+
+func TestUpdateInstance(t *testing.T) {
+	var got *compute.Instance
+	svr, c, err := NewTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PUT" && r.URL.String() == fmt.Sprintf("/projects/%s/zones/%s/instances/%s?alt=json&prettyPrint=false", testProject, testZone, testInstance) {
+			got = &compute.Instance{}
+			if err := json.NewDecoder(r.Body).Decode(got); err != nil {
+				t.Error(err)
+			}
+			fmt.Fprint(w, `{"Name":"op"}`)
+		} else {
+			w.WriteHeader(500)
+			fmt.Fprintln(w, "URL and Method not recognized:", r.Method, r.URL)
+		}
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer svr.Close()
+	c.zoneOperationsWaitFn = func(_, _, _ string) error { return nil }
+	c.GetInstanceFn = func(_, _, _ string) (*compute.Instance, error) {
+		return &compute.Instance{
+			Name:        testInstance,
+			Description: "current",
+			Fingerprint: "fp",
+			Labels:      map[string]string{"a": "b"},
+			Scheduling:  &compute.Scheduling{Preemptible: true},
+		}, nil
+	}
+	i := &compute.Instance{
+		Name:        testInstance,
+		Description: "ignored",
+		Scheduling:  &compute.Scheduling{OnHostMaintenance: "MIGRATE"},
+	}
+
+	tests := []struct {
+		desc    string
+		fields  []string
+		want    *compute.Instance
+		wantErr bool
+	}{
+		{"whole instance", nil, i, false},
+		{
+			"scheduling and labels",
+			[]string{"scheduling", "labels"},
+			&compute.Instance{
+				Name:        testInstance,
+				Description: "current",
+				Fingerprint: "fp",
+				Scheduling:  &compute.Scheduling{OnHostMaintenance: "MIGRATE"},
+			},
+			false,
+		},
+		{"unknown field", []string{"nope"}, nil, true},
+	}
+	for _, tt := range tests {
+		got = nil
+		err := c.UpdateInstance(testProject, testZone, i, tt.fields...)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: got error %v, want error %t", tt.desc, err, tt.wantErr)
+		}
+		if diff := pretty.Compare(got, tt.want); diff != "" {
+			t.Errorf("%s: sent instance does not match expectation: (-got +want)\n%s", tt.desc, diff)
+		}
+	}
+}
+
+func TestPickZone(t *testing.T) {
+	_, c, err := NewTestClient(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.ListZonesFn = func(_ string, opts ...ListCallOption) ([]*compute.Zone, error) {
+		if len(opts) != 1 || opts[0] != Filter("status = UP") {
+			t.Errorf("got list options %v, want status filter", opts)
+		}
+		return []*compute.Zone{
+			{Name: "r1-c", Region: "projects/p/regions/r1"},
+			{Name: "r2-a", Region: "projects/p/regions/r2"},
+			{Name: "r1-b", Region: "projects/p/regions/r1"},
+			{Name: "r1-a", Region: "projects/p/regions/r1"},
+		}, nil
+	}
+	c.GetMachineTypeFn = func(_, zone, machineType string) (*compute.MachineType, error) {
+		if zone == "r1-a" || machineType == "rare" && zone != "r1-c" {
+			return nil, &googleapi.Error{Code: http.StatusNotFound}
+		}
+		return &compute.MachineType{Name: machineType}, nil
+	}
+
+	tests := []struct {
+		desc, region, machineType, want string
+		wantErr                         bool
+	}{
+		{"first with machine type", "r1", "common", "r1-b", false},
+		{"later zone", "r1", "rare", "r1-c", false},
+		{"no zone with machine type", "r2", "rare", "", true},
+		{"unknown region", "r3", "common", "", true},
+	}
+	for _, tt := range tests {
+		got, err := c.PickZone(testProject, tt.region, tt.machineType)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: got error %v, want error %t", tt.desc, err, tt.wantErr)
+		}
+		if got != tt.want {
+			t.Errorf("%s: got zone %q, want %q", tt.desc, got, tt.want)
+		}
+	}
+}
+
+func TestInstanceIam(t *testing.T) {
+	base := fmt.Sprintf("/projects/%s/zones/%s/instances/%s", testProject, testZone, testInstance)
+	var gotSet *compute.ZoneSetPolicyRequest
+	svr, c, err := NewTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch url := r.URL.String(); {
+		case r.Method == "GET" && url == base+"/getIamPolicy?alt=json&prettyPrint=false":
+			fmt.Fprint(w, `{"etag":"e1","bindings":[{"role":"roles/compute.osLogin","members":["user:a@example.com"]}]}`)
+		case r.Method == "POST" && url == base+"/setIamPolicy?alt=json&prettyPrint=false":
+			gotSet = &compute.ZoneSetPolicyRequest{}
+			if err := json.NewDecoder(r.Body).Decode(gotSet); err != nil {
+				t.Error(err)
+			}
+			fmt.Fprint(w, `{"etag":"e2"}`)
+		case r.Method == "POST" && url == base+"/testIamPermissions?alt=json&prettyPrint=false":
+			fmt.Fprint(w, `{"permissions":["compute.instances.get"]}`)
+		default:
+			w.WriteHeader(500)
+			fmt.Fprintln(w, "URL and Method not recognized:", r.Method, url)
+		}
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer svr.Close()
+
+	p, err := c.GetInstanceIamPolicy(testProject, testZone, testInstance)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.Etag != "e1" || len(p.Bindings) != 1 {
+		t.Errorf("unexpected policy: %+v", p)
+	}
+
+	p.Bindings[0].Members = append(p.Bindings[0].Members, "user:b@example.com")
+	if err := c.SetInstanceIamPolicy(testProject, testZone, testInstance, p); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotSet == nil || gotSet.Policy == nil || gotSet.Policy.Etag != "e1" || !reflect.DeepEqual(gotSet.Policy.Bindings[0].Members, p.Bindings[0].Members) {
+		t.Errorf("unexpected set request: %+v", gotSet)
+	}
+
+	got, err := c.TestInstanceIamPermissions(testProject, testZone, testInstance, []string{"compute.instances.get", "compute.instances.setIamPolicy"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []string{"compute.instances.get"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got permissions %q, want %q", got, want)
+	}
+}
+
+func TestWaitForBackendServiceHealthy(t *testing.T) {
+	_, c, err := NewTestClient(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var polls int
+	c.GetRegionBackendServiceHealthFn = func(_, _, _, _ string) (*compute.BackendServiceGroupHealth, error) {
+		polls++
+		h := &compute.BackendServiceGroupHealth{HealthStatus: []*compute.HealthStatus{
+			{HealthState: "HEALTHY"},
+			{HealthState: "UNHEALTHY"},
+			{HealthState: "UNHEALTHY"},
+		}}
+		if polls >= 3 {
+			h.HealthStatus[1].HealthState = "HEALTHY"
+		}
+		return h, nil
+	}
+
+	if err := c.WaitForBackendServiceHealthy(testProject, testRegion, testBackendService, "ig", 2, time.Minute); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if polls != 3 {
+		t.Errorf("got %d polls, want 3", polls)
+	}
+
+	polls = 0
+	err = c.WaitForBackendServiceHealthy(testProject, testRegion, testBackendService, "ig", 3, time.Minute)
+	if err == nil || !strings.Contains(err.Error(), "(1 unhealthy)") {
+		t.Errorf("want timeout error with unhealthy count, got %v", err)
+	}
+
+	// Global backend services use the global health call.
+	var globalPolls int
+	c.GetBackendServiceHealthFn = func(_, _, _ string) (*compute.BackendServiceGroupHealth, error) {
+		globalPolls++
+		return &compute.BackendServiceGroupHealth{HealthStatus: []*compute.HealthStatus{{HealthState: "HEALTHY"}}}, nil
+	}
+	for _, region := range []string{"", "global"} {
+		polls, globalPolls = 0, 0
+		if err := c.WaitForBackendServiceHealthy(testProject, region, testBackendService, "ig", 1, time.Minute); err != nil {
+			t.Errorf("region %q: unexpected error: %v", region, err)
+		}
+		if polls != 0 || globalPolls != 1 {
+			t.Errorf("region %q: got %d regional and %d global polls, want 0 and 1", region, polls, globalPolls)
+		}
+	}
+}
+
+func TestBulkInsertInstanceTemplate(t *testing.T) {
+	var inserted bool
+	svr, c, err := NewTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" && r.URL.String() == fmt.Sprintf("/projects/%s/zones/%s/instances/bulkInsert?alt=json&prettyPrint=false", testProject, testZone) {
+			inserted = true
+			fmt.Fprint(w, `{"Name":"op"}`)
+		} else {
+			w.WriteHeader(500)
+			fmt.Fprintln(w, "URL and Method not recognized:", r.Method, r.URL)
+		}
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer svr.Close()
+	WithValidation()(&c.client)
+	c.zoneOperationsWaitFn = func(_, _, _ string) error { return nil }
+	var gotTemplate string
+	c.GetInstanceTemplateFn = func(project, name string) (*compute.InstanceTemplate, error) {
+		gotTemplate = project + "/" + name
+		if name != "tmpl" {
+			return nil, &googleapi.Error{Code: http.StatusNotFound}
+		}
+		return &compute.InstanceTemplate{Name: name}, nil
+	}
+
+	tests := []struct {
+		desc, template, wantTemplate string
+		props                        *compute.InstanceProperties
+		wantErr                      bool
+	}{
+		{"template", "global/instanceTemplates/tmpl", testProject + "/tmpl", nil, false},
+		{"template in other project", "https://www.googleapis.com/compute/v1/projects/p/global/instanceTemplates/tmpl", "p/tmpl", nil, false},
+		{"missing template", "global/instanceTemplates/nope", testProject + "/nope", nil, true},
+		{"regional template", "regions/r/instanceTemplates/nope", "", nil, false},
+		{"properties", "", "", &compute.InstanceProperties{}, false},
+		{"neither", "", "", nil, true},
+	}
+	for _, tt := range tests {
+		inserted, gotTemplate = false, ""
+		err := c.BulkInsertInstance(testProject, testZone, &compute.BulkInsertInstanceResource{
+			Count:                  1,
+			SourceInstanceTemplate: tt.template,
+			InstanceProperties:     tt.props,
+		})
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: got error %v, want error %t", tt.desc, err, tt.wantErr)
+		}
+		if inserted == tt.wantErr {
+			t.Errorf("%s: got bulk insert request %t, want %t", tt.desc, inserted, !tt.wantErr)
+		}
+		if gotTemplate != tt.wantTemplate {
+			t.Errorf("%s: checked template %q, want %q", tt.desc, gotTemplate, tt.wantTemplate)
+		}
+	}
+}
+
+func TestWaitForInstanceGroupManagerStable(t *testing.T) {
+	_, c, err := NewTestClient(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var polls int
+	igm := func(_, _, name string) (*compute.InstanceGroupManager, error) {
+		polls++
+		return &compute.InstanceGroupManager{
+			Name:           name,
+			Status:         &compute.InstanceGroupManagerStatus{IsStable: polls >= 3},
+			CurrentActions: &compute.InstanceGroupManagerActionsSummary{Creating: 2},
+		}, nil
+	}
+	c.GetInstanceGroupManagerFn = igm
+	c.GetRegionInstanceGroupManagerFn = igm
+
+	for desc, wait := range map[string]func(time.Duration) error{
+		"zonal": func(timeout time.Duration) error {
+			return c.WaitForInstanceGroupManagerStable(testProject, testZone, "mig", timeout)
+		},
+		"regional": func(timeout time.Duration) error {
+			return c.WaitForRegionInstanceGroupManagerStable(testProject, testRegion, "mig", timeout)
+		},
+	} {
+		polls = 0
+		if err := wait(time.Minute); err != nil {
+			t.Errorf("%s: unexpected error: %v", desc, err)
+		}
+		if polls != 3 {
+			t.Errorf("%s: got %d polls, want 3", desc, polls)
+		}
+
+		polls = 0
+		if err := wait(time.Second); err == nil || !strings.Contains(err.Error(), "2 creating") {
+			t.Errorf("%s: want timeout error with current actions, got %v", desc, err)
+		}
+	}
+}
+
+func TestSkipReadback(t *testing.T) {
+	svr, c, err := NewTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" && r.URL.String() == fmt.Sprintf("/projects/%s/zones/%s/instances?alt=json&prettyPrint=false", testProject, testZone) {
+			fmt.Fprint(w, `{"Name":"op"}`)
+		} else {
+			w.WriteHeader(500)
+			fmt.Fprintln(w, "URL and Method not recognized:", r.Method, r.URL)
+		}
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer svr.Close()
+	WithSkipReadback()(&c.client)
+	c.zoneOperationsWaitFn = func(_, _, _ string) error { return nil }
+	var gets int
+	created := &compute.Instance{Name: testInstance, SelfLink: "link"}
+	c.GetInstanceFn = func(_, _, _ string) (*compute.Instance, error) {
+		gets++
+		return created, nil
+	}
+
+	i := &compute.Instance{Name: testInstance}
+	if err := c.CreateInstance(testProject, testZone, i); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gets != 0 {
+		t.Errorf("CreateInstance read the instance back %d times, want 0", gets)
+	}
+	if diff := pretty.Compare(i, &compute.Instance{Name: testInstance}); diff != "" {
+		t.Errorf("instance was modified: (-got +want)\n%s", diff)
+	}
+
+	got, err := c.CreateInstanceAndGet(testProject, testZone, &compute.Instance{Name: testInstance})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gets != 1 {
+		t.Errorf("CreateInstanceAndGet read the instance back %d times, want 1", gets)
+	}
+	if diff := pretty.Compare(got, created); diff != "" {
+		t.Errorf("returned instance does not match expectation: (-got +want)\n%s", diff)
+	}
+
+	// CreateInstanceIfNotExists looks the instance up before creating it.
+	gets = 0
+	c.GetInstanceFn = func(_, _, _ string) (*compute.Instance, error) {
+		gets++
+		if gets == 1 {
+			return nil, &googleapi.Error{Code: http.StatusNotFound}
+		}
+		return created, nil
+	}
+	i = &compute.Instance{Name: testInstance}
+	if ok, err := c.CreateInstanceIfNotExists(testProject, testZone, i); err != nil || !ok {
+		t.Fatalf("got (%t, %v), want (true, nil)", ok, err)
+	}
+	if gets != 2 {
+		t.Errorf("CreateInstanceIfNotExists got the instance %d times, want 2", gets)
+	}
+	if diff := pretty.Compare(i, created); diff != "" {
+		t.Errorf("instance does not match expectation: (-got +want)\n%s", diff)
+	}
+}
+
+func TestTeardownByLabel(t *testing.T) {
+	_, c, err := NewTestClient(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantFilter := Filter("labels.daisy-workflow = wf")
+	checkFilter := func(opts []ListCallOption) {
+		if len(opts) != 1 || opts[0] != wantFilter {
+			t.Errorf("got list options %v, want [%v]", opts, wantFilter)
+		}
+	}
+	c.AggregatedListForwardingRulesFn = func(_ string, opts ...ListCallOption) ([]*compute.ForwardingRule, error) {
+		checkFilter(opts)
+		return []*compute.ForwardingRule{{Name: "fr", Region: "projects/p/regions/r"}}, nil
+	}
+	c.AggregatedListInstancesByZoneFn = func(_ string, opts ...ListCallOption) (map[string][]*compute.Instance, error) {
+		checkFilter(opts)
+		return map[string][]*compute.Instance{"z1": {{Name: "i1"}}, "z2": {{Name: "i2"}}}, nil
+	}
+	c.AggregatedListDisksByZoneFn = func(_ string, opts ...ListCallOption) (map[string][]*compute.Disk, error) {
+		checkFilter(opts)
+		return map[string][]*compute.Disk{"z1": {{Name: "d1"}, {Name: "gone"}}}, nil
+	}
+	c.ListImagesFn = func(_ string, opts ...ListCallOption) ([]*compute.Image, error) {
+		checkFilter(opts)
+		return []*compute.Image{{Name: "im"}}, nil
+	}
+	c.ListSnapshotsFn = func(_ string, opts ...ListCallOption) ([]*compute.Snapshot, error) {
+		checkFilter(opts)
+		return []*compute.Snapshot{{Name: "s"}}, nil
+	}
+
+	var mu sync.Mutex
+	var deleted []string
+	record := func(r string) {
+		mu.Lock()
+		deleted = append(deleted, r)
+		mu.Unlock()
+	}
+	c.DeleteForwardingRuleFn = func(_, region, name string) error { record(region + "/" + name); return nil }
+	c.DeleteInstanceFn = func(_, zone, name string) error { record(zone + "/" + name); return nil }
+	c.DeleteDiskFn = func(_, zone, name string) error {
+		if name == "gone" {
+			return &googleapi.Error{Code: http.StatusNotFound}
+		}
+		record(zone + "/" + name)
+		return nil
+	}
+	c.DeleteImageFn = func(_, name string) error { return errors.New("image in use") }
+	c.DeleteSnapshotFn = func(_, name string) error { record(name); return nil }
+
+	err = c.TeardownByLabel(testProject, "daisy-workflow", "wf")
+	if err == nil || !strings.Contains(err.Error(), `error deleting image "im": image in use`) {
+		t.Errorf("want image delete error, got %v", err)
+	}
+	if len(deleted) != 5 {
+		t.Fatalf("got deletes %q, want 5", deleted)
+	}
+	sort.Strings(deleted[1:3])
+	if want := []string{"r/fr", "z1/i1", "z2/i2", "z1/d1", "s"}; !reflect.DeepEqual(deleted, want) {
+		t.Errorf("got deletes %q, want %q", deleted, want)
+	}
+}
+
+func TestTeardownByLabelSameNames(t *testing.T) {
+	_, c, err := NewTestClient(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.AggregatedListForwardingRulesFn = func(_ string, _ ...ListCallOption) ([]*compute.ForwardingRule, error) {
+		return []*compute.ForwardingRule{
+			{Name: "fr", Region: "projects/p/regions/r1"},
+			{Name: "fr", Region: "projects/p/regions/r2"},
+		}, nil
+	}
+	c.AggregatedListInstancesByZoneFn = func(_ string, _ ...ListCallOption) (map[string][]*compute.Instance, error) {
+		return map[string][]*compute.Instance{"z1": {{Name: "i"}}, "z2": {{Name: "i"}}}, nil
+	}
+	c.AggregatedListDisksByZoneFn = func(_ string, _ ...ListCallOption) (map[string][]*compute.Disk, error) {
+		return map[string][]*compute.Disk{"z1": {{Name: "d"}}, "z2": {{Name: "d"}}}, nil
+	}
+	c.ListImagesFn = func(_ string, _ ...ListCallOption) ([]*compute.Image, error) { return nil, nil }
+	c.ListSnapshotsFn = func(_ string, _ ...ListCallOption) ([]*compute.Snapshot, error) { return nil, nil }
+
+	var mu sync.Mutex
+	var deleted []string
+	record := func(r string) {
+		mu.Lock()
+		deleted = append(deleted, r)
+		mu.Unlock()
+	}
+	c.DeleteForwardingRuleFn = func(_, region, name string) error { record("fr " + region + "/" + name); return nil }
+	c.DeleteInstanceFn = func(_, zone, name string) error { record("instance " + zone + "/" + name); return nil }
+	c.DeleteDiskFn = func(_, zone, name string) error { record("disk " + zone + "/" + name); return nil }
+
+	if err := c.TeardownByLabel(testProject, "daisy-workflow", "wf"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	sort.Strings(deleted)
+	want := []string{"disk z1/d", "disk z2/d", "fr r1/fr", "fr r2/fr", "instance z1/i", "instance z2/i"}
+	if !reflect.DeepEqual(deleted, want) {
+		t.Errorf("got deletes %q, want %q", deleted, want)
+	}
+}
+
+func TestTeardownByLabelRegionalDisks(t *testing.T) {
+	_, c, err := NewTestClient(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.AggregatedListForwardingRulesFn = func(_ string, _ ...ListCallOption) ([]*compute.ForwardingRule, error) { return nil, nil }
+	c.AggregatedListInstancesByZoneFn = func(_ string, _ ...ListCallOption) (map[string][]*compute.Instance, error) { return nil, nil }
+	c.AggregatedListDisksByZoneFn = func(_ string, _ ...ListCallOption) (map[string][]*compute.Disk, error) {
+		return map[string][]*compute.Disk{"z1": {{Name: "d"}}, "regions/r1": {{Name: "d"}}}, nil
+	}
+	c.ListImagesFn = func(_ string, _ ...ListCallOption) ([]*compute.Image, error) { return nil, nil }
+	c.ListSnapshotsFn = func(_ string, _ ...ListCallOption) ([]*compute.Snapshot, error) { return nil, nil }
+
+	var mu sync.Mutex
+	var deleted []string
+	record := func(r string) {
+		mu.Lock()
+		deleted = append(deleted, r)
+		mu.Unlock()
+	}
+	c.DeleteDiskFn = func(_, zone, name string) error { record("disk " + zone + "/" + name); return nil }
+	c.DeleteRegionDiskFn = func(_, region, name string) error { record("regional disk " + region + "/" + name); return nil }
+
+	if err := c.TeardownByLabel(testProject, "daisy-workflow", "wf"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	sort.Strings(deleted)
+	if want := []string{"disk z1/d", "regional disk r1/d"}; !reflect.DeepEqual(deleted, want) {
+		t.Errorf("got deletes %q, want %q", deleted, want)
+	}
+}
+
+func TestDeleteUnattachedDisks(t *testing.T) {
+	_, c, err := NewTestClient(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.ListDisksFn = func(_, _ string, _ ...ListCallOption) ([]*compute.Disk, error) {
+		return []*compute.Disk{
+			{Name: "attached", Users: []string{"projects/p/zones/z/instances/i"}},
+			{Name: "orphan-1"},
+			{Name: "orphan-2"},
+		}, nil
+	}
+
+	ds, err := c.ListUnattachedDisks(testProject, testZone)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var got []string
+	for _, d := range ds {
+		got = append(got, d.Name)
+	}
+	if want := []string{"orphan-1", "orphan-2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got unattached disks %q, want %q", got, want)
+	}
+
+	var mu sync.Mutex
+	var deleted []string
+	c.DeleteDiskFn = func(_, _, name string) error {
+		mu.Lock()
+		defer mu.Unlock()
+		deleted = append(deleted, name)
+		if name == "orphan-2" {
+			return errors.New("resourceInUseByAnotherResource")
+		}
+		return nil
+	}
+	err = c.DeleteUnattachedDisks(testProject, testZone)
+	if err == nil || !strings.Contains(err.Error(), `error deleting disk "orphan-2"`) {
+		t.Errorf("want error for orphan-2, got %v", err)
+	}
+	sort.Strings(deleted)
+	if want := []string{"orphan-1", "orphan-2"}; !reflect.DeepEqual(deleted, want) {
+		t.Errorf("got deleted disks %q, want %q", deleted, want)
+	}
+}
+
+func TestListDiskUsers(t *testing.T) {
+	_, c, err := NewTestClient(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.ListInstancesFn = func(_, _ string, _ ...ListCallOption) ([]*compute.Instance, error) {
+		return []*compute.Instance{
+			{Name: "a", Disks: []*compute.AttachedDisk{{Source: "projects/p/zones/z/disks/boot-a"}, {Source: "projects/p/zones/z/disks/shared"}}},
+			{Name: "b", Disks: []*compute.AttachedDisk{{Source: "projects/p/zones/z/disks/boot-b"}}},
+			{Name: "c", Disks: []*compute.AttachedDisk{{Source: "projects/p/zones/z/disks/not-shared"}, {Source: "projects/p/zones/z/disks/shared"}}},
+		}, nil
+	}
+
+	users, err := c.ListDiskUsers(testProject, testZone, "shared")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var got []string
+	for _, u := range users {
+		got = append(got, u.Name)
+	}
+	if want := []string{"a", "c"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got users %q, want %q", got, want)
+	}
+}
+
+func TestCreateInstanceIfNotExists(t *testing.T) {
+	_, c, err := NewTestClient(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var created []string
+	c.CreateInstanceFn = func(_, _ string, i *compute.Instance) error {
+		created = append(created, i.Name)
+		return nil
+	}
+
+	tests := []struct {
+		desc        string
+		getErr      error
+		wantCreated bool
+		wantErr     bool
+	}{
+		{"exists", nil, false, false},
+		{"not found", &googleapi.Error{Code: 404}, true, false},
+		{"get error", &googleapi.Error{Code: 403}, false, true},
+	}
+	for _, tt := range tests {
+		created = nil
+		c.GetInstanceFn = func(_, _, name string) (*compute.Instance, error) {
+			if tt.getErr != nil {
+				return nil, tt.getErr
+			}
+			return &compute.Instance{Name: name, Status: "RUNNING"}, nil
+		}
+		i := &compute.Instance{Name: testInstance}
+		got, err := c.CreateInstanceIfNotExists(testProject, testZone, i)
+		if tt.wantErr != (err != nil) {
+			t.Errorf("%s: unexpected error: %v", tt.desc, err)
+		}
+		if got != tt.wantCreated || (len(created) == 1) != tt.wantCreated {
+			t.Errorf("%s: got created %t with creates %v, want %t", tt.desc, got, created, tt.wantCreated)
+		}
+		if tt.desc == "exists" && i.Status != "RUNNING" {
+			t.Errorf("%s: instance not updated from GCE: %+v", tt.desc, i)
+		}
+	}
+}
+
+func TestCreateInstanceInZones(t *testing.T) {
+	stockout := &OperationError{Op: &compute.Operation{Error: &compute.OperationError{
+		Errors: []*compute.OperationErrorErrors{{Code: "ZONE_RESOURCE_POOL_EXHAUSTED"}},
+	}}}
+	tests := []struct {
+		desc     string
+		zoneErrs map[string]error
+		wantZone string
+		wantErr  bool
+		wantTry  []string
+	}{
+		{"first zone succeeds", nil, "z1", false, []string{"z1"}},
+		{"fallback after stockout", map[string]error{"z1": stockout}, "z2", false, []string{"z1", "z2"}},
+		{"stop on other error", map[string]error{"z1": errors.New("bad request")}, "", true, []string{"z1"}},
+		{"all zones stocked out", map[string]error{"z1": stockout, "z2": stockout, "z3": stockout}, "", true, []string{"z1", "z2", "z3"}},
+	}
+
+	for _, tt := range tests {
+		_, c, err := NewTestClient(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var tried []string
+		c.CreateInstanceFn = func(project, zone string, i *compute.Instance) error {
+			tried = append(tried, zone)
+			return tt.zoneErrs[zone]
+		}
+
+		zone, err := c.CreateInstanceInZones(testProject, []string{"z1", "z2", "z3"}, &compute.Instance{Name: testInstance})
+		if tt.wantErr && err == nil {
+			t.Errorf("%s: expected error", tt.desc)
+		} else if !tt.wantErr && err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.desc, err)
+		}
+		if zone != tt.wantZone {
+			t.Errorf("%s: want zone %q, got %q", tt.desc, tt.wantZone, zone)
+		}
+		if !reflect.DeepEqual(tried, tt.wantTry) {
+			t.Errorf("%s: want zones tried %v, got %v", tt.desc, tt.wantTry, tried)
+		}
+	}
+}
+
+func TestWaitForInstanceRunning(t *testing.T) {
+	_, c, err := NewTestClient(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	statuses := []string{"PROVISIONING", "STAGING", "RUNNING"}
+	c.InstanceStatusFn = func(_, _, _ string) (string, error) {
+		s := statuses[0]
+		if len(statuses) > 1 {
+			statuses = statuses[1:]
+		}
+		return s, nil
+	}
+	if err := c.WaitForInstanceRunning(testProject, testZone, testInstance, time.Minute); err != nil {
+		t.Errorf("error running WaitForInstanceRunning: %v", err)
+	}
+
+	c.InstanceStatusFn = func(_, _, _ string) (string, error) { return "STAGING", nil }
+	err = c.WaitForInstanceRunning(testProject, testZone, testInstance, 10*time.Millisecond)
+	if err == nil || !strings.Contains(err.Error(), "STAGING") {
+		t.Errorf("want timeout error naming last status, got: %v", err)
+	}
+
+	c.InstanceStatusFn = func(_, _, _ string) (string, error) { return "", errors.New("get err") }
+	if err := c.WaitForInstanceRunning(testProject, testZone, testInstance, time.Minute); err == nil {
+		t.Error("expected error when instance status cannot be read")
+	}
+}
+
+func TestWaitForInstanceStopped(t *testing.T) {
+	_, c, err := NewTestClient(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	statuses := []string{"RUNNING", "STOPPING", "TERMINATED"}
+	c.InstanceStatusFn = func(_, _, _ string) (string, error) {
+		s := statuses[0]
+		if len(statuses) > 1 {
+			statuses = statuses[1:]
+		}
+		return s, nil
+	}
+	if err := c.WaitForInstanceStopped(testProject, testZone, testInstance, time.Minute); err != nil {
+		t.Errorf("error running WaitForInstanceStopped: %v", err)
+	}
+
+	c.InstanceStatusFn = func(_, _, _ string) (string, error) { return "STOPPING", nil }
+	err = c.WaitForInstanceStopped(testProject, testZone, testInstance, 10*time.Millisecond)
+	if err == nil || !strings.Contains(err.Error(), "not stopped") || !strings.Contains(err.Error(), "STOPPING") {
+		t.Errorf("want timeout error naming last status, got: %v", err)
+	}
+}
+
+func TestIsNotFound(t *testing.T) {
+	tests := []struct {
+		desc string
+		err  error
+		want bool
+	}{
+		{"nil error", nil, false},
+		{"404 error", &googleapi.Error{Code: 404}, true},
+		{"403 error", &googleapi.Error{Code: 403}, false},
+		{"non API error", errors.New("foo"), false},
+	}
+	for _, tt := range tests {
+		if got := IsNotFound(tt.err); got != tt.want {
+			t.Errorf("%s: want %t, got %t", tt.desc, tt.want, got)
+		}
+	}
+}
+
+func TestSetProjectMetadataItem(t *testing.T) {
+	_, c, err := NewTestClient(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	strPtr := func(s string) *string { return &s }
+	var gets int
+	c.GetProjectFn = func(project string) (*compute.Project, error) {
+		gets++
+		return &compute.Project{CommonInstanceMetadata: &compute.Metadata{
+			Fingerprint: fmt.Sprintf("fp%d", gets),
+			Items: []*compute.MetadataItems{
+				{Key: "other", Value: strPtr("keep")},
+				{Key: "ssh-keys", Value: strPtr("old")},
+			},
+		}}, nil
+	}
+	var got *compute.Metadata
+	c.SetCommonInstanceMetadataFn = func(project string, md *compute.Metadata) error {
+		if md.Fingerprint == "fp1" {
+			return &googleapi.Error{Code: 412}
+		}
+		got = md
+		return nil
+	}
+
+	if err := c.SetProjectMetadataItem(testProject, "ssh-keys", "new"); err != nil {
+		t.Fatalf("error running SetProjectMetadataItem: %v", err)
+	}
+	want := &compute.Metadata{
+		Fingerprint: "fp2",
+		Items: []*compute.MetadataItems{
+			{Key: "other", Value: strPtr("keep")},
+			{Key: "ssh-keys", Value: strPtr("new")},
+		},
+	}
+	if diff := pretty.Compare(got, want); diff != "" {
+		t.Errorf("metadata does not match expectation: (-got +want)\n%s", diff)
+	}
+
+	c.SetCommonInstanceMetadataFn = func(project string, md *compute.Metadata) error {
+		return &googleapi.Error{Code: 400}
+	}
+	if err := c.SetProjectMetadataItem(testProject, "ssh-keys", "new"); err == nil {
+		t.Error("expected error from SetProjectMetadataItem")
+	}
+}
+
+func TestCreateInstanceValidation(t *testing.T) {
+	var insertCalled bool
+	svr, c, err := NewTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" && r.URL.String() == fmt.Sprintf("/projects/%s/zones/%s/instances?alt=json&prettyPrint=false", testProject, testZone) {
+			insertCalled = true
+			fmt.Fprint(w, `{"Name":"op"}`)
+		} else if r.Method == "POST" && r.URL.String() == fmt.Sprintf("/projects/%s/zones/%s/operations/op/wait?alt=json&prettyPrint=false", testProject, testZone) {
+			fmt.Fprint(w, `{"Status":"DONE"}`)
+		} else {
+			w.WriteHeader(500)
+			fmt.Fprintln(w, "URL and Method not recognized:", r.Method, r.URL)
+		}
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer svr.Close()
+	WithValidation()(&c.client)
+	c.GetInstanceFn = func(_, _, _ string) (*compute.Instance, error) { return &compute.Instance{}, nil }
+	c.GetAcceleratorTypeFn = func(project, zone, acceleratorType string) (*compute.AcceleratorType, error) {
+		if acceleratorType == "nvidia-tesla-t4" {
+			return &compute.AcceleratorType{Name: acceleratorType}, nil
+		}
+		return nil, &googleapi.Error{Code: 404}
+	}
+
+	tests := []struct {
+		desc, acceleratorType string
+		wantErr               bool
+	}{
+		{"supported accelerator", fmt.Sprintf("projects/%s/zones/%s/acceleratorTypes/nvidia-tesla-t4", testProject, testZone), false},
+		{"unsupported accelerator", "nvidia-tesla-v100", true},
+	}
+	for _, tt := range tests {
+		insertCalled = false
+		i := &compute.Instance{
+			Name:              testInstance,
+			GuestAccelerators: []*compute.AcceleratorConfig{{AcceleratorType: tt.acceleratorType, AcceleratorCount: 1}},
+		}
+		err := c.CreateInstance(testProject, testZone, i)
+		if tt.wantErr {
+			if err == nil || !strings.Contains(err.Error(), "nvidia-tesla-v100") {
+				t.Errorf("%s: want error naming the accelerator, got: %v", tt.desc, err)
+			}
+			if insertCalled {
+				t.Errorf("%s: instance insert should not be called", tt.desc)
+			}
+		} else if err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.desc, err)
+		}
+	}
+}
+
+func TestValidateDisk(t *testing.T) {
+	tests := []struct {
+		desc    string
+		d       *compute.Disk
+		wantErr bool
+	}{
+		{"hyperdisk iops and throughput", &compute.Disk{Type: "zones/z/diskTypes/hyperdisk-balanced", ProvisionedIops: 3000, ProvisionedThroughput: 140}, false},
+		{"pd-extreme iops", &compute.Disk{Type: "pd-extreme", ProvisionedIops: 10000}, false},
+		{"pd-standard without performance", &compute.Disk{}, false},
+		{"pd-standard iops", &compute.Disk{Type: "pd-standard", ProvisionedIops: 3000}, true},
+		{"default type iops", &compute.Disk{ProvisionedIops: 3000}, true},
+		{"pd-extreme throughput", &compute.Disk{Type: "pd-extreme", ProvisionedThroughput: 140}, true},
+		{"invalid label", &compute.Disk{Labels: map[string]string{"Bad": ""}}, true},
+	}
+	for _, tt := range tests {
+		if err := validateDisk(tt.d); (err != nil) != tt.wantErr {
+			t.Errorf("%s: got error %v, want error %t", tt.desc, err, tt.wantErr)
+		}
+	}
+}
+
+func TestValidateConfidentialInstance(t *testing.T) {
+	confidential := &compute.ConfidentialInstanceConfig{EnableConfidentialCompute: true}
+	tests := []struct {
+		desc    string
+		i       *compute.Instance
+		wantErr bool
+	}{
+		{"not confidential", &compute.Instance{MachineType: "zones/z/machineTypes/e2-standard-2"}, false},
+		{"n2d", &compute.Instance{MachineType: "zones/z/machineTypes/n2d-standard-2", ConfidentialInstanceConfig: confidential}, false},
+		{"c2d", &compute.Instance{MachineType: "c2d-standard-4", ConfidentialInstanceConfig: confidential}, false},
+		{"e2", &compute.Instance{MachineType: "zones/z/machineTypes/e2-standard-2", ConfidentialInstanceConfig: confidential}, true},
+		{"disabled", &compute.Instance{MachineType: "e2-standard-2", ConfidentialInstanceConfig: &compute.ConfidentialInstanceConfig{}}, false},
+	}
+	for _, tt := range tests {
+		if err := validateConfidentialInstance(tt.i); (err != nil) != tt.wantErr {
+			t.Errorf("%s: got error %v, want error %t", tt.desc, err, tt.wantErr)
+		}
+	}
+}
+
+func TestValidateNetworkPerformance(t *testing.T) {
+	tier1 := &compute.NetworkPerformanceConfig{TotalEgressBandwidthTier: "TIER_1"}
+	gvnic := []*compute.NetworkInterface{{Name: "nic0", NicType: "GVNIC"}}
+	tests := []struct {
+		desc    string
+		i       *compute.Instance
+		wantErr bool
+	}{
+		{"default tier", &compute.Instance{MachineType: "e2-standard-2", NetworkPerformanceConfig: &compute.NetworkPerformanceConfig{TotalEgressBandwidthTier: "DEFAULT"}}, false},
+		{"tier 1", &compute.Instance{MachineType: "zones/z/machineTypes/n2-standard-32", NetworkInterfaces: gvnic, NetworkPerformanceConfig: tier1}, false},
+		{"virtio", &compute.Instance{MachineType: "n2-standard-32", NetworkInterfaces: []*compute.NetworkInterface{{Name: "nic0"}}, NetworkPerformanceConfig: tier1}, true},
+		{"e2", &compute.Instance{MachineType: "zones/z/machineTypes/e2-standard-32", NetworkInterfaces: gvnic, NetworkPerformanceConfig: tier1}, true},
+		{"n1", &compute.Instance{MachineType: "n1-standard-32", NetworkInterfaces: gvnic, NetworkPerformanceConfig: tier1}, true},
+		{"unlisted family", &compute.Instance{MachineType: "zones/z/machineTypes/c4-standard-48", NetworkInterfaces: gvnic, NetworkPerformanceConfig: tier1}, false},
+	}
+	for _, tt := range tests {
+		if err := validateNetworkPerformance(tt.i); (err != nil) != tt.wantErr {
+			t.Errorf("%s: got error %v, want error %t", tt.desc, err, tt.wantErr)
+		}
+	}
+}
+
+func TestValidateReservationAffinity(t *testing.T) {
+	_, c, err := NewTestClient(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.GetReservationFn = func(project, zone, name string) (*compute.Reservation, error) {
+		if project == "shared" && name == "r" || project == testProject && name == "r" {
+			return &compute.Reservation{Name: name}, nil
+		}
+		return nil, &googleapi.Error{Code: http.StatusNotFound}
+	}
+
+	tests := []struct {
+		desc    string
+		ra      *compute.ReservationAffinity
+		wantErr bool
+	}{
+		{"none", nil, false},
+		{"any", &compute.ReservationAffinity{ConsumeReservationType: "ANY_RESERVATION"}, false},
+		{"exists", &compute.ReservationAffinity{ConsumeReservationType: "SPECIFIC_RESERVATION", Key: "compute.googleapis.com/reservation-name", Values: []string{"r"}}, false},
+		{"shared", &compute.ReservationAffinity{ConsumeReservationType: "SPECIFIC_RESERVATION", Values: []string{"projects/shared/reservations/r"}}, false},
+		{"missing", &compute.ReservationAffinity{ConsumeReservationType: "SPECIFIC_RESERVATION", Values: []string{"nope"}}, true},
+		{"no values", &compute.ReservationAffinity{ConsumeReservationType: "SPECIFIC_RESERVATION"}, true},
+	}
+	for _, tt := range tests {
+		if err := c.client.validateReservationAffinity(testProject, testZone, tt.ra); (err != nil) != tt.wantErr {
+			t.Errorf("%s: got error %v, want error %t", tt.desc, err, tt.wantErr)
+		}
+	}
+}
+
+func TestCreateDiskMultiWriterValidation(t *testing.T) {
+	var insertCalled bool
+	svr, c, err := NewTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" && r.URL.String() == fmt.Sprintf("/projects/%s/zones/%s/disks?alt=json&prettyPrint=false", testProject, testZone) {
+			insertCalled = true
+			fmt.Fprint(w, `{}`)
+		} else if r.Method == "GET" && r.URL.String() == fmt.Sprintf("/projects/%s/zones/%s/disks/%s?alt=json&prettyPrint=false", testProject, testZone, testDisk) {
+			fmt.Fprint(w, `{}`)
+		} else {
+			w.WriteHeader(500)
+			fmt.Fprintln(w, "URL and Method not recognized:", r.Method, r.URL)
+		}
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer svr.Close()
+	WithValidation()(&c.client)
+	c.zoneOperationsWaitBetaFn = func(_, _, _ string) error { return nil }
+
+	tests := []struct {
+		desc, diskType string
+		wantErr        bool
+	}{
+		{"pd-ssd", fmt.Sprintf("projects/%s/zones/%s/diskTypes/pd-ssd", testProject, testZone), false},
+		{"pd-balanced", "pd-balanced", true},
+		{"default type", "", true},
+	}
+	for _, tt := range tests {
+		insertCalled = false
+		err := c.CreateDiskBeta(testProject, testZone, &computeBeta.Disk{Name: testDisk, Type: tt.diskType, MultiWriter: true})
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("%s: want error", tt.desc)
+			}
+			if insertCalled {
+				t.Errorf("%s: disk insert should not be called", tt.desc)
+			}
+		} else if err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.desc, err)
+		}
+	}
+}
+
+func TestGetBulkInsertInstanceResult(t *testing.T) {
+	_, c, err := NewTestClient(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.ListInstancesFn = func(_, _ string, _ ...ListCallOption) ([]*compute.Instance, error) {
+		return []*compute.Instance{
+			{Name: "builder-0001", Status: "RUNNING"},
+			{Name: "builder-0002", Status: "STAGING"},
+			{Name: "builder-0003", Status: "RUNNING"},
+			{Name: "named", Status: "RUNNING"},
+			{Name: "unrelated", Status: "RUNNING"},
+			{Name: "builder-abc", Status: "RUNNING"},
+		}, nil
+	}
+
+	tests := []struct {
+		desc        string
+		r           *compute.BulkInsertInstanceResource
+		wantRunning []string
+		wantFailed  []string
+	}{
+		{
+			"name pattern",
+			&compute.BulkInsertInstanceResource{NamePattern: "builder-####"},
+			[]string{"builder-0001", "builder-0003"},
+			[]string{"builder-0002"},
+		},
+		{
+			"per instance properties",
+			&compute.BulkInsertInstanceResource{PerInstanceProperties: map[string]compute.BulkInsertInstanceResourcePerInstanceProperties{
+				"named":   {},
+				"missing": {},
+			}},
+			[]string{"named"},
+			[]string{"missing"},
+		},
+	}
+	for _, tt := range tests {
+		running, failed, err := c.GetBulkInsertInstanceResult(testProject, testZone, tt.r)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.desc, err)
+			continue
+		}
+		if !reflect.DeepEqual(running, tt.wantRunning) {
+			t.Errorf("%s: want running %v, got %v", tt.desc, tt.wantRunning, running)
+		}
+		if !reflect.DeepEqual(failed, tt.wantFailed) {
+			t.Errorf("%s: want failed %v, got %v", tt.desc, tt.wantFailed, failed)
+		}
+	}
+}
+
+func TestPatchRegionBackendService(t *testing.T) {
+	var gotBody string
+	svr, c, err := NewTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		u := fmt.Sprintf("/projects/%s/regions/%s/backendServices/%s?alt=json&prettyPrint=false", testProject, testRegion, testBackendService)
+		if r.Method == "PATCH" && r.URL.String() == u {
+			buf := new(bytes.Buffer)
+			buf.ReadFrom(r.Body)
+			gotBody = buf.String()
+			fmt.Fprint(w, `{"Name":"op"}`)
+		} else if r.Method == "POST" && r.URL.String() == fmt.Sprintf("/projects/%s/regions/%s/operations/op/wait?alt=json&prettyPrint=false", testProject, testRegion) {
+			fmt.Fprint(w, `{"Status":"DONE"}`)
+		} else if r.Method == "GET" && r.URL.String() == u {
+			fmt.Fprintf(w, `{"Name":%q,"Backends":[{"Group":"ig"}],"SelfLink":"link"}`, testBackendService)
+		} else {
+			w.WriteHeader(500)
+			fmt.Fprintln(w, "URL and Method not recognized:", r.Method, r.URL)
+		}
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer svr.Close()
+
+	bs := &compute.BackendService{Backends: []*compute.Backend{{Group: "ig"}}}
+	if err := c.PatchRegionBackendService(testProject, testRegion, testBackendService, bs); err != nil {
+		t.Fatalf("error running PatchRegionBackendService: %v", err)
+	}
+	if want := `{"backends":[{"group":"ig"}]}` + "\n"; gotBody != want {
+		t.Errorf("want patch body %q, got %q", want, gotBody)
+	}
+	if bs.SelfLink != "link" {
+		t.Errorf("backend service not updated from the patched resource: %+v", bs)
+	}
+}
+
+func TestInstanceGroupMembership(t *testing.T) {
+	var gotBodies []string
+	svr, c, err := NewTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" && (r.URL.String() == fmt.Sprintf("/projects/%s/zones/%s/instanceGroups/%s/addInstances?alt=json&prettyPrint=false", testProject, testZone, testInstanceGroup) ||
+			r.URL.String() == fmt.Sprintf("/projects/%s/zones/%s/instanceGroups/%s/removeInstances?alt=json&prettyPrint=false", testProject, testZone, testInstanceGroup)) {
+			buf := new(bytes.Buffer)
+			buf.ReadFrom(r.Body)
+			gotBodies = append(gotBodies, buf.String())
+			fmt.Fprint(w, `{"Name":"op"}`)
+		} else if r.Method == "POST" && r.URL.String() == fmt.Sprintf("/projects/%s/zones/%s/operations/op/wait?alt=json&prettyPrint=false", testProject, testZone) {
+			fmt.Fprint(w, `{"Status":"DONE"}`)
+		} else {
+			w.WriteHeader(500)
+			fmt.Fprintln(w, "URL and Method not recognized:", r.Method, r.URL)
+		}
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer svr.Close()
+
+	instances := []string{"zones/z/instances/a", "zones/z/instances/b"}
+	if err := c.AddInstanceGroupInstances(testProject, testZone, testInstanceGroup, instances); err != nil {
+		t.Fatalf("error running AddInstanceGroupInstances: %v", err)
+	}
+	if err := c.RemoveInstanceGroupInstances(testProject, testZone, testInstanceGroup, instances[:1]); err != nil {
+		t.Fatalf("error running RemoveInstanceGroupInstances: %v", err)
+	}
+	want := []string{
+		`{"instances":[{"instance":"zones/z/instances/a"},{"instance":"zones/z/instances/b"}]}` + "\n",
+		`{"instances":[{"instance":"zones/z/instances/a"}]}` + "\n",
+	}
+	if !reflect.DeepEqual(gotBodies, want) {
+		t.Errorf("want request bodies %q, got %q", want, gotBodies)
+	}
+}
+
+func TestCreateDiskFromImageAndAttach(t *testing.T) {
+	tests := []struct {
+		desc                 string
+		createErr, attachErr error
+		deleteErr            error
+		wantErr, wantDeleted bool
+	}{
+		{"success", nil, nil, nil, false, false},
+		{"create error", errors.New("create"), nil, nil, true, false},
+		{"attach error rolls back disk", nil, errors.New("attach"), nil, true, true},
+		{"attach and rollback error", nil, errors.New("attach"), errors.New("delete"), true, true},
+	}
+
+	for _, tt := range tests {
+		_, c, err := NewTestClient(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var attached *compute.AttachedDisk
+		var deleted bool
+		c.CreateDiskFn = func(_, _ string, d *compute.Disk) error {
+			if d.SourceImage != testImage {
+				t.Errorf("%s: want source image %q, got %q", tt.desc, testImage, d.SourceImage)
+			}
+			d.SelfLink = "disk-link"
+			return tt.createErr
+		}
+		c.AttachDiskFn = func(_, _, instance string, d *compute.AttachedDisk) error {
+			attached = d
+			return tt.attachErr
+		}
+		c.DeleteDiskFn = func(_, _, name string) error {
+			deleted = name == testDisk
+			return tt.deleteErr
+		}
+
+		err = c.CreateDiskFromImageAndAttach(testProject, testZone, testInstance, testImage, &compute.Disk{Name: testDisk})
+		if tt.wantErr != (err != nil) {
+			t.Errorf("%s: want error %t, got: %v", tt.desc, tt.wantErr, err)
+		}
+		if deleted != tt.wantDeleted {
+			t.Errorf("%s: want disk deleted %t, got %t", tt.desc, tt.wantDeleted, deleted)
+		}
+		if tt.createErr == nil {
+			want := &compute.AttachedDisk{Source: "disk-link", DeviceName: testDisk}
+			if diff := pretty.Compare(attached, want); diff != "" {
+				t.Errorf("%s: attached disk does not match expectation: (-got +want)\n%s", tt.desc, diff)
+			}
+		}
+	}
+}
+
+func TestGetRegionAutoscalerRecommendedSize(t *testing.T) {
+	_, c, err := NewTestClient(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.GetRegionAutoscalerFn = func(_, _, _ string) (*compute.Autoscaler, error) {
+		return &compute.Autoscaler{RecommendedSize: 7}, nil
+	}
+	got, err := c.GetRegionAutoscalerRecommendedSize(testProject, testRegion, "as")
+	if err != nil {
+		t.Fatalf("error running GetRegionAutoscalerRecommendedSize: %v", err)
+	}
+	if got != 7 {
+		t.Errorf("want recommended size 7, got %d", got)
+	}
+
+	c.GetRegionAutoscalerFn = func(_, _, _ string) (*compute.Autoscaler, error) { return nil, errors.New("get err") }
+	if _, err := c.GetRegionAutoscalerRecommendedSize(testProject, testRegion, "as"); err == nil {
+		t.Error("expected error from GetRegionAutoscalerRecommendedSize")
+	}
+}
+
+func TestDeleteInstancesByFilter(t *testing.T) {
+	_, c, err := NewTestClient(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var gotOpts []ListCallOption
+	c.ListInstancesFn = func(_, _ string, opts ...ListCallOption) ([]*compute.Instance, error) {
+		gotOpts = opts
+		return []*compute.Instance{{Name: "a"}, {Name: "gone"}, {Name: "bad"}}, nil
+	}
+	var mu sync.Mutex
+	var deleted []string
+	c.DeleteInstanceFn = func(_, _, name string) error {
+		mu.Lock()
+		defer mu.Unlock()
+		deleted = append(deleted, name)
+		switch name {
+		case "gone":
+			return &googleapi.Error{Code: 404}
+		case "bad":
+			return errors.New("delete err")
+		}
+		return nil
+	}
+
+	err = c.DeleteInstancesByFilter(testProject, testZone, "labels.daisy-workflow = foo")
+	if err == nil || !strings.Contains(err.Error(), `"bad"`) || strings.Contains(err.Error(), `"gone"`) {
+		t.Errorf("want only the error deleting instance bad, got: %v", err)
+	}
+	if want := []ListCallOption{Filter("labels.daisy-workflow = foo")}; !reflect.DeepEqual(gotOpts, want) {
+		t.Errorf("want list options %v, got %v", want, gotOpts)
+	}
+	sort.Strings(deleted)
+	if want := []string{"a", "bad", "gone"}; !reflect.DeepEqual(deleted, want) {
+		t.Errorf("want deleted %v, got %v", want, deleted)
+	}
+
+	c.DeleteInstanceFn = func(_, _, _ string) error { return nil }
+	if err := c.DeleteInstancesByFilter(testProject, testZone, "foo"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestWaitForGuestAttribute(t *testing.T) {
+	_, c, err := NewTestClient(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clk := NewFakeClock(time.Now())
+	c.client.clock = clk
+	var gotKey string
+	responses := []error{&googleapi.Error{Code: 404}, nil, nil}
+	values := []string{"", "failure", "success"}
+	c.GetGuestAttributesFn = func(_, _, _, _, variableKey string) (*compute.GuestAttributes, error) {
+		gotKey = variableKey
+		err, v := responses[0], values[0]
+		if len(responses) > 1 {
+			responses, values = responses[1:], values[1:]
+		}
+		if err != nil {
+			return nil, err
+		}
+		return &compute.GuestAttributes{VariableValue: v}, nil
+	}
+	if err := c.WaitForGuestAttribute(testProject, testZone, testInstance, "daisy", "status", "success", time.Minute); err != nil {
+		t.Errorf("error running WaitForGuestAttribute: %v", err)
+	}
+	if gotKey != "daisy/status" {
+		t.Errorf("want variable key daisy/status, got %q", gotKey)
+	}
+	// Two misses back off 6s then 12s on the client clock.
+	if slept := clk.Slept(); slept != 18*time.Second {
+		t.Errorf("want 18s slept on the client clock, got %v", slept)
+	}
+
+	c.GetGuestAttributesFn = func(_, _, _, _, _ string) (*compute.GuestAttributes, error) {
+		return nil, &googleapi.Error{Code: 404}
+	}
+	err = c.WaitForGuestAttribute(testProject, testZone, testInstance, "daisy", "status", "success", 10*time.Millisecond)
+	if err == nil || !strings.Contains(err.Error(), testInstance) || !strings.Contains(err.Error(), "daisy/status") {
+		t.Errorf("want timeout error naming instance and key, got: %v", err)
+	}
+
+	c.GetGuestAttributesFn = func(_, _, _, _, _ string) (*compute.GuestAttributes, error) {
+		return nil, &googleapi.Error{Code: 403}
+	}
+	if err := c.WaitForGuestAttribute(testProject, testZone, testInstance, "daisy", "status", "success", time.Minute); err == nil {
+		t.Error("expected error for non 404 error")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	c.GetGuestAttributesFn = func(_, _, _, _, _ string) (*compute.GuestAttributes, error) {
+		return &compute.GuestAttributes{VariableValue: "failure"}, nil
+	}
+	if err := c.WaitForGuestAttributeContext(ctx, testProject, testZone, testInstance, "daisy", "status", "success"); err == nil || !strings.Contains(err.Error(), `"failure"`) {
+		t.Errorf("want cancellation error with last value, got: %v", err)
+	}
+}
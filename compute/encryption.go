@@ -0,0 +1,32 @@
+//  Copyright 2020 Google Inc. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package compute
+
+import "google.golang.org/api/compute/v1"
+
+// CMEK returns an encryption key that uses the given Cloud KMS key, e.g.
+// "projects/p/locations/l/keyRings/r/cryptoKeys/k", for use as a disk, image
+// or snapshot encryption key.
+func CMEK(kmsKeyName string) *compute.CustomerEncryptionKey {
+	return &compute.CustomerEncryptionKey{KmsKeyName: kmsKeyName}
+}
+
+// CSEK returns a customer-supplied encryption key from a base64 encoded
+// 256-bit AES key, for use as a disk, image or snapshot encryption key.
+// Reading the resource back does not return the key, so callers must keep it
+// to use the resource later.
+func CSEK(rawKey string) *compute.CustomerEncryptionKey {
+	return &compute.CustomerEncryptionKey{RawKey: rawKey}
+}
@@ -0,0 +1,62 @@
+//  Copyright 2020 Google Inc. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package compute
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+	"google.golang.org/api/compute/v1"
+)
+
+func TestCreateDiskForwardsEncryptionKey(t *testing.T) {
+	tests := []struct {
+		desc string
+		key  *compute.CustomerEncryptionKey
+	}{
+		{"CMEK", CMEK("projects/p/locations/l/keyRings/r/cryptoKeys/k")},
+		{"CSEK", CSEK("c2VjcmV0")},
+	}
+	for _, tt := range tests {
+		var got *compute.Disk
+		svr, c, err := NewTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == "POST" && r.URL.String() == fmt.Sprintf("/projects/%s/zones/%s/disks?alt=json&prettyPrint=false", testProject, testZone) {
+				body, _ := io.ReadAll(r.Body)
+				json.Unmarshal(body, &got)
+				fmt.Fprint(w, `{}`)
+			} else {
+				w.WriteHeader(500)
+				fmt.Fprintln(w, "URL and Method not recognized:", r.Method, r.URL)
+			}
+		}))
+		if err != nil {
+			t.Fatal(err)
+		}
+		c.zoneOperationsWaitFn = func(_, _, _ string) error { return nil }
+		c.GetDiskFn = func(_, _, _ string) (*compute.Disk, error) { return &compute.Disk{}, nil }
+
+		if err := c.CreateDisk(testProject, testZone, &compute.Disk{Name: testDisk, DiskEncryptionKey: tt.key}); err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.desc, err)
+		}
+		if diff := pretty.Compare(got.DiskEncryptionKey, tt.key); diff != "" {
+			t.Errorf("%s: encryption key not forwarded: (-got +want)\n%s", tt.desc, diff)
+		}
+		svr.Close()
+	}
+}
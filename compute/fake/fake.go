@@ -0,0 +1,79 @@
+//  Copyright 2020 Google Inc. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+// Package fake provides a fake compute.Client for tests that records calls
+// instead of talking to an HTTP server.
+package fake
+
+//go:generate go run gen.go
+
+import (
+	"sync"
+
+	daisyCompute "github.com/GoogleCloudPlatform/compute-daisy/compute"
+)
+
+var _ daisyCompute.Client = (*FakeClient)(nil)
+
+// Call is a recorded call to a FakeClient method.
+type Call struct {
+	Method string
+	Args   []interface{}
+}
+
+type fakeState struct {
+	// Errors maps method names to the error returned by that method when
+	// its Fn field is not set.
+	Errors map[string]error
+
+	mu    sync.Mutex
+	calls []Call
+}
+
+// NewFakeClient returns an empty FakeClient.
+func NewFakeClient() *FakeClient {
+	return &FakeClient{fakeState: fakeState{Errors: map[string]error{}}}
+}
+
+// Calls returns all recorded calls, in order.
+func (f *fakeState) Calls() []Call {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]Call(nil), f.calls...)
+}
+
+// CallsTo returns the recorded calls to the named method, in order.
+func (f *fakeState) CallsTo(method string) []Call {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var calls []Call
+	for _, c := range f.calls {
+		if c.Method == method {
+			calls = append(calls, c)
+		}
+	}
+	return calls
+}
+
+func (f *fakeState) record(method string, args ...interface{}) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, Call{Method: method, Args: args})
+}
+
+func (f *fakeState) err(method string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.Errors[method]
+}
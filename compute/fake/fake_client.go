@@ -0,0 +1,2410 @@
+//  Copyright 2020 Google Inc. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+// Code generated by gen.go. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+	"time"
+
+	daisyCompute "github.com/GoogleCloudPlatform/compute-daisy/compute"
+	computeAlpha "google.golang.org/api/compute/v0.alpha"
+	computeBeta "google.golang.org/api/compute/v0.beta"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/googleapi"
+)
+
+// FakeClient is a Client that records its calls. Each method calls the
+// matching Fn field if it is set, and otherwise returns zero values and the
+// error configured in Errors.
+type FakeClient struct {
+	fakeState
+
+	AttachDiskFn                              func(project string, zone string, instance string, d *compute.AttachedDisk) error
+	DetachDiskFn                              func(project string, zone string, instance string, disk string) error
+	CreateDiskFromImageAndAttachFn            func(project string, zone string, instance string, image string, d *compute.Disk) error
+	CreateDiskFromSnapshotFn                  func(project string, zone string, diskName string, snapshotSelfLink string, sizeGb int64, diskType string) error
+	CloneDiskReencryptFn                      func(project string, zone string, sourceDisk string, diskName string, key *compute.CustomerEncryptionKey) error
+	CreateDiskFn                              func(project string, zone string, d *compute.Disk) error
+	CreateDiskAlphaFn                         func(project string, zone string, d *computeAlpha.Disk) error
+	CreateDiskBetaFn                          func(project string, zone string, d *computeBeta.Disk) error
+	CreateForwardingRuleFn                    func(project string, region string, fr *compute.ForwardingRule) error
+	CreateFirewallRuleFn                      func(project string, i *compute.Firewall) error
+	CreateFirewallRuleBetaFn                  func(project string, fw *computeBeta.Firewall) error
+	PatchFirewallRuleBetaFn                   func(project string, name string, fw *computeBeta.Firewall) error
+	CreateImageFn                             func(project string, i *compute.Image) error
+	CreateImageAlphaFn                        func(project string, i *computeAlpha.Image) error
+	CreateImageBetaFn                         func(project string, i *computeBeta.Image) error
+	CreateImageFromEncryptedDiskFn            func(project string, im *compute.Image, diskKey *compute.CustomerEncryptionKey) error
+	CopyImageToLocationFn                     func(srcProject string, srcImage string, dstProject string, dstImage string, storageLocations []string) error
+	CreateInstanceFn                          func(project string, zone string, i *compute.Instance) error
+	CreateInstanceAlphaFn                     func(project string, zone string, i *computeAlpha.Instance) error
+	CreateInstanceBetaFn                      func(project string, zone string, i *computeBeta.Instance) error
+	CreateInstanceInZonesFn                   func(project string, zones []string, i *compute.Instance) (string, error)
+	CreateInstanceIfNotExistsFn               func(project string, zone string, i *compute.Instance) (bool, error)
+	CreateInstanceAndGetFn                    func(project string, zone string, i *compute.Instance) (*compute.Instance, error)
+	CreateInstanceFromMachineImageFn          func(project string, zone string, machineImage string, i *compute.Instance) error
+	BulkInsertInstanceFn                      func(project string, zone string, r *compute.BulkInsertInstanceResource) error
+	GetBulkInsertInstanceResultFn             func(project string, zone string, r *compute.BulkInsertInstanceResource) ([]string, []string, error)
+	CreateNetworkFn                           func(project string, n *compute.Network) error
+	CreateSnapshotFn                          func(project string, zone string, disk string, s *compute.Snapshot) error
+	CreateSubnetworkFn                        func(project string, region string, n *compute.Subnetwork) error
+	CreateTargetInstanceFn                    func(project string, zone string, ti *compute.TargetInstance) error
+	DeleteDiskFn                              func(project string, zone string, name string) error
+	DeleteRegionDiskFn                        func(project string, region string, name string) error
+	DeleteForwardingRuleFn                    func(project string, region string, name string) error
+	DeleteFirewallRuleFn                      func(project string, name string) error
+	DeleteImageFn                             func(project string, name string) error
+	DeleteInstanceFn                          func(project string, zone string, name string) error
+	DeleteInstancesByFilterFn                 func(project string, zone string, filter string) error
+	TeardownByLabelFn                         func(project string, labelKey string, labelValue string) error
+	SetDeletionProtectionFn                   func(project string, zone string, instance string, enabled bool) error
+	UpdateNetworkInterfaceFn                  func(project string, zone string, instance string, networkInterface string, ni *compute.NetworkInterface) error
+	GetShieldedInstanceIdentityFn             func(project string, zone string, instance string) (*compute.ShieldedInstanceIdentity, error)
+	GetEffectiveFirewallsFn                   func(project string, zone string, instance string, networkInterface string) (*compute.InstancesGetEffectiveFirewallsResponse, error)
+	GetScreenshotFn                           func(project string, zone string, instance string) (*compute.Screenshot, error)
+	GetInstanceIamPolicyFn                    func(project string, zone string, instance string) (*compute.Policy, error)
+	SetInstanceIamPolicyFn                    func(project string, zone string, instance string, policy *compute.Policy) error
+	TestInstanceIamPermissionsFn              func(project string, zone string, instance string, permissions []string) ([]string, error)
+	SetShieldedInstanceIntegrityPolicyFn      func(project string, zone string, instance string, p *compute.ShieldedInstanceIntegrityPolicy) error
+	StartInstanceFn                           func(project string, zone string, name string) error
+	StopInstanceFn                            func(project string, zone string, name string) error
+	StopInstanceWithOptionsFn                 func(project string, zone string, name string, discardLocalSSD bool) error
+	DeleteNetworkFn                           func(project string, name string) error
+	DeleteSubnetworkFn                        func(project string, region string, name string) error
+	DeleteTargetInstanceFn                    func(project string, zone string, name string) error
+	DeprecateImageFn                          func(project string, name string, deprecationstatus *compute.DeprecationStatus) error
+	DeprecateImageAlphaFn                     func(project string, name string, deprecationstatus *computeAlpha.DeprecationStatus) error
+	DeprecateImageFamilyFn                    func(project string, family string, keepLatest int, status *compute.DeprecationStatus) error
+	GetMachineTypeFn                          func(project string, zone string, machineType string) (*compute.MachineType, error)
+	GetAcceleratorTypeFn                      func(project string, zone string, acceleratorType string) (*compute.AcceleratorType, error)
+	GetReservationFn                          func(project string, zone string, name string) (*compute.Reservation, error)
+	GetProjectFn                              func(project string) (*compute.Project, error)
+	GetSerialPortOutputFn                     func(project string, zone string, name string, port int64, start int64) (*compute.SerialPortOutput, error)
+	SendDiagnosticInterruptFn                 func(project string, zone string, instance string) error
+	GetZoneFn                                 func(project string, zone string) (*compute.Zone, error)
+	GetInstanceFn                             func(project string, zone string, name string) (*compute.Instance, error)
+	GetInstanceAlphaFn                        func(project string, zone string, name string) (*computeAlpha.Instance, error)
+	GetInstanceBetaFn                         func(project string, zone string, name string) (*computeBeta.Instance, error)
+	GetDiskFn                                 func(project string, zone string, name string) (*compute.Disk, error)
+	GetRegionDiskFn                           func(project string, region string, name string) (*compute.Disk, error)
+	GetDiskAlphaFn                            func(project string, zone string, name string) (*computeAlpha.Disk, error)
+	GetDiskBetaFn                             func(project string, zone string, name string) (*computeBeta.Disk, error)
+	GetForwardingRuleFn                       func(project string, region string, name string) (*compute.ForwardingRule, error)
+	GetFirewallRuleFn                         func(project string, name string) (*compute.Firewall, error)
+	GetFirewallRuleBetaFn                     func(project string, name string) (*computeBeta.Firewall, error)
+	GetGuestAttributesFn                      func(project string, zone string, name string, queryPath string, variableKey string) (*compute.GuestAttributes, error)
+	GetImageFn                                func(project string, name string) (*compute.Image, error)
+	GetImageAlphaFn                           func(project string, name string) (*computeAlpha.Image, error)
+	GetImageBetaFn                            func(project string, name string) (*computeBeta.Image, error)
+	GetImageFromFamilyFn                      func(project string, family string) (*compute.Image, error)
+	GetLatestImageFromFamiliesFn              func(projects []string, family string) (*compute.Image, error)
+	GetLicenseFn                              func(project string, name string) (*compute.License, error)
+	GetInstanceTemplateFn                     func(project string, name string) (*compute.InstanceTemplate, error)
+	GetInstanceGroupManagerFn                 func(project string, zone string, name string) (*compute.InstanceGroupManager, error)
+	GetRegionInstanceGroupManagerFn           func(project string, region string, name string) (*compute.InstanceGroupManager, error)
+	WaitForInstanceGroupManagerStableFn       func(project string, zone string, name string, timeout time.Duration) error
+	WaitForRegionInstanceGroupManagerStableFn func(project string, region string, name string, timeout time.Duration) error
+	GetNetworkFn                              func(project string, name string) (*compute.Network, error)
+	GetRegionFn                               func(project string, region string) (*compute.Region, error)
+	GetSubnetworkFn                           func(project string, region string, name string) (*compute.Subnetwork, error)
+	GetTargetInstanceFn                       func(project string, zone string, name string) (*compute.TargetInstance, error)
+	GetBackendServiceFn                       func(project string, name string) (*compute.BackendService, error)
+	GetBackendServiceHealthFn                 func(project string, backendService string, group string) (*compute.BackendServiceGroupHealth, error)
+	GetHealthCheckFn                          func(project string, name string) (*compute.HealthCheck, error)
+	CreateHTTPHealthCheckFn                   func(project string, hc *compute.HttpHealthCheck) error
+	GetHTTPHealthCheckFn                      func(project string, name string) (*compute.HttpHealthCheck, error)
+	DeleteHTTPHealthCheckFn                   func(project string, name string) error
+	CreateHTTPSHealthCheckFn                  func(project string, hc *compute.HttpsHealthCheck) error
+	GetHTTPSHealthCheckFn                     func(project string, name string) (*compute.HttpsHealthCheck, error)
+	DeleteHTTPSHealthCheckFn                  func(project string, name string) error
+	CreateSecurityPolicyFn                    func(project string, sp *compute.SecurityPolicy) error
+	GetSecurityPolicyFn                       func(project string, name string) (*compute.SecurityPolicy, error)
+	DeleteSecurityPolicyFn                    func(project string, name string) error
+	AddSecurityPolicyRuleFn                   func(project string, securityPolicy string, r *compute.SecurityPolicyRule) error
+	PatchSecurityPolicyRuleFn                 func(project string, securityPolicy string, priority int64, r *compute.SecurityPolicyRule) error
+	GetFirewallPolicyFn                       func(policy string) (*compute.FirewallPolicy, error)
+	AddFirewallPolicyAssociationFn            func(policy string, assoc *compute.FirewallPolicyAssociation) error
+	AddFirewallPolicyRuleFn                   func(policy string, rule *compute.FirewallPolicyRule) error
+	CreatePacketMirroringFn                   func(project string, region string, pm *compute.PacketMirroring) error
+	GetPacketMirroringFn                      func(project string, region string, name string) (*compute.PacketMirroring, error)
+	DeletePacketMirroringFn                   func(project string, region string, name string) error
+	GetURLMapFn                               func(project string, name string) (*compute.UrlMap, error)
+	GetTargetHTTPProxyFn                      func(project string, name string) (*compute.TargetHttpProxy, error)
+	GetNetworkEndpointGroupFn                 func(project string, zone string, name string) (*compute.NetworkEndpointGroup, error)
+	InstanceStatusFn                          func(project string, zone string, name string) (string, error)
+	InstanceStoppedFn                         func(project string, zone string, name string) (bool, error)
+	WaitForInstanceRunningFn                  func(project string, zone string, name string, timeout time.Duration) error
+	WaitForInstanceStoppedFn                  func(project string, zone string, name string, timeout time.Duration) error
+	WaitForGuestAttributeFn                   func(project string, zone string, instance string, namespace string, key string, wantValue string, timeout time.Duration) error
+	WaitForGuestAttributeContextFn            func(ctx context.Context, project string, zone string, instance string, namespace string, key string, wantValue string) error
+	WaitForOperationWithProgressFn            func(project string, selfLink string, onProgress func(percent int)) error
+	WaitForOperationWithConfigFn              func(project string, selfLink string, wc daisyCompute.WaitConfig) error
+	DeleteOperationFn                         func(project string, selfLink string) error
+	ListZoneOperationsFn                      func(project string, zone string, opts ...daisyCompute.ListCallOption) ([]*compute.Operation, error)
+	ListRegionOperationsFn                    func(project string, region string, opts ...daisyCompute.ListCallOption) ([]*compute.Operation, error)
+	ListGlobalOperationsFn                    func(project string, opts ...daisyCompute.ListCallOption) ([]*compute.Operation, error)
+	GetOperationsForTargetFn                  func(project string, scope string, targetSelfLink string) ([]*compute.Operation, error)
+	ListMachineTypesFn                        func(project string, zone string, opts ...daisyCompute.ListCallOption) ([]*compute.MachineType, error)
+	ListAcceleratorTypesFn                    func(project string, zone string, opts ...daisyCompute.ListCallOption) ([]*compute.AcceleratorType, error)
+	ListLicensesFn                            func(project string, opts ...daisyCompute.ListCallOption) ([]*compute.License, error)
+	ListZonesFn                               func(project string, opts ...daisyCompute.ListCallOption) ([]*compute.Zone, error)
+	PickZoneFn                                func(project string, region string, machineType string) (string, error)
+	ListRegionsFn                             func(project string, opts ...daisyCompute.ListCallOption) ([]*compute.Region, error)
+	AggregatedListInstancesFn                 func(project string, opts ...daisyCompute.ListCallOption) ([]*compute.Instance, error)
+	AggregatedListInstancesByZoneFn           func(project string, opts ...daisyCompute.ListCallOption) (map[string][]*compute.Instance, error)
+	ListInstancesFn                           func(project string, zone string, opts ...daisyCompute.ListCallOption) ([]*compute.Instance, error)
+	ListInstancesByStatusFn                   func(project string, zone string, status string) ([]*compute.Instance, error)
+	GetInstanceReferrersFn                    func(project string, zone string, instance string) ([]*compute.Reference, error)
+	ListDiskUsersFn                           func(project string, zone string, disk string) ([]*compute.Instance, error)
+	ListAttachedAcceleratorsFn                func(project string, zone string) (map[string][]*compute.AcceleratorConfig, error)
+	AggregatedListDisksFn                     func(project string, opts ...daisyCompute.ListCallOption) ([]*compute.Disk, error)
+	AggregatedListDisksByZoneFn               func(project string, opts ...daisyCompute.ListCallOption) (map[string][]*compute.Disk, error)
+	ListDisksFn                               func(project string, zone string, opts ...daisyCompute.ListCallOption) ([]*compute.Disk, error)
+	ListUnattachedDisksFn                     func(project string, zone string) ([]*compute.Disk, error)
+	DeleteUnattachedDisksFn                   func(project string, zone string) error
+	AggregatedListForwardingRulesFn           func(project string, opts ...daisyCompute.ListCallOption) ([]*compute.ForwardingRule, error)
+	ListForwardingRulesFn                     func(project string, zone string, opts ...daisyCompute.ListCallOption) ([]*compute.ForwardingRule, error)
+	ListFirewallRulesFn                       func(project string, opts ...daisyCompute.ListCallOption) ([]*compute.Firewall, error)
+	ListImagesFn                              func(project string, opts ...daisyCompute.ListCallOption) ([]*compute.Image, error)
+	ListImagesAlphaFn                         func(project string, opts ...daisyCompute.ListCallOption) ([]*computeAlpha.Image, error)
+	GetSnapshotFn                             func(project string, name string) (*compute.Snapshot, error)
+	SetSnapshotLabelsFn                       func(project string, name string, labels map[string]string, fingerprint string) error
+	ListSnapshotsFn                           func(project string, opts ...daisyCompute.ListCallOption) ([]*compute.Snapshot, error)
+	DeleteSnapshotFn                          func(project string, name string) error
+	ListNetworksFn                            func(project string, opts ...daisyCompute.ListCallOption) ([]*compute.Network, error)
+	AggregatedListSubnetworksFn               func(project string, opts ...daisyCompute.ListCallOption) ([]*compute.Subnetwork, error)
+	ListSubnetworksFn                         func(project string, region string, opts ...daisyCompute.ListCallOption) ([]*compute.Subnetwork, error)
+	ListTargetInstancesFn                     func(project string, zone string, opts ...daisyCompute.ListCallOption) ([]*compute.TargetInstance, error)
+	ResizeDiskFn                              func(project string, zone string, disk string, drr *compute.DisksResizeRequest) error
+	SetInstanceMetadataFn                     func(project string, zone string, name string, md *compute.Metadata) error
+	ResetWindowsPasswordFn                    func(project string, zone string, instance string, username string) (string, error)
+	SetCommonInstanceMetadataFn               func(project string, md *compute.Metadata) error
+	SetProjectMetadataItemFn                  func(project string, key string, value string) error
+	SetUsageExportBucketFn                    func(project string, cfg *compute.UsageExportLocation) error
+	GetXpnHostFn                              func(project string) (*compute.Project, error)
+	ListXpnHostsFn                            func(project string, organization string) ([]*compute.Project, error)
+	EnableXpnResourceFn                       func(project string, req *compute.ProjectsEnableXpnResourceRequest) error
+	DisableXpnResourceFn                      func(project string, req *compute.ProjectsDisableXpnResourceRequest) error
+	SetDiskAutoDeleteFn                       func(project string, zone string, instance string, autoDelete bool, deviceName string) error
+	ListMachineImagesFn                       func(project string, opts ...daisyCompute.ListCallOption) ([]*compute.MachineImage, error)
+	DeleteMachineImageFn                      func(project string, name string) error
+	CreateMachineImageFn                      func(project string, i *compute.MachineImage) error
+	GetMachineImageFn                         func(project string, name string) (*compute.MachineImage, error)
+	SuspendFn                                 func(project string, zone string, instance string) error
+	ResumeFn                                  func(project string, zone string, instance string) error
+	ResumeWithEncryptionKeyFn                 func(project string, zone string, instance string, req *computeBeta.InstancesResumeRequest) error
+	PerformMaintenanceFn                      func(project string, zone string, instance string) error
+	SetInstanceNameFn                         func(project string, zone string, instance string, newName string) error
+	UpdateInstanceFn                          func(project string, zone string, i *compute.Instance, fields ...string) error
+	DeleteRegionTargetHTTPProxyFn             func(project string, region string, name string) error
+	CreateRegionTargetHTTPProxyFn             func(project string, region string, p *compute.TargetHttpProxy) error
+	ListRegionTargetHTTPProxiesFn             func(project string, region string, opts ...daisyCompute.ListCallOption) ([]*compute.TargetHttpProxy, error)
+	GetRegionTargetHTTPProxyFn                func(project string, region string, name string) (*compute.TargetHttpProxy, error)
+	DeleteRegionSSLCertificateFn              func(project string, region string, name string) error
+	CreateRegionSSLCertificateFn              func(project string, region string, sc *compute.SslCertificate) error
+	GetRegionSSLCertificateFn                 func(project string, region string, name string) (*compute.SslCertificate, error)
+	DeleteRegionTargetHTTPSProxyFn            func(project string, region string, name string) error
+	CreateRegionTargetHTTPSProxyFn            func(project string, region string, p *compute.TargetHttpsProxy) error
+	GetRegionTargetHTTPSProxyFn               func(project string, region string, name string) (*compute.TargetHttpsProxy, error)
+	SetRegionSSLCertificatesFn                func(project string, region string, proxy string, sslCertificates []string) error
+	DeleteRegionURLMapFn                      func(project string, region string, name string) error
+	CreateRegionURLMapFn                      func(project string, region string, u *compute.UrlMap) error
+	ListRegionURLMapsFn                       func(project string, region string, opts ...daisyCompute.ListCallOption) ([]*compute.UrlMap, error)
+	GetRegionURLMapFn                         func(project string, region string, name string) (*compute.UrlMap, error)
+	ValidateRegionURLMapFn                    func(project string, region string, u *compute.UrlMap) (*compute.UrlMapsValidateResponse, error)
+	ValidateURLMapFn                          func(project string, u *compute.UrlMap) (*compute.UrlMapsValidateResponse, error)
+	DeleteRegionBackendServiceFn              func(project string, region string, name string) error
+	CreateRegionBackendServiceFn              func(project string, region string, b *compute.BackendService) error
+	PatchRegionBackendServiceFn               func(project string, region string, name string, b *compute.BackendService) error
+	ListRegionBackendServicesFn               func(project string, region string, opts ...daisyCompute.ListCallOption) ([]*compute.BackendService, error)
+	GetRegionBackendServiceFn                 func(project string, region string, name string) (*compute.BackendService, error)
+	GetRegionBackendServiceHealthFn           func(project string, region string, backendService string, group string) (*compute.BackendServiceGroupHealth, error)
+	WaitForBackendServiceHealthyFn            func(project string, region string, backendService string, group string, minHealthy int, timeout time.Duration) error
+	DeleteRegionHealthCheckFn                 func(project string, region string, name string) error
+	CreateRegionHealthCheckFn                 func(project string, region string, h *compute.HealthCheck) error
+	ListRegionHealthChecksFn                  func(project string, region string, opts ...daisyCompute.ListCallOption) ([]*compute.HealthCheck, error)
+	GetRegionHealthCheckFn                    func(project string, region string, name string) (*compute.HealthCheck, error)
+	CreateInstanceGroupFn                     func(project string, zone string, ig *compute.InstanceGroup) error
+	DeleteInstanceGroupFn                     func(project string, zone string, name string) error
+	GetInstanceGroupFn                        func(project string, zone string, name string) (*compute.InstanceGroup, error)
+	AddInstanceGroupInstancesFn               func(project string, zone string, name string, instances []string) error
+	RemoveInstanceGroupInstancesFn            func(project string, zone string, name string, instances []string) error
+	AttachNetworkEndpointsFn                  func(project string, zone string, neg string, endpoints []*compute.NetworkEndpoint) error
+	DetachNetworkEndpointsFn                  func(project string, zone string, neg string, endpoints []*compute.NetworkEndpoint) error
+	AttachRegionNetworkEndpointsFn            func(project string, region string, neg string, endpoints []*compute.NetworkEndpoint) error
+	DetachRegionNetworkEndpointsFn            func(project string, region string, neg string, endpoints []*compute.NetworkEndpoint) error
+	DeleteRegionNetworkEndpointGroupFn        func(project string, region string, name string) error
+	CreateRegionNetworkEndpointGroupFn        func(project string, region string, n *compute.NetworkEndpointGroup) error
+	ListRegionNetworkEndpointGroupsFn         func(project string, region string, opts ...daisyCompute.ListCallOption) ([]*compute.NetworkEndpointGroup, error)
+	GetRegionNetworkEndpointGroupFn           func(project string, region string, name string) (*compute.NetworkEndpointGroup, error)
+	GetRegionAutoscalerFn                     func(project string, region string, name string) (*compute.Autoscaler, error)
+	ListRegionAutoscalersFn                   func(project string, region string, opts ...daisyCompute.ListCallOption) ([]*compute.Autoscaler, error)
+	GetRegionAutoscalerRecommendedSizeFn      func(project string, region string, name string) (int64, error)
+	RetryFn                                   func(fArg func(opts ...googleapi.CallOption) (*compute.Operation, error), opts ...googleapi.CallOption) (*compute.Operation, error)
+	RetryContextFn                            func(ctx context.Context, fArg func(opts ...googleapi.CallOption) (*compute.Operation, error), opts ...googleapi.CallOption) (*compute.Operation, error)
+	RetryBetaFn                               func(fArg func(opts ...googleapi.CallOption) (*computeBeta.Operation, error), opts ...googleapi.CallOption) (*computeBeta.Operation, error)
+	BasePathFn                                func() string
+	DefaultProjectFn                          func() *daisyCompute.ProjectClient
+}
+
+// AttachDisk records the call and calls AttachDiskFn if it is set.
+func (f *FakeClient) AttachDisk(project string, zone string, instance string, d *compute.AttachedDisk) error {
+	f.record("AttachDisk", project, zone, instance, d)
+	if f.AttachDiskFn != nil {
+		return f.AttachDiskFn(project, zone, instance, d)
+	}
+	return f.err("AttachDisk")
+}
+
+// DetachDisk records the call and calls DetachDiskFn if it is set.
+func (f *FakeClient) DetachDisk(project string, zone string, instance string, disk string) error {
+	f.record("DetachDisk", project, zone, instance, disk)
+	if f.DetachDiskFn != nil {
+		return f.DetachDiskFn(project, zone, instance, disk)
+	}
+	return f.err("DetachDisk")
+}
+
+// CreateDiskFromImageAndAttach records the call and calls CreateDiskFromImageAndAttachFn if it is set.
+func (f *FakeClient) CreateDiskFromImageAndAttach(project string, zone string, instance string, image string, d *compute.Disk) error {
+	f.record("CreateDiskFromImageAndAttach", project, zone, instance, image, d)
+	if f.CreateDiskFromImageAndAttachFn != nil {
+		return f.CreateDiskFromImageAndAttachFn(project, zone, instance, image, d)
+	}
+	return f.err("CreateDiskFromImageAndAttach")
+}
+
+// CreateDiskFromSnapshot records the call and calls CreateDiskFromSnapshotFn if it is set.
+func (f *FakeClient) CreateDiskFromSnapshot(project string, zone string, diskName string, snapshotSelfLink string, sizeGb int64, diskType string) error {
+	f.record("CreateDiskFromSnapshot", project, zone, diskName, snapshotSelfLink, sizeGb, diskType)
+	if f.CreateDiskFromSnapshotFn != nil {
+		return f.CreateDiskFromSnapshotFn(project, zone, diskName, snapshotSelfLink, sizeGb, diskType)
+	}
+	return f.err("CreateDiskFromSnapshot")
+}
+
+// CloneDiskReencrypt records the call and calls CloneDiskReencryptFn if it is set.
+func (f *FakeClient) CloneDiskReencrypt(project string, zone string, sourceDisk string, diskName string, key *compute.CustomerEncryptionKey) error {
+	f.record("CloneDiskReencrypt", project, zone, sourceDisk, diskName, key)
+	if f.CloneDiskReencryptFn != nil {
+		return f.CloneDiskReencryptFn(project, zone, sourceDisk, diskName, key)
+	}
+	return f.err("CloneDiskReencrypt")
+}
+
+// CreateDisk records the call and calls CreateDiskFn if it is set.
+func (f *FakeClient) CreateDisk(project string, zone string, d *compute.Disk) error {
+	f.record("CreateDisk", project, zone, d)
+	if f.CreateDiskFn != nil {
+		return f.CreateDiskFn(project, zone, d)
+	}
+	return f.err("CreateDisk")
+}
+
+// CreateDiskAlpha records the call and calls CreateDiskAlphaFn if it is set.
+func (f *FakeClient) CreateDiskAlpha(project string, zone string, d *computeAlpha.Disk) error {
+	f.record("CreateDiskAlpha", project, zone, d)
+	if f.CreateDiskAlphaFn != nil {
+		return f.CreateDiskAlphaFn(project, zone, d)
+	}
+	return f.err("CreateDiskAlpha")
+}
+
+// CreateDiskBeta records the call and calls CreateDiskBetaFn if it is set.
+func (f *FakeClient) CreateDiskBeta(project string, zone string, d *computeBeta.Disk) error {
+	f.record("CreateDiskBeta", project, zone, d)
+	if f.CreateDiskBetaFn != nil {
+		return f.CreateDiskBetaFn(project, zone, d)
+	}
+	return f.err("CreateDiskBeta")
+}
+
+// CreateForwardingRule records the call and calls CreateForwardingRuleFn if it is set.
+func (f *FakeClient) CreateForwardingRule(project string, region string, fr *compute.ForwardingRule) error {
+	f.record("CreateForwardingRule", project, region, fr)
+	if f.CreateForwardingRuleFn != nil {
+		return f.CreateForwardingRuleFn(project, region, fr)
+	}
+	return f.err("CreateForwardingRule")
+}
+
+// CreateFirewallRule records the call and calls CreateFirewallRuleFn if it is set.
+func (f *FakeClient) CreateFirewallRule(project string, i *compute.Firewall) error {
+	f.record("CreateFirewallRule", project, i)
+	if f.CreateFirewallRuleFn != nil {
+		return f.CreateFirewallRuleFn(project, i)
+	}
+	return f.err("CreateFirewallRule")
+}
+
+// CreateFirewallRuleBeta records the call and calls CreateFirewallRuleBetaFn if it is set.
+func (f *FakeClient) CreateFirewallRuleBeta(project string, fw *computeBeta.Firewall) error {
+	f.record("CreateFirewallRuleBeta", project, fw)
+	if f.CreateFirewallRuleBetaFn != nil {
+		return f.CreateFirewallRuleBetaFn(project, fw)
+	}
+	return f.err("CreateFirewallRuleBeta")
+}
+
+// PatchFirewallRuleBeta records the call and calls PatchFirewallRuleBetaFn if it is set.
+func (f *FakeClient) PatchFirewallRuleBeta(project string, name string, fw *computeBeta.Firewall) error {
+	f.record("PatchFirewallRuleBeta", project, name, fw)
+	if f.PatchFirewallRuleBetaFn != nil {
+		return f.PatchFirewallRuleBetaFn(project, name, fw)
+	}
+	return f.err("PatchFirewallRuleBeta")
+}
+
+// CreateImage records the call and calls CreateImageFn if it is set.
+func (f *FakeClient) CreateImage(project string, i *compute.Image) error {
+	f.record("CreateImage", project, i)
+	if f.CreateImageFn != nil {
+		return f.CreateImageFn(project, i)
+	}
+	return f.err("CreateImage")
+}
+
+// CreateImageAlpha records the call and calls CreateImageAlphaFn if it is set.
+func (f *FakeClient) CreateImageAlpha(project string, i *computeAlpha.Image) error {
+	f.record("CreateImageAlpha", project, i)
+	if f.CreateImageAlphaFn != nil {
+		return f.CreateImageAlphaFn(project, i)
+	}
+	return f.err("CreateImageAlpha")
+}
+
+// CreateImageBeta records the call and calls CreateImageBetaFn if it is set.
+func (f *FakeClient) CreateImageBeta(project string, i *computeBeta.Image) error {
+	f.record("CreateImageBeta", project, i)
+	if f.CreateImageBetaFn != nil {
+		return f.CreateImageBetaFn(project, i)
+	}
+	return f.err("CreateImageBeta")
+}
+
+// CreateImageFromEncryptedDisk records the call and calls CreateImageFromEncryptedDiskFn if it is set.
+func (f *FakeClient) CreateImageFromEncryptedDisk(project string, im *compute.Image, diskKey *compute.CustomerEncryptionKey) error {
+	f.record("CreateImageFromEncryptedDisk", project, im, diskKey)
+	if f.CreateImageFromEncryptedDiskFn != nil {
+		return f.CreateImageFromEncryptedDiskFn(project, im, diskKey)
+	}
+	return f.err("CreateImageFromEncryptedDisk")
+}
+
+// CopyImageToLocation records the call and calls CopyImageToLocationFn if it is set.
+func (f *FakeClient) CopyImageToLocation(srcProject string, srcImage string, dstProject string, dstImage string, storageLocations []string) error {
+	f.record("CopyImageToLocation", srcProject, srcImage, dstProject, dstImage, storageLocations)
+	if f.CopyImageToLocationFn != nil {
+		return f.CopyImageToLocationFn(srcProject, srcImage, dstProject, dstImage, storageLocations)
+	}
+	return f.err("CopyImageToLocation")
+}
+
+// CreateInstance records the call and calls CreateInstanceFn if it is set.
+func (f *FakeClient) CreateInstance(project string, zone string, i *compute.Instance) error {
+	f.record("CreateInstance", project, zone, i)
+	if f.CreateInstanceFn != nil {
+		return f.CreateInstanceFn(project, zone, i)
+	}
+	return f.err("CreateInstance")
+}
+
+// CreateInstanceAlpha records the call and calls CreateInstanceAlphaFn if it is set.
+func (f *FakeClient) CreateInstanceAlpha(project string, zone string, i *computeAlpha.Instance) error {
+	f.record("CreateInstanceAlpha", project, zone, i)
+	if f.CreateInstanceAlphaFn != nil {
+		return f.CreateInstanceAlphaFn(project, zone, i)
+	}
+	return f.err("CreateInstanceAlpha")
+}
+
+// CreateInstanceBeta records the call and calls CreateInstanceBetaFn if it is set.
+func (f *FakeClient) CreateInstanceBeta(project string, zone string, i *computeBeta.Instance) error {
+	f.record("CreateInstanceBeta", project, zone, i)
+	if f.CreateInstanceBetaFn != nil {
+		return f.CreateInstanceBetaFn(project, zone, i)
+	}
+	return f.err("CreateInstanceBeta")
+}
+
+// CreateInstanceInZones records the call and calls CreateInstanceInZonesFn if it is set.
+func (f *FakeClient) CreateInstanceInZones(project string, zones []string, i *compute.Instance) (string, error) {
+	f.record("CreateInstanceInZones", project, zones, i)
+	if f.CreateInstanceInZonesFn != nil {
+		return f.CreateInstanceInZonesFn(project, zones, i)
+	}
+	var r0 string
+	return r0, f.err("CreateInstanceInZones")
+}
+
+// CreateInstanceIfNotExists records the call and calls CreateInstanceIfNotExistsFn if it is set.
+func (f *FakeClient) CreateInstanceIfNotExists(project string, zone string, i *compute.Instance) (bool, error) {
+	f.record("CreateInstanceIfNotExists", project, zone, i)
+	if f.CreateInstanceIfNotExistsFn != nil {
+		return f.CreateInstanceIfNotExistsFn(project, zone, i)
+	}
+	var r0 bool
+	return r0, f.err("CreateInstanceIfNotExists")
+}
+
+// CreateInstanceAndGet records the call and calls CreateInstanceAndGetFn if it is set.
+func (f *FakeClient) CreateInstanceAndGet(project string, zone string, i *compute.Instance) (*compute.Instance, error) {
+	f.record("CreateInstanceAndGet", project, zone, i)
+	if f.CreateInstanceAndGetFn != nil {
+		return f.CreateInstanceAndGetFn(project, zone, i)
+	}
+	var r0 *compute.Instance
+	return r0, f.err("CreateInstanceAndGet")
+}
+
+// CreateInstanceFromMachineImage records the call and calls CreateInstanceFromMachineImageFn if it is set.
+func (f *FakeClient) CreateInstanceFromMachineImage(project string, zone string, machineImage string, i *compute.Instance) error {
+	f.record("CreateInstanceFromMachineImage", project, zone, machineImage, i)
+	if f.CreateInstanceFromMachineImageFn != nil {
+		return f.CreateInstanceFromMachineImageFn(project, zone, machineImage, i)
+	}
+	return f.err("CreateInstanceFromMachineImage")
+}
+
+// BulkInsertInstance records the call and calls BulkInsertInstanceFn if it is set.
+func (f *FakeClient) BulkInsertInstance(project string, zone string, r *compute.BulkInsertInstanceResource) error {
+	f.record("BulkInsertInstance", project, zone, r)
+	if f.BulkInsertInstanceFn != nil {
+		return f.BulkInsertInstanceFn(project, zone, r)
+	}
+	return f.err("BulkInsertInstance")
+}
+
+// GetBulkInsertInstanceResult records the call and calls GetBulkInsertInstanceResultFn if it is set.
+func (f *FakeClient) GetBulkInsertInstanceResult(project string, zone string, r *compute.BulkInsertInstanceResource) ([]string, []string, error) {
+	f.record("GetBulkInsertInstanceResult", project, zone, r)
+	if f.GetBulkInsertInstanceResultFn != nil {
+		return f.GetBulkInsertInstanceResultFn(project, zone, r)
+	}
+	var r0 []string
+	var r1 []string
+	return r0, r1, f.err("GetBulkInsertInstanceResult")
+}
+
+// CreateNetwork records the call and calls CreateNetworkFn if it is set.
+func (f *FakeClient) CreateNetwork(project string, n *compute.Network) error {
+	f.record("CreateNetwork", project, n)
+	if f.CreateNetworkFn != nil {
+		return f.CreateNetworkFn(project, n)
+	}
+	return f.err("CreateNetwork")
+}
+
+// CreateSnapshot records the call and calls CreateSnapshotFn if it is set.
+func (f *FakeClient) CreateSnapshot(project string, zone string, disk string, s *compute.Snapshot) error {
+	f.record("CreateSnapshot", project, zone, disk, s)
+	if f.CreateSnapshotFn != nil {
+		return f.CreateSnapshotFn(project, zone, disk, s)
+	}
+	return f.err("CreateSnapshot")
+}
+
+// CreateSubnetwork records the call and calls CreateSubnetworkFn if it is set.
+func (f *FakeClient) CreateSubnetwork(project string, region string, n *compute.Subnetwork) error {
+	f.record("CreateSubnetwork", project, region, n)
+	if f.CreateSubnetworkFn != nil {
+		return f.CreateSubnetworkFn(project, region, n)
+	}
+	return f.err("CreateSubnetwork")
+}
+
+// CreateTargetInstance records the call and calls CreateTargetInstanceFn if it is set.
+func (f *FakeClient) CreateTargetInstance(project string, zone string, ti *compute.TargetInstance) error {
+	f.record("CreateTargetInstance", project, zone, ti)
+	if f.CreateTargetInstanceFn != nil {
+		return f.CreateTargetInstanceFn(project, zone, ti)
+	}
+	return f.err("CreateTargetInstance")
+}
+
+// DeleteDisk records the call and calls DeleteDiskFn if it is set.
+func (f *FakeClient) DeleteDisk(project string, zone string, name string) error {
+	f.record("DeleteDisk", project, zone, name)
+	if f.DeleteDiskFn != nil {
+		return f.DeleteDiskFn(project, zone, name)
+	}
+	return f.err("DeleteDisk")
+}
+
+// DeleteRegionDisk records the call and calls DeleteRegionDiskFn if it is set.
+func (f *FakeClient) DeleteRegionDisk(project string, region string, name string) error {
+	f.record("DeleteRegionDisk", project, region, name)
+	if f.DeleteRegionDiskFn != nil {
+		return f.DeleteRegionDiskFn(project, region, name)
+	}
+	return f.err("DeleteRegionDisk")
+}
+
+// DeleteForwardingRule records the call and calls DeleteForwardingRuleFn if it is set.
+func (f *FakeClient) DeleteForwardingRule(project string, region string, name string) error {
+	f.record("DeleteForwardingRule", project, region, name)
+	if f.DeleteForwardingRuleFn != nil {
+		return f.DeleteForwardingRuleFn(project, region, name)
+	}
+	return f.err("DeleteForwardingRule")
+}
+
+// DeleteFirewallRule records the call and calls DeleteFirewallRuleFn if it is set.
+func (f *FakeClient) DeleteFirewallRule(project string, name string) error {
+	f.record("DeleteFirewallRule", project, name)
+	if f.DeleteFirewallRuleFn != nil {
+		return f.DeleteFirewallRuleFn(project, name)
+	}
+	return f.err("DeleteFirewallRule")
+}
+
+// DeleteImage records the call and calls DeleteImageFn if it is set.
+func (f *FakeClient) DeleteImage(project string, name string) error {
+	f.record("DeleteImage", project, name)
+	if f.DeleteImageFn != nil {
+		return f.DeleteImageFn(project, name)
+	}
+	return f.err("DeleteImage")
+}
+
+// DeleteInstance records the call and calls DeleteInstanceFn if it is set.
+func (f *FakeClient) DeleteInstance(project string, zone string, name string) error {
+	f.record("DeleteInstance", project, zone, name)
+	if f.DeleteInstanceFn != nil {
+		return f.DeleteInstanceFn(project, zone, name)
+	}
+	return f.err("DeleteInstance")
+}
+
+// DeleteInstancesByFilter records the call and calls DeleteInstancesByFilterFn if it is set.
+func (f *FakeClient) DeleteInstancesByFilter(project string, zone string, filter string) error {
+	f.record("DeleteInstancesByFilter", project, zone, filter)
+	if f.DeleteInstancesByFilterFn != nil {
+		return f.DeleteInstancesByFilterFn(project, zone, filter)
+	}
+	return f.err("DeleteInstancesByFilter")
+}
+
+// TeardownByLabel records the call and calls TeardownByLabelFn if it is set.
+func (f *FakeClient) TeardownByLabel(project string, labelKey string, labelValue string) error {
+	f.record("TeardownByLabel", project, labelKey, labelValue)
+	if f.TeardownByLabelFn != nil {
+		return f.TeardownByLabelFn(project, labelKey, labelValue)
+	}
+	return f.err("TeardownByLabel")
+}
+
+// SetDeletionProtection records the call and calls SetDeletionProtectionFn if it is set.
+func (f *FakeClient) SetDeletionProtection(project string, zone string, instance string, enabled bool) error {
+	f.record("SetDeletionProtection", project, zone, instance, enabled)
+	if f.SetDeletionProtectionFn != nil {
+		return f.SetDeletionProtectionFn(project, zone, instance, enabled)
+	}
+	return f.err("SetDeletionProtection")
+}
+
+// UpdateNetworkInterface records the call and calls UpdateNetworkInterfaceFn if it is set.
+func (f *FakeClient) UpdateNetworkInterface(project string, zone string, instance string, networkInterface string, ni *compute.NetworkInterface) error {
+	f.record("UpdateNetworkInterface", project, zone, instance, networkInterface, ni)
+	if f.UpdateNetworkInterfaceFn != nil {
+		return f.UpdateNetworkInterfaceFn(project, zone, instance, networkInterface, ni)
+	}
+	return f.err("UpdateNetworkInterface")
+}
+
+// GetShieldedInstanceIdentity records the call and calls GetShieldedInstanceIdentityFn if it is set.
+func (f *FakeClient) GetShieldedInstanceIdentity(project string, zone string, instance string) (*compute.ShieldedInstanceIdentity, error) {
+	f.record("GetShieldedInstanceIdentity", project, zone, instance)
+	if f.GetShieldedInstanceIdentityFn != nil {
+		return f.GetShieldedInstanceIdentityFn(project, zone, instance)
+	}
+	var r0 *compute.ShieldedInstanceIdentity
+	return r0, f.err("GetShieldedInstanceIdentity")
+}
+
+// GetEffectiveFirewalls records the call and calls GetEffectiveFirewallsFn if it is set.
+func (f *FakeClient) GetEffectiveFirewalls(project string, zone string, instance string, networkInterface string) (*compute.InstancesGetEffectiveFirewallsResponse, error) {
+	f.record("GetEffectiveFirewalls", project, zone, instance, networkInterface)
+	if f.GetEffectiveFirewallsFn != nil {
+		return f.GetEffectiveFirewallsFn(project, zone, instance, networkInterface)
+	}
+	var r0 *compute.InstancesGetEffectiveFirewallsResponse
+	return r0, f.err("GetEffectiveFirewalls")
+}
+
+// GetScreenshot records the call and calls GetScreenshotFn if it is set.
+func (f *FakeClient) GetScreenshot(project string, zone string, instance string) (*compute.Screenshot, error) {
+	f.record("GetScreenshot", project, zone, instance)
+	if f.GetScreenshotFn != nil {
+		return f.GetScreenshotFn(project, zone, instance)
+	}
+	var r0 *compute.Screenshot
+	return r0, f.err("GetScreenshot")
+}
+
+// GetInstanceIamPolicy records the call and calls GetInstanceIamPolicyFn if it is set.
+func (f *FakeClient) GetInstanceIamPolicy(project string, zone string, instance string) (*compute.Policy, error) {
+	f.record("GetInstanceIamPolicy", project, zone, instance)
+	if f.GetInstanceIamPolicyFn != nil {
+		return f.GetInstanceIamPolicyFn(project, zone, instance)
+	}
+	var r0 *compute.Policy
+	return r0, f.err("GetInstanceIamPolicy")
+}
+
+// SetInstanceIamPolicy records the call and calls SetInstanceIamPolicyFn if it is set.
+func (f *FakeClient) SetInstanceIamPolicy(project string, zone string, instance string, policy *compute.Policy) error {
+	f.record("SetInstanceIamPolicy", project, zone, instance, policy)
+	if f.SetInstanceIamPolicyFn != nil {
+		return f.SetInstanceIamPolicyFn(project, zone, instance, policy)
+	}
+	return f.err("SetInstanceIamPolicy")
+}
+
+// TestInstanceIamPermissions records the call and calls TestInstanceIamPermissionsFn if it is set.
+func (f *FakeClient) TestInstanceIamPermissions(project string, zone string, instance string, permissions []string) ([]string, error) {
+	f.record("TestInstanceIamPermissions", project, zone, instance, permissions)
+	if f.TestInstanceIamPermissionsFn != nil {
+		return f.TestInstanceIamPermissionsFn(project, zone, instance, permissions)
+	}
+	var r0 []string
+	return r0, f.err("TestInstanceIamPermissions")
+}
+
+// SetShieldedInstanceIntegrityPolicy records the call and calls SetShieldedInstanceIntegrityPolicyFn if it is set.
+func (f *FakeClient) SetShieldedInstanceIntegrityPolicy(project string, zone string, instance string, p *compute.ShieldedInstanceIntegrityPolicy) error {
+	f.record("SetShieldedInstanceIntegrityPolicy", project, zone, instance, p)
+	if f.SetShieldedInstanceIntegrityPolicyFn != nil {
+		return f.SetShieldedInstanceIntegrityPolicyFn(project, zone, instance, p)
+	}
+	return f.err("SetShieldedInstanceIntegrityPolicy")
+}
+
+// StartInstance records the call and calls StartInstanceFn if it is set.
+func (f *FakeClient) StartInstance(project string, zone string, name string) error {
+	f.record("StartInstance", project, zone, name)
+	if f.StartInstanceFn != nil {
+		return f.StartInstanceFn(project, zone, name)
+	}
+	return f.err("StartInstance")
+}
+
+// StopInstance records the call and calls StopInstanceFn if it is set.
+func (f *FakeClient) StopInstance(project string, zone string, name string) error {
+	f.record("StopInstance", project, zone, name)
+	if f.StopInstanceFn != nil {
+		return f.StopInstanceFn(project, zone, name)
+	}
+	return f.err("StopInstance")
+}
+
+// StopInstanceWithOptions records the call and calls StopInstanceWithOptionsFn if it is set.
+func (f *FakeClient) StopInstanceWithOptions(project string, zone string, name string, discardLocalSSD bool) error {
+	f.record("StopInstanceWithOptions", project, zone, name, discardLocalSSD)
+	if f.StopInstanceWithOptionsFn != nil {
+		return f.StopInstanceWithOptionsFn(project, zone, name, discardLocalSSD)
+	}
+	return f.err("StopInstanceWithOptions")
+}
+
+// DeleteNetwork records the call and calls DeleteNetworkFn if it is set.
+func (f *FakeClient) DeleteNetwork(project string, name string) error {
+	f.record("DeleteNetwork", project, name)
+	if f.DeleteNetworkFn != nil {
+		return f.DeleteNetworkFn(project, name)
+	}
+	return f.err("DeleteNetwork")
+}
+
+// DeleteSubnetwork records the call and calls DeleteSubnetworkFn if it is set.
+func (f *FakeClient) DeleteSubnetwork(project string, region string, name string) error {
+	f.record("DeleteSubnetwork", project, region, name)
+	if f.DeleteSubnetworkFn != nil {
+		return f.DeleteSubnetworkFn(project, region, name)
+	}
+	return f.err("DeleteSubnetwork")
+}
+
+// DeleteTargetInstance records the call and calls DeleteTargetInstanceFn if it is set.
+func (f *FakeClient) DeleteTargetInstance(project string, zone string, name string) error {
+	f.record("DeleteTargetInstance", project, zone, name)
+	if f.DeleteTargetInstanceFn != nil {
+		return f.DeleteTargetInstanceFn(project, zone, name)
+	}
+	return f.err("DeleteTargetInstance")
+}
+
+// DeprecateImage records the call and calls DeprecateImageFn if it is set.
+func (f *FakeClient) DeprecateImage(project string, name string, deprecationstatus *compute.DeprecationStatus) error {
+	f.record("DeprecateImage", project, name, deprecationstatus)
+	if f.DeprecateImageFn != nil {
+		return f.DeprecateImageFn(project, name, deprecationstatus)
+	}
+	return f.err("DeprecateImage")
+}
+
+// DeprecateImageAlpha records the call and calls DeprecateImageAlphaFn if it is set.
+func (f *FakeClient) DeprecateImageAlpha(project string, name string, deprecationstatus *computeAlpha.DeprecationStatus) error {
+	f.record("DeprecateImageAlpha", project, name, deprecationstatus)
+	if f.DeprecateImageAlphaFn != nil {
+		return f.DeprecateImageAlphaFn(project, name, deprecationstatus)
+	}
+	return f.err("DeprecateImageAlpha")
+}
+
+// DeprecateImageFamily records the call and calls DeprecateImageFamilyFn if it is set.
+func (f *FakeClient) DeprecateImageFamily(project string, family string, keepLatest int, status *compute.DeprecationStatus) error {
+	f.record("DeprecateImageFamily", project, family, keepLatest, status)
+	if f.DeprecateImageFamilyFn != nil {
+		return f.DeprecateImageFamilyFn(project, family, keepLatest, status)
+	}
+	return f.err("DeprecateImageFamily")
+}
+
+// GetMachineType records the call and calls GetMachineTypeFn if it is set.
+func (f *FakeClient) GetMachineType(project string, zone string, machineType string) (*compute.MachineType, error) {
+	f.record("GetMachineType", project, zone, machineType)
+	if f.GetMachineTypeFn != nil {
+		return f.GetMachineTypeFn(project, zone, machineType)
+	}
+	var r0 *compute.MachineType
+	return r0, f.err("GetMachineType")
+}
+
+// GetAcceleratorType records the call and calls GetAcceleratorTypeFn if it is set.
+func (f *FakeClient) GetAcceleratorType(project string, zone string, acceleratorType string) (*compute.AcceleratorType, error) {
+	f.record("GetAcceleratorType", project, zone, acceleratorType)
+	if f.GetAcceleratorTypeFn != nil {
+		return f.GetAcceleratorTypeFn(project, zone, acceleratorType)
+	}
+	var r0 *compute.AcceleratorType
+	return r0, f.err("GetAcceleratorType")
+}
+
+// GetReservation records the call and calls GetReservationFn if it is set.
+func (f *FakeClient) GetReservation(project string, zone string, name string) (*compute.Reservation, error) {
+	f.record("GetReservation", project, zone, name)
+	if f.GetReservationFn != nil {
+		return f.GetReservationFn(project, zone, name)
+	}
+	var r0 *compute.Reservation
+	return r0, f.err("GetReservation")
+}
+
+// GetProject records the call and calls GetProjectFn if it is set.
+func (f *FakeClient) GetProject(project string) (*compute.Project, error) {
+	f.record("GetProject", project)
+	if f.GetProjectFn != nil {
+		return f.GetProjectFn(project)
+	}
+	var r0 *compute.Project
+	return r0, f.err("GetProject")
+}
+
+// GetSerialPortOutput records the call and calls GetSerialPortOutputFn if it is set.
+func (f *FakeClient) GetSerialPortOutput(project string, zone string, name string, port int64, start int64) (*compute.SerialPortOutput, error) {
+	f.record("GetSerialPortOutput", project, zone, name, port, start)
+	if f.GetSerialPortOutputFn != nil {
+		return f.GetSerialPortOutputFn(project, zone, name, port, start)
+	}
+	var r0 *compute.SerialPortOutput
+	return r0, f.err("GetSerialPortOutput")
+}
+
+// SendDiagnosticInterrupt records the call and calls SendDiagnosticInterruptFn if it is set.
+func (f *FakeClient) SendDiagnosticInterrupt(project string, zone string, instance string) error {
+	f.record("SendDiagnosticInterrupt", project, zone, instance)
+	if f.SendDiagnosticInterruptFn != nil {
+		return f.SendDiagnosticInterruptFn(project, zone, instance)
+	}
+	return f.err("SendDiagnosticInterrupt")
+}
+
+// GetZone records the call and calls GetZoneFn if it is set.
+func (f *FakeClient) GetZone(project string, zone string) (*compute.Zone, error) {
+	f.record("GetZone", project, zone)
+	if f.GetZoneFn != nil {
+		return f.GetZoneFn(project, zone)
+	}
+	var r0 *compute.Zone
+	return r0, f.err("GetZone")
+}
+
+// GetInstance records the call and calls GetInstanceFn if it is set.
+func (f *FakeClient) GetInstance(project string, zone string, name string) (*compute.Instance, error) {
+	f.record("GetInstance", project, zone, name)
+	if f.GetInstanceFn != nil {
+		return f.GetInstanceFn(project, zone, name)
+	}
+	var r0 *compute.Instance
+	return r0, f.err("GetInstance")
+}
+
+// GetInstanceAlpha records the call and calls GetInstanceAlphaFn if it is set.
+func (f *FakeClient) GetInstanceAlpha(project string, zone string, name string) (*computeAlpha.Instance, error) {
+	f.record("GetInstanceAlpha", project, zone, name)
+	if f.GetInstanceAlphaFn != nil {
+		return f.GetInstanceAlphaFn(project, zone, name)
+	}
+	var r0 *computeAlpha.Instance
+	return r0, f.err("GetInstanceAlpha")
+}
+
+// GetInstanceBeta records the call and calls GetInstanceBetaFn if it is set.
+func (f *FakeClient) GetInstanceBeta(project string, zone string, name string) (*computeBeta.Instance, error) {
+	f.record("GetInstanceBeta", project, zone, name)
+	if f.GetInstanceBetaFn != nil {
+		return f.GetInstanceBetaFn(project, zone, name)
+	}
+	var r0 *computeBeta.Instance
+	return r0, f.err("GetInstanceBeta")
+}
+
+// GetDisk records the call and calls GetDiskFn if it is set.
+func (f *FakeClient) GetDisk(project string, zone string, name string) (*compute.Disk, error) {
+	f.record("GetDisk", project, zone, name)
+	if f.GetDiskFn != nil {
+		return f.GetDiskFn(project, zone, name)
+	}
+	var r0 *compute.Disk
+	return r0, f.err("GetDisk")
+}
+
+// GetRegionDisk records the call and calls GetRegionDiskFn if it is set.
+func (f *FakeClient) GetRegionDisk(project string, region string, name string) (*compute.Disk, error) {
+	f.record("GetRegionDisk", project, region, name)
+	if f.GetRegionDiskFn != nil {
+		return f.GetRegionDiskFn(project, region, name)
+	}
+	var r0 *compute.Disk
+	return r0, f.err("GetRegionDisk")
+}
+
+// GetDiskAlpha records the call and calls GetDiskAlphaFn if it is set.
+func (f *FakeClient) GetDiskAlpha(project string, zone string, name string) (*computeAlpha.Disk, error) {
+	f.record("GetDiskAlpha", project, zone, name)
+	if f.GetDiskAlphaFn != nil {
+		return f.GetDiskAlphaFn(project, zone, name)
+	}
+	var r0 *computeAlpha.Disk
+	return r0, f.err("GetDiskAlpha")
+}
+
+// GetDiskBeta records the call and calls GetDiskBetaFn if it is set.
+func (f *FakeClient) GetDiskBeta(project string, zone string, name string) (*computeBeta.Disk, error) {
+	f.record("GetDiskBeta", project, zone, name)
+	if f.GetDiskBetaFn != nil {
+		return f.GetDiskBetaFn(project, zone, name)
+	}
+	var r0 *computeBeta.Disk
+	return r0, f.err("GetDiskBeta")
+}
+
+// GetForwardingRule records the call and calls GetForwardingRuleFn if it is set.
+func (f *FakeClient) GetForwardingRule(project string, region string, name string) (*compute.ForwardingRule, error) {
+	f.record("GetForwardingRule", project, region, name)
+	if f.GetForwardingRuleFn != nil {
+		return f.GetForwardingRuleFn(project, region, name)
+	}
+	var r0 *compute.ForwardingRule
+	return r0, f.err("GetForwardingRule")
+}
+
+// GetFirewallRule records the call and calls GetFirewallRuleFn if it is set.
+func (f *FakeClient) GetFirewallRule(project string, name string) (*compute.Firewall, error) {
+	f.record("GetFirewallRule", project, name)
+	if f.GetFirewallRuleFn != nil {
+		return f.GetFirewallRuleFn(project, name)
+	}
+	var r0 *compute.Firewall
+	return r0, f.err("GetFirewallRule")
+}
+
+// GetFirewallRuleBeta records the call and calls GetFirewallRuleBetaFn if it is set.
+func (f *FakeClient) GetFirewallRuleBeta(project string, name string) (*computeBeta.Firewall, error) {
+	f.record("GetFirewallRuleBeta", project, name)
+	if f.GetFirewallRuleBetaFn != nil {
+		return f.GetFirewallRuleBetaFn(project, name)
+	}
+	var r0 *computeBeta.Firewall
+	return r0, f.err("GetFirewallRuleBeta")
+}
+
+// GetGuestAttributes records the call and calls GetGuestAttributesFn if it is set.
+func (f *FakeClient) GetGuestAttributes(project string, zone string, name string, queryPath string, variableKey string) (*compute.GuestAttributes, error) {
+	f.record("GetGuestAttributes", project, zone, name, queryPath, variableKey)
+	if f.GetGuestAttributesFn != nil {
+		return f.GetGuestAttributesFn(project, zone, name, queryPath, variableKey)
+	}
+	var r0 *compute.GuestAttributes
+	return r0, f.err("GetGuestAttributes")
+}
+
+// GetImage records the call and calls GetImageFn if it is set.
+func (f *FakeClient) GetImage(project string, name string) (*compute.Image, error) {
+	f.record("GetImage", project, name)
+	if f.GetImageFn != nil {
+		return f.GetImageFn(project, name)
+	}
+	var r0 *compute.Image
+	return r0, f.err("GetImage")
+}
+
+// GetImageAlpha records the call and calls GetImageAlphaFn if it is set.
+func (f *FakeClient) GetImageAlpha(project string, name string) (*computeAlpha.Image, error) {
+	f.record("GetImageAlpha", project, name)
+	if f.GetImageAlphaFn != nil {
+		return f.GetImageAlphaFn(project, name)
+	}
+	var r0 *computeAlpha.Image
+	return r0, f.err("GetImageAlpha")
+}
+
+// GetImageBeta records the call and calls GetImageBetaFn if it is set.
+func (f *FakeClient) GetImageBeta(project string, name string) (*computeBeta.Image, error) {
+	f.record("GetImageBeta", project, name)
+	if f.GetImageBetaFn != nil {
+		return f.GetImageBetaFn(project, name)
+	}
+	var r0 *computeBeta.Image
+	return r0, f.err("GetImageBeta")
+}
+
+// GetImageFromFamily records the call and calls GetImageFromFamilyFn if it is set.
+func (f *FakeClient) GetImageFromFamily(project string, family string) (*compute.Image, error) {
+	f.record("GetImageFromFamily", project, family)
+	if f.GetImageFromFamilyFn != nil {
+		return f.GetImageFromFamilyFn(project, family)
+	}
+	var r0 *compute.Image
+	return r0, f.err("GetImageFromFamily")
+}
+
+// GetLatestImageFromFamilies records the call and calls GetLatestImageFromFamiliesFn if it is set.
+func (f *FakeClient) GetLatestImageFromFamilies(projects []string, family string) (*compute.Image, error) {
+	f.record("GetLatestImageFromFamilies", projects, family)
+	if f.GetLatestImageFromFamiliesFn != nil {
+		return f.GetLatestImageFromFamiliesFn(projects, family)
+	}
+	var r0 *compute.Image
+	return r0, f.err("GetLatestImageFromFamilies")
+}
+
+// GetLicense records the call and calls GetLicenseFn if it is set.
+func (f *FakeClient) GetLicense(project string, name string) (*compute.License, error) {
+	f.record("GetLicense", project, name)
+	if f.GetLicenseFn != nil {
+		return f.GetLicenseFn(project, name)
+	}
+	var r0 *compute.License
+	return r0, f.err("GetLicense")
+}
+
+// GetInstanceTemplate records the call and calls GetInstanceTemplateFn if it is set.
+func (f *FakeClient) GetInstanceTemplate(project string, name string) (*compute.InstanceTemplate, error) {
+	f.record("GetInstanceTemplate", project, name)
+	if f.GetInstanceTemplateFn != nil {
+		return f.GetInstanceTemplateFn(project, name)
+	}
+	var r0 *compute.InstanceTemplate
+	return r0, f.err("GetInstanceTemplate")
+}
+
+// GetInstanceGroupManager records the call and calls GetInstanceGroupManagerFn if it is set.
+func (f *FakeClient) GetInstanceGroupManager(project string, zone string, name string) (*compute.InstanceGroupManager, error) {
+	f.record("GetInstanceGroupManager", project, zone, name)
+	if f.GetInstanceGroupManagerFn != nil {
+		return f.GetInstanceGroupManagerFn(project, zone, name)
+	}
+	var r0 *compute.InstanceGroupManager
+	return r0, f.err("GetInstanceGroupManager")
+}
+
+// GetRegionInstanceGroupManager records the call and calls GetRegionInstanceGroupManagerFn if it is set.
+func (f *FakeClient) GetRegionInstanceGroupManager(project string, region string, name string) (*compute.InstanceGroupManager, error) {
+	f.record("GetRegionInstanceGroupManager", project, region, name)
+	if f.GetRegionInstanceGroupManagerFn != nil {
+		return f.GetRegionInstanceGroupManagerFn(project, region, name)
+	}
+	var r0 *compute.InstanceGroupManager
+	return r0, f.err("GetRegionInstanceGroupManager")
+}
+
+// WaitForInstanceGroupManagerStable records the call and calls WaitForInstanceGroupManagerStableFn if it is set.
+func (f *FakeClient) WaitForInstanceGroupManagerStable(project string, zone string, name string, timeout time.Duration) error {
+	f.record("WaitForInstanceGroupManagerStable", project, zone, name, timeout)
+	if f.WaitForInstanceGroupManagerStableFn != nil {
+		return f.WaitForInstanceGroupManagerStableFn(project, zone, name, timeout)
+	}
+	return f.err("WaitForInstanceGroupManagerStable")
+}
+
+// WaitForRegionInstanceGroupManagerStable records the call and calls WaitForRegionInstanceGroupManagerStableFn if it is set.
+func (f *FakeClient) WaitForRegionInstanceGroupManagerStable(project string, region string, name string, timeout time.Duration) error {
+	f.record("WaitForRegionInstanceGroupManagerStable", project, region, name, timeout)
+	if f.WaitForRegionInstanceGroupManagerStableFn != nil {
+		return f.WaitForRegionInstanceGroupManagerStableFn(project, region, name, timeout)
+	}
+	return f.err("WaitForRegionInstanceGroupManagerStable")
+}
+
+// GetNetwork records the call and calls GetNetworkFn if it is set.
+func (f *FakeClient) GetNetwork(project string, name string) (*compute.Network, error) {
+	f.record("GetNetwork", project, name)
+	if f.GetNetworkFn != nil {
+		return f.GetNetworkFn(project, name)
+	}
+	var r0 *compute.Network
+	return r0, f.err("GetNetwork")
+}
+
+// GetRegion records the call and calls GetRegionFn if it is set.
+func (f *FakeClient) GetRegion(project string, region string) (*compute.Region, error) {
+	f.record("GetRegion", project, region)
+	if f.GetRegionFn != nil {
+		return f.GetRegionFn(project, region)
+	}
+	var r0 *compute.Region
+	return r0, f.err("GetRegion")
+}
+
+// GetSubnetwork records the call and calls GetSubnetworkFn if it is set.
+func (f *FakeClient) GetSubnetwork(project string, region string, name string) (*compute.Subnetwork, error) {
+	f.record("GetSubnetwork", project, region, name)
+	if f.GetSubnetworkFn != nil {
+		return f.GetSubnetworkFn(project, region, name)
+	}
+	var r0 *compute.Subnetwork
+	return r0, f.err("GetSubnetwork")
+}
+
+// GetTargetInstance records the call and calls GetTargetInstanceFn if it is set.
+func (f *FakeClient) GetTargetInstance(project string, zone string, name string) (*compute.TargetInstance, error) {
+	f.record("GetTargetInstance", project, zone, name)
+	if f.GetTargetInstanceFn != nil {
+		return f.GetTargetInstanceFn(project, zone, name)
+	}
+	var r0 *compute.TargetInstance
+	return r0, f.err("GetTargetInstance")
+}
+
+// GetBackendService records the call and calls GetBackendServiceFn if it is set.
+func (f *FakeClient) GetBackendService(project string, name string) (*compute.BackendService, error) {
+	f.record("GetBackendService", project, name)
+	if f.GetBackendServiceFn != nil {
+		return f.GetBackendServiceFn(project, name)
+	}
+	var r0 *compute.BackendService
+	return r0, f.err("GetBackendService")
+}
+
+// GetBackendServiceHealth records the call and calls GetBackendServiceHealthFn if it is set.
+func (f *FakeClient) GetBackendServiceHealth(project string, backendService string, group string) (*compute.BackendServiceGroupHealth, error) {
+	f.record("GetBackendServiceHealth", project, backendService, group)
+	if f.GetBackendServiceHealthFn != nil {
+		return f.GetBackendServiceHealthFn(project, backendService, group)
+	}
+	var r0 *compute.BackendServiceGroupHealth
+	return r0, f.err("GetBackendServiceHealth")
+}
+
+// GetHealthCheck records the call and calls GetHealthCheckFn if it is set.
+func (f *FakeClient) GetHealthCheck(project string, name string) (*compute.HealthCheck, error) {
+	f.record("GetHealthCheck", project, name)
+	if f.GetHealthCheckFn != nil {
+		return f.GetHealthCheckFn(project, name)
+	}
+	var r0 *compute.HealthCheck
+	return r0, f.err("GetHealthCheck")
+}
+
+// CreateHTTPHealthCheck records the call and calls CreateHTTPHealthCheckFn if it is set.
+func (f *FakeClient) CreateHTTPHealthCheck(project string, hc *compute.HttpHealthCheck) error {
+	f.record("CreateHTTPHealthCheck", project, hc)
+	if f.CreateHTTPHealthCheckFn != nil {
+		return f.CreateHTTPHealthCheckFn(project, hc)
+	}
+	return f.err("CreateHTTPHealthCheck")
+}
+
+// GetHTTPHealthCheck records the call and calls GetHTTPHealthCheckFn if it is set.
+func (f *FakeClient) GetHTTPHealthCheck(project string, name string) (*compute.HttpHealthCheck, error) {
+	f.record("GetHTTPHealthCheck", project, name)
+	if f.GetHTTPHealthCheckFn != nil {
+		return f.GetHTTPHealthCheckFn(project, name)
+	}
+	var r0 *compute.HttpHealthCheck
+	return r0, f.err("GetHTTPHealthCheck")
+}
+
+// DeleteHTTPHealthCheck records the call and calls DeleteHTTPHealthCheckFn if it is set.
+func (f *FakeClient) DeleteHTTPHealthCheck(project string, name string) error {
+	f.record("DeleteHTTPHealthCheck", project, name)
+	if f.DeleteHTTPHealthCheckFn != nil {
+		return f.DeleteHTTPHealthCheckFn(project, name)
+	}
+	return f.err("DeleteHTTPHealthCheck")
+}
+
+// CreateHTTPSHealthCheck records the call and calls CreateHTTPSHealthCheckFn if it is set.
+func (f *FakeClient) CreateHTTPSHealthCheck(project string, hc *compute.HttpsHealthCheck) error {
+	f.record("CreateHTTPSHealthCheck", project, hc)
+	if f.CreateHTTPSHealthCheckFn != nil {
+		return f.CreateHTTPSHealthCheckFn(project, hc)
+	}
+	return f.err("CreateHTTPSHealthCheck")
+}
+
+// GetHTTPSHealthCheck records the call and calls GetHTTPSHealthCheckFn if it is set.
+func (f *FakeClient) GetHTTPSHealthCheck(project string, name string) (*compute.HttpsHealthCheck, error) {
+	f.record("GetHTTPSHealthCheck", project, name)
+	if f.GetHTTPSHealthCheckFn != nil {
+		return f.GetHTTPSHealthCheckFn(project, name)
+	}
+	var r0 *compute.HttpsHealthCheck
+	return r0, f.err("GetHTTPSHealthCheck")
+}
+
+// DeleteHTTPSHealthCheck records the call and calls DeleteHTTPSHealthCheckFn if it is set.
+func (f *FakeClient) DeleteHTTPSHealthCheck(project string, name string) error {
+	f.record("DeleteHTTPSHealthCheck", project, name)
+	if f.DeleteHTTPSHealthCheckFn != nil {
+		return f.DeleteHTTPSHealthCheckFn(project, name)
+	}
+	return f.err("DeleteHTTPSHealthCheck")
+}
+
+// CreateSecurityPolicy records the call and calls CreateSecurityPolicyFn if it is set.
+func (f *FakeClient) CreateSecurityPolicy(project string, sp *compute.SecurityPolicy) error {
+	f.record("CreateSecurityPolicy", project, sp)
+	if f.CreateSecurityPolicyFn != nil {
+		return f.CreateSecurityPolicyFn(project, sp)
+	}
+	return f.err("CreateSecurityPolicy")
+}
+
+// GetSecurityPolicy records the call and calls GetSecurityPolicyFn if it is set.
+func (f *FakeClient) GetSecurityPolicy(project string, name string) (*compute.SecurityPolicy, error) {
+	f.record("GetSecurityPolicy", project, name)
+	if f.GetSecurityPolicyFn != nil {
+		return f.GetSecurityPolicyFn(project, name)
+	}
+	var r0 *compute.SecurityPolicy
+	return r0, f.err("GetSecurityPolicy")
+}
+
+// DeleteSecurityPolicy records the call and calls DeleteSecurityPolicyFn if it is set.
+func (f *FakeClient) DeleteSecurityPolicy(project string, name string) error {
+	f.record("DeleteSecurityPolicy", project, name)
+	if f.DeleteSecurityPolicyFn != nil {
+		return f.DeleteSecurityPolicyFn(project, name)
+	}
+	return f.err("DeleteSecurityPolicy")
+}
+
+// AddSecurityPolicyRule records the call and calls AddSecurityPolicyRuleFn if it is set.
+func (f *FakeClient) AddSecurityPolicyRule(project string, securityPolicy string, r *compute.SecurityPolicyRule) error {
+	f.record("AddSecurityPolicyRule", project, securityPolicy, r)
+	if f.AddSecurityPolicyRuleFn != nil {
+		return f.AddSecurityPolicyRuleFn(project, securityPolicy, r)
+	}
+	return f.err("AddSecurityPolicyRule")
+}
+
+// PatchSecurityPolicyRule records the call and calls PatchSecurityPolicyRuleFn if it is set.
+func (f *FakeClient) PatchSecurityPolicyRule(project string, securityPolicy string, priority int64, r *compute.SecurityPolicyRule) error {
+	f.record("PatchSecurityPolicyRule", project, securityPolicy, priority, r)
+	if f.PatchSecurityPolicyRuleFn != nil {
+		return f.PatchSecurityPolicyRuleFn(project, securityPolicy, priority, r)
+	}
+	return f.err("PatchSecurityPolicyRule")
+}
+
+// GetFirewallPolicy records the call and calls GetFirewallPolicyFn if it is set.
+func (f *FakeClient) GetFirewallPolicy(policy string) (*compute.FirewallPolicy, error) {
+	f.record("GetFirewallPolicy", policy)
+	if f.GetFirewallPolicyFn != nil {
+		return f.GetFirewallPolicyFn(policy)
+	}
+	var r0 *compute.FirewallPolicy
+	return r0, f.err("GetFirewallPolicy")
+}
+
+// AddFirewallPolicyAssociation records the call and calls AddFirewallPolicyAssociationFn if it is set.
+func (f *FakeClient) AddFirewallPolicyAssociation(policy string, assoc *compute.FirewallPolicyAssociation) error {
+	f.record("AddFirewallPolicyAssociation", policy, assoc)
+	if f.AddFirewallPolicyAssociationFn != nil {
+		return f.AddFirewallPolicyAssociationFn(policy, assoc)
+	}
+	return f.err("AddFirewallPolicyAssociation")
+}
+
+// AddFirewallPolicyRule records the call and calls AddFirewallPolicyRuleFn if it is set.
+func (f *FakeClient) AddFirewallPolicyRule(policy string, rule *compute.FirewallPolicyRule) error {
+	f.record("AddFirewallPolicyRule", policy, rule)
+	if f.AddFirewallPolicyRuleFn != nil {
+		return f.AddFirewallPolicyRuleFn(policy, rule)
+	}
+	return f.err("AddFirewallPolicyRule")
+}
+
+// CreatePacketMirroring records the call and calls CreatePacketMirroringFn if it is set.
+func (f *FakeClient) CreatePacketMirroring(project string, region string, pm *compute.PacketMirroring) error {
+	f.record("CreatePacketMirroring", project, region, pm)
+	if f.CreatePacketMirroringFn != nil {
+		return f.CreatePacketMirroringFn(project, region, pm)
+	}
+	return f.err("CreatePacketMirroring")
+}
+
+// GetPacketMirroring records the call and calls GetPacketMirroringFn if it is set.
+func (f *FakeClient) GetPacketMirroring(project string, region string, name string) (*compute.PacketMirroring, error) {
+	f.record("GetPacketMirroring", project, region, name)
+	if f.GetPacketMirroringFn != nil {
+		return f.GetPacketMirroringFn(project, region, name)
+	}
+	var r0 *compute.PacketMirroring
+	return r0, f.err("GetPacketMirroring")
+}
+
+// DeletePacketMirroring records the call and calls DeletePacketMirroringFn if it is set.
+func (f *FakeClient) DeletePacketMirroring(project string, region string, name string) error {
+	f.record("DeletePacketMirroring", project, region, name)
+	if f.DeletePacketMirroringFn != nil {
+		return f.DeletePacketMirroringFn(project, region, name)
+	}
+	return f.err("DeletePacketMirroring")
+}
+
+// GetURLMap records the call and calls GetURLMapFn if it is set.
+func (f *FakeClient) GetURLMap(project string, name string) (*compute.UrlMap, error) {
+	f.record("GetURLMap", project, name)
+	if f.GetURLMapFn != nil {
+		return f.GetURLMapFn(project, name)
+	}
+	var r0 *compute.UrlMap
+	return r0, f.err("GetURLMap")
+}
+
+// GetTargetHTTPProxy records the call and calls GetTargetHTTPProxyFn if it is set.
+func (f *FakeClient) GetTargetHTTPProxy(project string, name string) (*compute.TargetHttpProxy, error) {
+	f.record("GetTargetHTTPProxy", project, name)
+	if f.GetTargetHTTPProxyFn != nil {
+		return f.GetTargetHTTPProxyFn(project, name)
+	}
+	var r0 *compute.TargetHttpProxy
+	return r0, f.err("GetTargetHTTPProxy")
+}
+
+// GetNetworkEndpointGroup records the call and calls GetNetworkEndpointGroupFn if it is set.
+func (f *FakeClient) GetNetworkEndpointGroup(project string, zone string, name string) (*compute.NetworkEndpointGroup, error) {
+	f.record("GetNetworkEndpointGroup", project, zone, name)
+	if f.GetNetworkEndpointGroupFn != nil {
+		return f.GetNetworkEndpointGroupFn(project, zone, name)
+	}
+	var r0 *compute.NetworkEndpointGroup
+	return r0, f.err("GetNetworkEndpointGroup")
+}
+
+// InstanceStatus records the call and calls InstanceStatusFn if it is set.
+func (f *FakeClient) InstanceStatus(project string, zone string, name string) (string, error) {
+	f.record("InstanceStatus", project, zone, name)
+	if f.InstanceStatusFn != nil {
+		return f.InstanceStatusFn(project, zone, name)
+	}
+	var r0 string
+	return r0, f.err("InstanceStatus")
+}
+
+// InstanceStopped records the call and calls InstanceStoppedFn if it is set.
+func (f *FakeClient) InstanceStopped(project string, zone string, name string) (bool, error) {
+	f.record("InstanceStopped", project, zone, name)
+	if f.InstanceStoppedFn != nil {
+		return f.InstanceStoppedFn(project, zone, name)
+	}
+	var r0 bool
+	return r0, f.err("InstanceStopped")
+}
+
+// WaitForInstanceRunning records the call and calls WaitForInstanceRunningFn if it is set.
+func (f *FakeClient) WaitForInstanceRunning(project string, zone string, name string, timeout time.Duration) error {
+	f.record("WaitForInstanceRunning", project, zone, name, timeout)
+	if f.WaitForInstanceRunningFn != nil {
+		return f.WaitForInstanceRunningFn(project, zone, name, timeout)
+	}
+	return f.err("WaitForInstanceRunning")
+}
+
+// WaitForInstanceStopped records the call and calls WaitForInstanceStoppedFn if it is set.
+func (f *FakeClient) WaitForInstanceStopped(project string, zone string, name string, timeout time.Duration) error {
+	f.record("WaitForInstanceStopped", project, zone, name, timeout)
+	if f.WaitForInstanceStoppedFn != nil {
+		return f.WaitForInstanceStoppedFn(project, zone, name, timeout)
+	}
+	return f.err("WaitForInstanceStopped")
+}
+
+// WaitForGuestAttribute records the call and calls WaitForGuestAttributeFn if it is set.
+func (f *FakeClient) WaitForGuestAttribute(project string, zone string, instance string, namespace string, key string, wantValue string, timeout time.Duration) error {
+	f.record("WaitForGuestAttribute", project, zone, instance, namespace, key, wantValue, timeout)
+	if f.WaitForGuestAttributeFn != nil {
+		return f.WaitForGuestAttributeFn(project, zone, instance, namespace, key, wantValue, timeout)
+	}
+	return f.err("WaitForGuestAttribute")
+}
+
+// WaitForGuestAttributeContext records the call and calls WaitForGuestAttributeContextFn if it is set.
+func (f *FakeClient) WaitForGuestAttributeContext(ctx context.Context, project string, zone string, instance string, namespace string, key string, wantValue string) error {
+	f.record("WaitForGuestAttributeContext", ctx, project, zone, instance, namespace, key, wantValue)
+	if f.WaitForGuestAttributeContextFn != nil {
+		return f.WaitForGuestAttributeContextFn(ctx, project, zone, instance, namespace, key, wantValue)
+	}
+	return f.err("WaitForGuestAttributeContext")
+}
+
+// WaitForOperationWithProgress records the call and calls WaitForOperationWithProgressFn if it is set.
+func (f *FakeClient) WaitForOperationWithProgress(project string, selfLink string, onProgress func(percent int)) error {
+	f.record("WaitForOperationWithProgress", project, selfLink, onProgress)
+	if f.WaitForOperationWithProgressFn != nil {
+		return f.WaitForOperationWithProgressFn(project, selfLink, onProgress)
+	}
+	return f.err("WaitForOperationWithProgress")
+}
+
+// WaitForOperationWithConfig records the call and calls WaitForOperationWithConfigFn if it is set.
+func (f *FakeClient) WaitForOperationWithConfig(project string, selfLink string, wc daisyCompute.WaitConfig) error {
+	f.record("WaitForOperationWithConfig", project, selfLink, wc)
+	if f.WaitForOperationWithConfigFn != nil {
+		return f.WaitForOperationWithConfigFn(project, selfLink, wc)
+	}
+	return f.err("WaitForOperationWithConfig")
+}
+
+// DeleteOperation records the call and calls DeleteOperationFn if it is set.
+func (f *FakeClient) DeleteOperation(project string, selfLink string) error {
+	f.record("DeleteOperation", project, selfLink)
+	if f.DeleteOperationFn != nil {
+		return f.DeleteOperationFn(project, selfLink)
+	}
+	return f.err("DeleteOperation")
+}
+
+// ListZoneOperations records the call and calls ListZoneOperationsFn if it is set.
+func (f *FakeClient) ListZoneOperations(project string, zone string, opts ...daisyCompute.ListCallOption) ([]*compute.Operation, error) {
+	f.record("ListZoneOperations", project, zone, opts)
+	if f.ListZoneOperationsFn != nil {
+		return f.ListZoneOperationsFn(project, zone, opts...)
+	}
+	var r0 []*compute.Operation
+	return r0, f.err("ListZoneOperations")
+}
+
+// ListRegionOperations records the call and calls ListRegionOperationsFn if it is set.
+func (f *FakeClient) ListRegionOperations(project string, region string, opts ...daisyCompute.ListCallOption) ([]*compute.Operation, error) {
+	f.record("ListRegionOperations", project, region, opts)
+	if f.ListRegionOperationsFn != nil {
+		return f.ListRegionOperationsFn(project, region, opts...)
+	}
+	var r0 []*compute.Operation
+	return r0, f.err("ListRegionOperations")
+}
+
+// ListGlobalOperations records the call and calls ListGlobalOperationsFn if it is set.
+func (f *FakeClient) ListGlobalOperations(project string, opts ...daisyCompute.ListCallOption) ([]*compute.Operation, error) {
+	f.record("ListGlobalOperations", project, opts)
+	if f.ListGlobalOperationsFn != nil {
+		return f.ListGlobalOperationsFn(project, opts...)
+	}
+	var r0 []*compute.Operation
+	return r0, f.err("ListGlobalOperations")
+}
+
+// GetOperationsForTarget records the call and calls GetOperationsForTargetFn if it is set.
+func (f *FakeClient) GetOperationsForTarget(project string, scope string, targetSelfLink string) ([]*compute.Operation, error) {
+	f.record("GetOperationsForTarget", project, scope, targetSelfLink)
+	if f.GetOperationsForTargetFn != nil {
+		return f.GetOperationsForTargetFn(project, scope, targetSelfLink)
+	}
+	var r0 []*compute.Operation
+	return r0, f.err("GetOperationsForTarget")
+}
+
+// ListMachineTypes records the call and calls ListMachineTypesFn if it is set.
+func (f *FakeClient) ListMachineTypes(project string, zone string, opts ...daisyCompute.ListCallOption) ([]*compute.MachineType, error) {
+	f.record("ListMachineTypes", project, zone, opts)
+	if f.ListMachineTypesFn != nil {
+		return f.ListMachineTypesFn(project, zone, opts...)
+	}
+	var r0 []*compute.MachineType
+	return r0, f.err("ListMachineTypes")
+}
+
+// ListAcceleratorTypes records the call and calls ListAcceleratorTypesFn if it is set.
+func (f *FakeClient) ListAcceleratorTypes(project string, zone string, opts ...daisyCompute.ListCallOption) ([]*compute.AcceleratorType, error) {
+	f.record("ListAcceleratorTypes", project, zone, opts)
+	if f.ListAcceleratorTypesFn != nil {
+		return f.ListAcceleratorTypesFn(project, zone, opts...)
+	}
+	var r0 []*compute.AcceleratorType
+	return r0, f.err("ListAcceleratorTypes")
+}
+
+// ListLicenses records the call and calls ListLicensesFn if it is set.
+func (f *FakeClient) ListLicenses(project string, opts ...daisyCompute.ListCallOption) ([]*compute.License, error) {
+	f.record("ListLicenses", project, opts)
+	if f.ListLicensesFn != nil {
+		return f.ListLicensesFn(project, opts...)
+	}
+	var r0 []*compute.License
+	return r0, f.err("ListLicenses")
+}
+
+// ListZones records the call and calls ListZonesFn if it is set.
+func (f *FakeClient) ListZones(project string, opts ...daisyCompute.ListCallOption) ([]*compute.Zone, error) {
+	f.record("ListZones", project, opts)
+	if f.ListZonesFn != nil {
+		return f.ListZonesFn(project, opts...)
+	}
+	var r0 []*compute.Zone
+	return r0, f.err("ListZones")
+}
+
+// PickZone records the call and calls PickZoneFn if it is set.
+func (f *FakeClient) PickZone(project string, region string, machineType string) (string, error) {
+	f.record("PickZone", project, region, machineType)
+	if f.PickZoneFn != nil {
+		return f.PickZoneFn(project, region, machineType)
+	}
+	var r0 string
+	return r0, f.err("PickZone")
+}
+
+// ListRegions records the call and calls ListRegionsFn if it is set.
+func (f *FakeClient) ListRegions(project string, opts ...daisyCompute.ListCallOption) ([]*compute.Region, error) {
+	f.record("ListRegions", project, opts)
+	if f.ListRegionsFn != nil {
+		return f.ListRegionsFn(project, opts...)
+	}
+	var r0 []*compute.Region
+	return r0, f.err("ListRegions")
+}
+
+// AggregatedListInstances records the call and calls AggregatedListInstancesFn if it is set.
+func (f *FakeClient) AggregatedListInstances(project string, opts ...daisyCompute.ListCallOption) ([]*compute.Instance, error) {
+	f.record("AggregatedListInstances", project, opts)
+	if f.AggregatedListInstancesFn != nil {
+		return f.AggregatedListInstancesFn(project, opts...)
+	}
+	var r0 []*compute.Instance
+	return r0, f.err("AggregatedListInstances")
+}
+
+// AggregatedListInstancesByZone records the call and calls AggregatedListInstancesByZoneFn if it is set.
+func (f *FakeClient) AggregatedListInstancesByZone(project string, opts ...daisyCompute.ListCallOption) (map[string][]*compute.Instance, error) {
+	f.record("AggregatedListInstancesByZone", project, opts)
+	if f.AggregatedListInstancesByZoneFn != nil {
+		return f.AggregatedListInstancesByZoneFn(project, opts...)
+	}
+	var r0 map[string][]*compute.Instance
+	return r0, f.err("AggregatedListInstancesByZone")
+}
+
+// ListInstances records the call and calls ListInstancesFn if it is set.
+func (f *FakeClient) ListInstances(project string, zone string, opts ...daisyCompute.ListCallOption) ([]*compute.Instance, error) {
+	f.record("ListInstances", project, zone, opts)
+	if f.ListInstancesFn != nil {
+		return f.ListInstancesFn(project, zone, opts...)
+	}
+	var r0 []*compute.Instance
+	return r0, f.err("ListInstances")
+}
+
+// ListInstancesByStatus records the call and calls ListInstancesByStatusFn if it is set.
+func (f *FakeClient) ListInstancesByStatus(project string, zone string, status string) ([]*compute.Instance, error) {
+	f.record("ListInstancesByStatus", project, zone, status)
+	if f.ListInstancesByStatusFn != nil {
+		return f.ListInstancesByStatusFn(project, zone, status)
+	}
+	var r0 []*compute.Instance
+	return r0, f.err("ListInstancesByStatus")
+}
+
+// GetInstanceReferrers records the call and calls GetInstanceReferrersFn if it is set.
+func (f *FakeClient) GetInstanceReferrers(project string, zone string, instance string) ([]*compute.Reference, error) {
+	f.record("GetInstanceReferrers", project, zone, instance)
+	if f.GetInstanceReferrersFn != nil {
+		return f.GetInstanceReferrersFn(project, zone, instance)
+	}
+	var r0 []*compute.Reference
+	return r0, f.err("GetInstanceReferrers")
+}
+
+// ListDiskUsers records the call and calls ListDiskUsersFn if it is set.
+func (f *FakeClient) ListDiskUsers(project string, zone string, disk string) ([]*compute.Instance, error) {
+	f.record("ListDiskUsers", project, zone, disk)
+	if f.ListDiskUsersFn != nil {
+		return f.ListDiskUsersFn(project, zone, disk)
+	}
+	var r0 []*compute.Instance
+	return r0, f.err("ListDiskUsers")
+}
+
+// ListAttachedAccelerators records the call and calls ListAttachedAcceleratorsFn if it is set.
+func (f *FakeClient) ListAttachedAccelerators(project string, zone string) (map[string][]*compute.AcceleratorConfig, error) {
+	f.record("ListAttachedAccelerators", project, zone)
+	if f.ListAttachedAcceleratorsFn != nil {
+		return f.ListAttachedAcceleratorsFn(project, zone)
+	}
+	var r0 map[string][]*compute.AcceleratorConfig
+	return r0, f.err("ListAttachedAccelerators")
+}
+
+// AggregatedListDisks records the call and calls AggregatedListDisksFn if it is set.
+func (f *FakeClient) AggregatedListDisks(project string, opts ...daisyCompute.ListCallOption) ([]*compute.Disk, error) {
+	f.record("AggregatedListDisks", project, opts)
+	if f.AggregatedListDisksFn != nil {
+		return f.AggregatedListDisksFn(project, opts...)
+	}
+	var r0 []*compute.Disk
+	return r0, f.err("AggregatedListDisks")
+}
+
+// AggregatedListDisksByZone records the call and calls AggregatedListDisksByZoneFn if it is set.
+func (f *FakeClient) AggregatedListDisksByZone(project string, opts ...daisyCompute.ListCallOption) (map[string][]*compute.Disk, error) {
+	f.record("AggregatedListDisksByZone", project, opts)
+	if f.AggregatedListDisksByZoneFn != nil {
+		return f.AggregatedListDisksByZoneFn(project, opts...)
+	}
+	var r0 map[string][]*compute.Disk
+	return r0, f.err("AggregatedListDisksByZone")
+}
+
+// ListDisks records the call and calls ListDisksFn if it is set.
+func (f *FakeClient) ListDisks(project string, zone string, opts ...daisyCompute.ListCallOption) ([]*compute.Disk, error) {
+	f.record("ListDisks", project, zone, opts)
+	if f.ListDisksFn != nil {
+		return f.ListDisksFn(project, zone, opts...)
+	}
+	var r0 []*compute.Disk
+	return r0, f.err("ListDisks")
+}
+
+// ListUnattachedDisks records the call and calls ListUnattachedDisksFn if it is set.
+func (f *FakeClient) ListUnattachedDisks(project string, zone string) ([]*compute.Disk, error) {
+	f.record("ListUnattachedDisks", project, zone)
+	if f.ListUnattachedDisksFn != nil {
+		return f.ListUnattachedDisksFn(project, zone)
+	}
+	var r0 []*compute.Disk
+	return r0, f.err("ListUnattachedDisks")
+}
+
+// DeleteUnattachedDisks records the call and calls DeleteUnattachedDisksFn if it is set.
+func (f *FakeClient) DeleteUnattachedDisks(project string, zone string) error {
+	f.record("DeleteUnattachedDisks", project, zone)
+	if f.DeleteUnattachedDisksFn != nil {
+		return f.DeleteUnattachedDisksFn(project, zone)
+	}
+	return f.err("DeleteUnattachedDisks")
+}
+
+// AggregatedListForwardingRules records the call and calls AggregatedListForwardingRulesFn if it is set.
+func (f *FakeClient) AggregatedListForwardingRules(project string, opts ...daisyCompute.ListCallOption) ([]*compute.ForwardingRule, error) {
+	f.record("AggregatedListForwardingRules", project, opts)
+	if f.AggregatedListForwardingRulesFn != nil {
+		return f.AggregatedListForwardingRulesFn(project, opts...)
+	}
+	var r0 []*compute.ForwardingRule
+	return r0, f.err("AggregatedListForwardingRules")
+}
+
+// ListForwardingRules records the call and calls ListForwardingRulesFn if it is set.
+func (f *FakeClient) ListForwardingRules(project string, zone string, opts ...daisyCompute.ListCallOption) ([]*compute.ForwardingRule, error) {
+	f.record("ListForwardingRules", project, zone, opts)
+	if f.ListForwardingRulesFn != nil {
+		return f.ListForwardingRulesFn(project, zone, opts...)
+	}
+	var r0 []*compute.ForwardingRule
+	return r0, f.err("ListForwardingRules")
+}
+
+// ListFirewallRules records the call and calls ListFirewallRulesFn if it is set.
+func (f *FakeClient) ListFirewallRules(project string, opts ...daisyCompute.ListCallOption) ([]*compute.Firewall, error) {
+	f.record("ListFirewallRules", project, opts)
+	if f.ListFirewallRulesFn != nil {
+		return f.ListFirewallRulesFn(project, opts...)
+	}
+	var r0 []*compute.Firewall
+	return r0, f.err("ListFirewallRules")
+}
+
+// ListImages records the call and calls ListImagesFn if it is set.
+func (f *FakeClient) ListImages(project string, opts ...daisyCompute.ListCallOption) ([]*compute.Image, error) {
+	f.record("ListImages", project, opts)
+	if f.ListImagesFn != nil {
+		return f.ListImagesFn(project, opts...)
+	}
+	var r0 []*compute.Image
+	return r0, f.err("ListImages")
+}
+
+// ListImagesAlpha records the call and calls ListImagesAlphaFn if it is set.
+func (f *FakeClient) ListImagesAlpha(project string, opts ...daisyCompute.ListCallOption) ([]*computeAlpha.Image, error) {
+	f.record("ListImagesAlpha", project, opts)
+	if f.ListImagesAlphaFn != nil {
+		return f.ListImagesAlphaFn(project, opts...)
+	}
+	var r0 []*computeAlpha.Image
+	return r0, f.err("ListImagesAlpha")
+}
+
+// GetSnapshot records the call and calls GetSnapshotFn if it is set.
+func (f *FakeClient) GetSnapshot(project string, name string) (*compute.Snapshot, error) {
+	f.record("GetSnapshot", project, name)
+	if f.GetSnapshotFn != nil {
+		return f.GetSnapshotFn(project, name)
+	}
+	var r0 *compute.Snapshot
+	return r0, f.err("GetSnapshot")
+}
+
+// SetSnapshotLabels records the call and calls SetSnapshotLabelsFn if it is set.
+func (f *FakeClient) SetSnapshotLabels(project string, name string, labels map[string]string, fingerprint string) error {
+	f.record("SetSnapshotLabels", project, name, labels, fingerprint)
+	if f.SetSnapshotLabelsFn != nil {
+		return f.SetSnapshotLabelsFn(project, name, labels, fingerprint)
+	}
+	return f.err("SetSnapshotLabels")
+}
+
+// ListSnapshots records the call and calls ListSnapshotsFn if it is set.
+func (f *FakeClient) ListSnapshots(project string, opts ...daisyCompute.ListCallOption) ([]*compute.Snapshot, error) {
+	f.record("ListSnapshots", project, opts)
+	if f.ListSnapshotsFn != nil {
+		return f.ListSnapshotsFn(project, opts...)
+	}
+	var r0 []*compute.Snapshot
+	return r0, f.err("ListSnapshots")
+}
+
+// DeleteSnapshot records the call and calls DeleteSnapshotFn if it is set.
+func (f *FakeClient) DeleteSnapshot(project string, name string) error {
+	f.record("DeleteSnapshot", project, name)
+	if f.DeleteSnapshotFn != nil {
+		return f.DeleteSnapshotFn(project, name)
+	}
+	return f.err("DeleteSnapshot")
+}
+
+// ListNetworks records the call and calls ListNetworksFn if it is set.
+func (f *FakeClient) ListNetworks(project string, opts ...daisyCompute.ListCallOption) ([]*compute.Network, error) {
+	f.record("ListNetworks", project, opts)
+	if f.ListNetworksFn != nil {
+		return f.ListNetworksFn(project, opts...)
+	}
+	var r0 []*compute.Network
+	return r0, f.err("ListNetworks")
+}
+
+// AggregatedListSubnetworks records the call and calls AggregatedListSubnetworksFn if it is set.
+func (f *FakeClient) AggregatedListSubnetworks(project string, opts ...daisyCompute.ListCallOption) ([]*compute.Subnetwork, error) {
+	f.record("AggregatedListSubnetworks", project, opts)
+	if f.AggregatedListSubnetworksFn != nil {
+		return f.AggregatedListSubnetworksFn(project, opts...)
+	}
+	var r0 []*compute.Subnetwork
+	return r0, f.err("AggregatedListSubnetworks")
+}
+
+// ListSubnetworks records the call and calls ListSubnetworksFn if it is set.
+func (f *FakeClient) ListSubnetworks(project string, region string, opts ...daisyCompute.ListCallOption) ([]*compute.Subnetwork, error) {
+	f.record("ListSubnetworks", project, region, opts)
+	if f.ListSubnetworksFn != nil {
+		return f.ListSubnetworksFn(project, region, opts...)
+	}
+	var r0 []*compute.Subnetwork
+	return r0, f.err("ListSubnetworks")
+}
+
+// ListTargetInstances records the call and calls ListTargetInstancesFn if it is set.
+func (f *FakeClient) ListTargetInstances(project string, zone string, opts ...daisyCompute.ListCallOption) ([]*compute.TargetInstance, error) {
+	f.record("ListTargetInstances", project, zone, opts)
+	if f.ListTargetInstancesFn != nil {
+		return f.ListTargetInstancesFn(project, zone, opts...)
+	}
+	var r0 []*compute.TargetInstance
+	return r0, f.err("ListTargetInstances")
+}
+
+// ResizeDisk records the call and calls ResizeDiskFn if it is set.
+func (f *FakeClient) ResizeDisk(project string, zone string, disk string, drr *compute.DisksResizeRequest) error {
+	f.record("ResizeDisk", project, zone, disk, drr)
+	if f.ResizeDiskFn != nil {
+		return f.ResizeDiskFn(project, zone, disk, drr)
+	}
+	return f.err("ResizeDisk")
+}
+
+// SetInstanceMetadata records the call and calls SetInstanceMetadataFn if it is set.
+func (f *FakeClient) SetInstanceMetadata(project string, zone string, name string, md *compute.Metadata) error {
+	f.record("SetInstanceMetadata", project, zone, name, md)
+	if f.SetInstanceMetadataFn != nil {
+		return f.SetInstanceMetadataFn(project, zone, name, md)
+	}
+	return f.err("SetInstanceMetadata")
+}
+
+// ResetWindowsPassword records the call and calls ResetWindowsPasswordFn if it is set.
+func (f *FakeClient) ResetWindowsPassword(project string, zone string, instance string, username string) (string, error) {
+	f.record("ResetWindowsPassword", project, zone, instance, username)
+	if f.ResetWindowsPasswordFn != nil {
+		return f.ResetWindowsPasswordFn(project, zone, instance, username)
+	}
+	var r0 string
+	return r0, f.err("ResetWindowsPassword")
+}
+
+// SetCommonInstanceMetadata records the call and calls SetCommonInstanceMetadataFn if it is set.
+func (f *FakeClient) SetCommonInstanceMetadata(project string, md *compute.Metadata) error {
+	f.record("SetCommonInstanceMetadata", project, md)
+	if f.SetCommonInstanceMetadataFn != nil {
+		return f.SetCommonInstanceMetadataFn(project, md)
+	}
+	return f.err("SetCommonInstanceMetadata")
+}
+
+// SetProjectMetadataItem records the call and calls SetProjectMetadataItemFn if it is set.
+func (f *FakeClient) SetProjectMetadataItem(project string, key string, value string) error {
+	f.record("SetProjectMetadataItem", project, key, value)
+	if f.SetProjectMetadataItemFn != nil {
+		return f.SetProjectMetadataItemFn(project, key, value)
+	}
+	return f.err("SetProjectMetadataItem")
+}
+
+// SetUsageExportBucket records the call and calls SetUsageExportBucketFn if it is set.
+func (f *FakeClient) SetUsageExportBucket(project string, cfg *compute.UsageExportLocation) error {
+	f.record("SetUsageExportBucket", project, cfg)
+	if f.SetUsageExportBucketFn != nil {
+		return f.SetUsageExportBucketFn(project, cfg)
+	}
+	return f.err("SetUsageExportBucket")
+}
+
+// GetXpnHost records the call and calls GetXpnHostFn if it is set.
+func (f *FakeClient) GetXpnHost(project string) (*compute.Project, error) {
+	f.record("GetXpnHost", project)
+	if f.GetXpnHostFn != nil {
+		return f.GetXpnHostFn(project)
+	}
+	var r0 *compute.Project
+	return r0, f.err("GetXpnHost")
+}
+
+// ListXpnHosts records the call and calls ListXpnHostsFn if it is set.
+func (f *FakeClient) ListXpnHosts(project string, organization string) ([]*compute.Project, error) {
+	f.record("ListXpnHosts", project, organization)
+	if f.ListXpnHostsFn != nil {
+		return f.ListXpnHostsFn(project, organization)
+	}
+	var r0 []*compute.Project
+	return r0, f.err("ListXpnHosts")
+}
+
+// EnableXpnResource records the call and calls EnableXpnResourceFn if it is set.
+func (f *FakeClient) EnableXpnResource(project string, req *compute.ProjectsEnableXpnResourceRequest) error {
+	f.record("EnableXpnResource", project, req)
+	if f.EnableXpnResourceFn != nil {
+		return f.EnableXpnResourceFn(project, req)
+	}
+	return f.err("EnableXpnResource")
+}
+
+// DisableXpnResource records the call and calls DisableXpnResourceFn if it is set.
+func (f *FakeClient) DisableXpnResource(project string, req *compute.ProjectsDisableXpnResourceRequest) error {
+	f.record("DisableXpnResource", project, req)
+	if f.DisableXpnResourceFn != nil {
+		return f.DisableXpnResourceFn(project, req)
+	}
+	return f.err("DisableXpnResource")
+}
+
+// SetDiskAutoDelete records the call and calls SetDiskAutoDeleteFn if it is set.
+func (f *FakeClient) SetDiskAutoDelete(project string, zone string, instance string, autoDelete bool, deviceName string) error {
+	f.record("SetDiskAutoDelete", project, zone, instance, autoDelete, deviceName)
+	if f.SetDiskAutoDeleteFn != nil {
+		return f.SetDiskAutoDeleteFn(project, zone, instance, autoDelete, deviceName)
+	}
+	return f.err("SetDiskAutoDelete")
+}
+
+// ListMachineImages records the call and calls ListMachineImagesFn if it is set.
+func (f *FakeClient) ListMachineImages(project string, opts ...daisyCompute.ListCallOption) ([]*compute.MachineImage, error) {
+	f.record("ListMachineImages", project, opts)
+	if f.ListMachineImagesFn != nil {
+		return f.ListMachineImagesFn(project, opts...)
+	}
+	var r0 []*compute.MachineImage
+	return r0, f.err("ListMachineImages")
+}
+
+// DeleteMachineImage records the call and calls DeleteMachineImageFn if it is set.
+func (f *FakeClient) DeleteMachineImage(project string, name string) error {
+	f.record("DeleteMachineImage", project, name)
+	if f.DeleteMachineImageFn != nil {
+		return f.DeleteMachineImageFn(project, name)
+	}
+	return f.err("DeleteMachineImage")
+}
+
+// CreateMachineImage records the call and calls CreateMachineImageFn if it is set.
+func (f *FakeClient) CreateMachineImage(project string, i *compute.MachineImage) error {
+	f.record("CreateMachineImage", project, i)
+	if f.CreateMachineImageFn != nil {
+		return f.CreateMachineImageFn(project, i)
+	}
+	return f.err("CreateMachineImage")
+}
+
+// GetMachineImage records the call and calls GetMachineImageFn if it is set.
+func (f *FakeClient) GetMachineImage(project string, name string) (*compute.MachineImage, error) {
+	f.record("GetMachineImage", project, name)
+	if f.GetMachineImageFn != nil {
+		return f.GetMachineImageFn(project, name)
+	}
+	var r0 *compute.MachineImage
+	return r0, f.err("GetMachineImage")
+}
+
+// Suspend records the call and calls SuspendFn if it is set.
+func (f *FakeClient) Suspend(project string, zone string, instance string) error {
+	f.record("Suspend", project, zone, instance)
+	if f.SuspendFn != nil {
+		return f.SuspendFn(project, zone, instance)
+	}
+	return f.err("Suspend")
+}
+
+// Resume records the call and calls ResumeFn if it is set.
+func (f *FakeClient) Resume(project string, zone string, instance string) error {
+	f.record("Resume", project, zone, instance)
+	if f.ResumeFn != nil {
+		return f.ResumeFn(project, zone, instance)
+	}
+	return f.err("Resume")
+}
+
+// ResumeWithEncryptionKey records the call and calls ResumeWithEncryptionKeyFn if it is set.
+func (f *FakeClient) ResumeWithEncryptionKey(project string, zone string, instance string, req *computeBeta.InstancesResumeRequest) error {
+	f.record("ResumeWithEncryptionKey", project, zone, instance, req)
+	if f.ResumeWithEncryptionKeyFn != nil {
+		return f.ResumeWithEncryptionKeyFn(project, zone, instance, req)
+	}
+	return f.err("ResumeWithEncryptionKey")
+}
+
+// PerformMaintenance records the call and calls PerformMaintenanceFn if it is set.
+func (f *FakeClient) PerformMaintenance(project string, zone string, instance string) error {
+	f.record("PerformMaintenance", project, zone, instance)
+	if f.PerformMaintenanceFn != nil {
+		return f.PerformMaintenanceFn(project, zone, instance)
+	}
+	return f.err("PerformMaintenance")
+}
+
+// SetInstanceName records the call and calls SetInstanceNameFn if it is set.
+func (f *FakeClient) SetInstanceName(project string, zone string, instance string, newName string) error {
+	f.record("SetInstanceName", project, zone, instance, newName)
+	if f.SetInstanceNameFn != nil {
+		return f.SetInstanceNameFn(project, zone, instance, newName)
+	}
+	return f.err("SetInstanceName")
+}
+
+// UpdateInstance records the call and calls UpdateInstanceFn if it is set.
+func (f *FakeClient) UpdateInstance(project string, zone string, i *compute.Instance, fields ...string) error {
+	f.record("UpdateInstance", project, zone, i, fields)
+	if f.UpdateInstanceFn != nil {
+		return f.UpdateInstanceFn(project, zone, i, fields...)
+	}
+	return f.err("UpdateInstance")
+}
+
+// DeleteRegionTargetHTTPProxy records the call and calls DeleteRegionTargetHTTPProxyFn if it is set.
+func (f *FakeClient) DeleteRegionTargetHTTPProxy(project string, region string, name string) error {
+	f.record("DeleteRegionTargetHTTPProxy", project, region, name)
+	if f.DeleteRegionTargetHTTPProxyFn != nil {
+		return f.DeleteRegionTargetHTTPProxyFn(project, region, name)
+	}
+	return f.err("DeleteRegionTargetHTTPProxy")
+}
+
+// CreateRegionTargetHTTPProxy records the call and calls CreateRegionTargetHTTPProxyFn if it is set.
+func (f *FakeClient) CreateRegionTargetHTTPProxy(project string, region string, p *compute.TargetHttpProxy) error {
+	f.record("CreateRegionTargetHTTPProxy", project, region, p)
+	if f.CreateRegionTargetHTTPProxyFn != nil {
+		return f.CreateRegionTargetHTTPProxyFn(project, region, p)
+	}
+	return f.err("CreateRegionTargetHTTPProxy")
+}
+
+// ListRegionTargetHTTPProxies records the call and calls ListRegionTargetHTTPProxiesFn if it is set.
+func (f *FakeClient) ListRegionTargetHTTPProxies(project string, region string, opts ...daisyCompute.ListCallOption) ([]*compute.TargetHttpProxy, error) {
+	f.record("ListRegionTargetHTTPProxies", project, region, opts)
+	if f.ListRegionTargetHTTPProxiesFn != nil {
+		return f.ListRegionTargetHTTPProxiesFn(project, region, opts...)
+	}
+	var r0 []*compute.TargetHttpProxy
+	return r0, f.err("ListRegionTargetHTTPProxies")
+}
+
+// GetRegionTargetHTTPProxy records the call and calls GetRegionTargetHTTPProxyFn if it is set.
+func (f *FakeClient) GetRegionTargetHTTPProxy(project string, region string, name string) (*compute.TargetHttpProxy, error) {
+	f.record("GetRegionTargetHTTPProxy", project, region, name)
+	if f.GetRegionTargetHTTPProxyFn != nil {
+		return f.GetRegionTargetHTTPProxyFn(project, region, name)
+	}
+	var r0 *compute.TargetHttpProxy
+	return r0, f.err("GetRegionTargetHTTPProxy")
+}
+
+// DeleteRegionSSLCertificate records the call and calls DeleteRegionSSLCertificateFn if it is set.
+func (f *FakeClient) DeleteRegionSSLCertificate(project string, region string, name string) error {
+	f.record("DeleteRegionSSLCertificate", project, region, name)
+	if f.DeleteRegionSSLCertificateFn != nil {
+		return f.DeleteRegionSSLCertificateFn(project, region, name)
+	}
+	return f.err("DeleteRegionSSLCertificate")
+}
+
+// CreateRegionSSLCertificate records the call and calls CreateRegionSSLCertificateFn if it is set.
+func (f *FakeClient) CreateRegionSSLCertificate(project string, region string, sc *compute.SslCertificate) error {
+	f.record("CreateRegionSSLCertificate", project, region, sc)
+	if f.CreateRegionSSLCertificateFn != nil {
+		return f.CreateRegionSSLCertificateFn(project, region, sc)
+	}
+	return f.err("CreateRegionSSLCertificate")
+}
+
+// GetRegionSSLCertificate records the call and calls GetRegionSSLCertificateFn if it is set.
+func (f *FakeClient) GetRegionSSLCertificate(project string, region string, name string) (*compute.SslCertificate, error) {
+	f.record("GetRegionSSLCertificate", project, region, name)
+	if f.GetRegionSSLCertificateFn != nil {
+		return f.GetRegionSSLCertificateFn(project, region, name)
+	}
+	var r0 *compute.SslCertificate
+	return r0, f.err("GetRegionSSLCertificate")
+}
+
+// DeleteRegionTargetHTTPSProxy records the call and calls DeleteRegionTargetHTTPSProxyFn if it is set.
+func (f *FakeClient) DeleteRegionTargetHTTPSProxy(project string, region string, name string) error {
+	f.record("DeleteRegionTargetHTTPSProxy", project, region, name)
+	if f.DeleteRegionTargetHTTPSProxyFn != nil {
+		return f.DeleteRegionTargetHTTPSProxyFn(project, region, name)
+	}
+	return f.err("DeleteRegionTargetHTTPSProxy")
+}
+
+// CreateRegionTargetHTTPSProxy records the call and calls CreateRegionTargetHTTPSProxyFn if it is set.
+func (f *FakeClient) CreateRegionTargetHTTPSProxy(project string, region string, p *compute.TargetHttpsProxy) error {
+	f.record("CreateRegionTargetHTTPSProxy", project, region, p)
+	if f.CreateRegionTargetHTTPSProxyFn != nil {
+		return f.CreateRegionTargetHTTPSProxyFn(project, region, p)
+	}
+	return f.err("CreateRegionTargetHTTPSProxy")
+}
+
+// GetRegionTargetHTTPSProxy records the call and calls GetRegionTargetHTTPSProxyFn if it is set.
+func (f *FakeClient) GetRegionTargetHTTPSProxy(project string, region string, name string) (*compute.TargetHttpsProxy, error) {
+	f.record("GetRegionTargetHTTPSProxy", project, region, name)
+	if f.GetRegionTargetHTTPSProxyFn != nil {
+		return f.GetRegionTargetHTTPSProxyFn(project, region, name)
+	}
+	var r0 *compute.TargetHttpsProxy
+	return r0, f.err("GetRegionTargetHTTPSProxy")
+}
+
+// SetRegionSSLCertificates records the call and calls SetRegionSSLCertificatesFn if it is set.
+func (f *FakeClient) SetRegionSSLCertificates(project string, region string, proxy string, sslCertificates []string) error {
+	f.record("SetRegionSSLCertificates", project, region, proxy, sslCertificates)
+	if f.SetRegionSSLCertificatesFn != nil {
+		return f.SetRegionSSLCertificatesFn(project, region, proxy, sslCertificates)
+	}
+	return f.err("SetRegionSSLCertificates")
+}
+
+// DeleteRegionURLMap records the call and calls DeleteRegionURLMapFn if it is set.
+func (f *FakeClient) DeleteRegionURLMap(project string, region string, name string) error {
+	f.record("DeleteRegionURLMap", project, region, name)
+	if f.DeleteRegionURLMapFn != nil {
+		return f.DeleteRegionURLMapFn(project, region, name)
+	}
+	return f.err("DeleteRegionURLMap")
+}
+
+// CreateRegionURLMap records the call and calls CreateRegionURLMapFn if it is set.
+func (f *FakeClient) CreateRegionURLMap(project string, region string, u *compute.UrlMap) error {
+	f.record("CreateRegionURLMap", project, region, u)
+	if f.CreateRegionURLMapFn != nil {
+		return f.CreateRegionURLMapFn(project, region, u)
+	}
+	return f.err("CreateRegionURLMap")
+}
+
+// ListRegionURLMaps records the call and calls ListRegionURLMapsFn if it is set.
+func (f *FakeClient) ListRegionURLMaps(project string, region string, opts ...daisyCompute.ListCallOption) ([]*compute.UrlMap, error) {
+	f.record("ListRegionURLMaps", project, region, opts)
+	if f.ListRegionURLMapsFn != nil {
+		return f.ListRegionURLMapsFn(project, region, opts...)
+	}
+	var r0 []*compute.UrlMap
+	return r0, f.err("ListRegionURLMaps")
+}
+
+// GetRegionURLMap records the call and calls GetRegionURLMapFn if it is set.
+func (f *FakeClient) GetRegionURLMap(project string, region string, name string) (*compute.UrlMap, error) {
+	f.record("GetRegionURLMap", project, region, name)
+	if f.GetRegionURLMapFn != nil {
+		return f.GetRegionURLMapFn(project, region, name)
+	}
+	var r0 *compute.UrlMap
+	return r0, f.err("GetRegionURLMap")
+}
+
+// ValidateRegionURLMap records the call and calls ValidateRegionURLMapFn if it is set.
+func (f *FakeClient) ValidateRegionURLMap(project string, region string, u *compute.UrlMap) (*compute.UrlMapsValidateResponse, error) {
+	f.record("ValidateRegionURLMap", project, region, u)
+	if f.ValidateRegionURLMapFn != nil {
+		return f.ValidateRegionURLMapFn(project, region, u)
+	}
+	var r0 *compute.UrlMapsValidateResponse
+	return r0, f.err("ValidateRegionURLMap")
+}
+
+// ValidateURLMap records the call and calls ValidateURLMapFn if it is set.
+func (f *FakeClient) ValidateURLMap(project string, u *compute.UrlMap) (*compute.UrlMapsValidateResponse, error) {
+	f.record("ValidateURLMap", project, u)
+	if f.ValidateURLMapFn != nil {
+		return f.ValidateURLMapFn(project, u)
+	}
+	var r0 *compute.UrlMapsValidateResponse
+	return r0, f.err("ValidateURLMap")
+}
+
+// DeleteRegionBackendService records the call and calls DeleteRegionBackendServiceFn if it is set.
+func (f *FakeClient) DeleteRegionBackendService(project string, region string, name string) error {
+	f.record("DeleteRegionBackendService", project, region, name)
+	if f.DeleteRegionBackendServiceFn != nil {
+		return f.DeleteRegionBackendServiceFn(project, region, name)
+	}
+	return f.err("DeleteRegionBackendService")
+}
+
+// CreateRegionBackendService records the call and calls CreateRegionBackendServiceFn if it is set.
+func (f *FakeClient) CreateRegionBackendService(project string, region string, b *compute.BackendService) error {
+	f.record("CreateRegionBackendService", project, region, b)
+	if f.CreateRegionBackendServiceFn != nil {
+		return f.CreateRegionBackendServiceFn(project, region, b)
+	}
+	return f.err("CreateRegionBackendService")
+}
+
+// PatchRegionBackendService records the call and calls PatchRegionBackendServiceFn if it is set.
+func (f *FakeClient) PatchRegionBackendService(project string, region string, name string, b *compute.BackendService) error {
+	f.record("PatchRegionBackendService", project, region, name, b)
+	if f.PatchRegionBackendServiceFn != nil {
+		return f.PatchRegionBackendServiceFn(project, region, name, b)
+	}
+	return f.err("PatchRegionBackendService")
+}
+
+// ListRegionBackendServices records the call and calls ListRegionBackendServicesFn if it is set.
+func (f *FakeClient) ListRegionBackendServices(project string, region string, opts ...daisyCompute.ListCallOption) ([]*compute.BackendService, error) {
+	f.record("ListRegionBackendServices", project, region, opts)
+	if f.ListRegionBackendServicesFn != nil {
+		return f.ListRegionBackendServicesFn(project, region, opts...)
+	}
+	var r0 []*compute.BackendService
+	return r0, f.err("ListRegionBackendServices")
+}
+
+// GetRegionBackendService records the call and calls GetRegionBackendServiceFn if it is set.
+func (f *FakeClient) GetRegionBackendService(project string, region string, name string) (*compute.BackendService, error) {
+	f.record("GetRegionBackendService", project, region, name)
+	if f.GetRegionBackendServiceFn != nil {
+		return f.GetRegionBackendServiceFn(project, region, name)
+	}
+	var r0 *compute.BackendService
+	return r0, f.err("GetRegionBackendService")
+}
+
+// GetRegionBackendServiceHealth records the call and calls GetRegionBackendServiceHealthFn if it is set.
+func (f *FakeClient) GetRegionBackendServiceHealth(project string, region string, backendService string, group string) (*compute.BackendServiceGroupHealth, error) {
+	f.record("GetRegionBackendServiceHealth", project, region, backendService, group)
+	if f.GetRegionBackendServiceHealthFn != nil {
+		return f.GetRegionBackendServiceHealthFn(project, region, backendService, group)
+	}
+	var r0 *compute.BackendServiceGroupHealth
+	return r0, f.err("GetRegionBackendServiceHealth")
+}
+
+// WaitForBackendServiceHealthy records the call and calls WaitForBackendServiceHealthyFn if it is set.
+func (f *FakeClient) WaitForBackendServiceHealthy(project string, region string, backendService string, group string, minHealthy int, timeout time.Duration) error {
+	f.record("WaitForBackendServiceHealthy", project, region, backendService, group, minHealthy, timeout)
+	if f.WaitForBackendServiceHealthyFn != nil {
+		return f.WaitForBackendServiceHealthyFn(project, region, backendService, group, minHealthy, timeout)
+	}
+	return f.err("WaitForBackendServiceHealthy")
+}
+
+// DeleteRegionHealthCheck records the call and calls DeleteRegionHealthCheckFn if it is set.
+func (f *FakeClient) DeleteRegionHealthCheck(project string, region string, name string) error {
+	f.record("DeleteRegionHealthCheck", project, region, name)
+	if f.DeleteRegionHealthCheckFn != nil {
+		return f.DeleteRegionHealthCheckFn(project, region, name)
+	}
+	return f.err("DeleteRegionHealthCheck")
+}
+
+// CreateRegionHealthCheck records the call and calls CreateRegionHealthCheckFn if it is set.
+func (f *FakeClient) CreateRegionHealthCheck(project string, region string, h *compute.HealthCheck) error {
+	f.record("CreateRegionHealthCheck", project, region, h)
+	if f.CreateRegionHealthCheckFn != nil {
+		return f.CreateRegionHealthCheckFn(project, region, h)
+	}
+	return f.err("CreateRegionHealthCheck")
+}
+
+// ListRegionHealthChecks records the call and calls ListRegionHealthChecksFn if it is set.
+func (f *FakeClient) ListRegionHealthChecks(project string, region string, opts ...daisyCompute.ListCallOption) ([]*compute.HealthCheck, error) {
+	f.record("ListRegionHealthChecks", project, region, opts)
+	if f.ListRegionHealthChecksFn != nil {
+		return f.ListRegionHealthChecksFn(project, region, opts...)
+	}
+	var r0 []*compute.HealthCheck
+	return r0, f.err("ListRegionHealthChecks")
+}
+
+// GetRegionHealthCheck records the call and calls GetRegionHealthCheckFn if it is set.
+func (f *FakeClient) GetRegionHealthCheck(project string, region string, name string) (*compute.HealthCheck, error) {
+	f.record("GetRegionHealthCheck", project, region, name)
+	if f.GetRegionHealthCheckFn != nil {
+		return f.GetRegionHealthCheckFn(project, region, name)
+	}
+	var r0 *compute.HealthCheck
+	return r0, f.err("GetRegionHealthCheck")
+}
+
+// CreateInstanceGroup records the call and calls CreateInstanceGroupFn if it is set.
+func (f *FakeClient) CreateInstanceGroup(project string, zone string, ig *compute.InstanceGroup) error {
+	f.record("CreateInstanceGroup", project, zone, ig)
+	if f.CreateInstanceGroupFn != nil {
+		return f.CreateInstanceGroupFn(project, zone, ig)
+	}
+	return f.err("CreateInstanceGroup")
+}
+
+// DeleteInstanceGroup records the call and calls DeleteInstanceGroupFn if it is set.
+func (f *FakeClient) DeleteInstanceGroup(project string, zone string, name string) error {
+	f.record("DeleteInstanceGroup", project, zone, name)
+	if f.DeleteInstanceGroupFn != nil {
+		return f.DeleteInstanceGroupFn(project, zone, name)
+	}
+	return f.err("DeleteInstanceGroup")
+}
+
+// GetInstanceGroup records the call and calls GetInstanceGroupFn if it is set.
+func (f *FakeClient) GetInstanceGroup(project string, zone string, name string) (*compute.InstanceGroup, error) {
+	f.record("GetInstanceGroup", project, zone, name)
+	if f.GetInstanceGroupFn != nil {
+		return f.GetInstanceGroupFn(project, zone, name)
+	}
+	var r0 *compute.InstanceGroup
+	return r0, f.err("GetInstanceGroup")
+}
+
+// AddInstanceGroupInstances records the call and calls AddInstanceGroupInstancesFn if it is set.
+func (f *FakeClient) AddInstanceGroupInstances(project string, zone string, name string, instances []string) error {
+	f.record("AddInstanceGroupInstances", project, zone, name, instances)
+	if f.AddInstanceGroupInstancesFn != nil {
+		return f.AddInstanceGroupInstancesFn(project, zone, name, instances)
+	}
+	return f.err("AddInstanceGroupInstances")
+}
+
+// RemoveInstanceGroupInstances records the call and calls RemoveInstanceGroupInstancesFn if it is set.
+func (f *FakeClient) RemoveInstanceGroupInstances(project string, zone string, name string, instances []string) error {
+	f.record("RemoveInstanceGroupInstances", project, zone, name, instances)
+	if f.RemoveInstanceGroupInstancesFn != nil {
+		return f.RemoveInstanceGroupInstancesFn(project, zone, name, instances)
+	}
+	return f.err("RemoveInstanceGroupInstances")
+}
+
+// AttachNetworkEndpoints records the call and calls AttachNetworkEndpointsFn if it is set.
+func (f *FakeClient) AttachNetworkEndpoints(project string, zone string, neg string, endpoints []*compute.NetworkEndpoint) error {
+	f.record("AttachNetworkEndpoints", project, zone, neg, endpoints)
+	if f.AttachNetworkEndpointsFn != nil {
+		return f.AttachNetworkEndpointsFn(project, zone, neg, endpoints)
+	}
+	return f.err("AttachNetworkEndpoints")
+}
+
+// DetachNetworkEndpoints records the call and calls DetachNetworkEndpointsFn if it is set.
+func (f *FakeClient) DetachNetworkEndpoints(project string, zone string, neg string, endpoints []*compute.NetworkEndpoint) error {
+	f.record("DetachNetworkEndpoints", project, zone, neg, endpoints)
+	if f.DetachNetworkEndpointsFn != nil {
+		return f.DetachNetworkEndpointsFn(project, zone, neg, endpoints)
+	}
+	return f.err("DetachNetworkEndpoints")
+}
+
+// AttachRegionNetworkEndpoints records the call and calls AttachRegionNetworkEndpointsFn if it is set.
+func (f *FakeClient) AttachRegionNetworkEndpoints(project string, region string, neg string, endpoints []*compute.NetworkEndpoint) error {
+	f.record("AttachRegionNetworkEndpoints", project, region, neg, endpoints)
+	if f.AttachRegionNetworkEndpointsFn != nil {
+		return f.AttachRegionNetworkEndpointsFn(project, region, neg, endpoints)
+	}
+	return f.err("AttachRegionNetworkEndpoints")
+}
+
+// DetachRegionNetworkEndpoints records the call and calls DetachRegionNetworkEndpointsFn if it is set.
+func (f *FakeClient) DetachRegionNetworkEndpoints(project string, region string, neg string, endpoints []*compute.NetworkEndpoint) error {
+	f.record("DetachRegionNetworkEndpoints", project, region, neg, endpoints)
+	if f.DetachRegionNetworkEndpointsFn != nil {
+		return f.DetachRegionNetworkEndpointsFn(project, region, neg, endpoints)
+	}
+	return f.err("DetachRegionNetworkEndpoints")
+}
+
+// DeleteRegionNetworkEndpointGroup records the call and calls DeleteRegionNetworkEndpointGroupFn if it is set.
+func (f *FakeClient) DeleteRegionNetworkEndpointGroup(project string, region string, name string) error {
+	f.record("DeleteRegionNetworkEndpointGroup", project, region, name)
+	if f.DeleteRegionNetworkEndpointGroupFn != nil {
+		return f.DeleteRegionNetworkEndpointGroupFn(project, region, name)
+	}
+	return f.err("DeleteRegionNetworkEndpointGroup")
+}
+
+// CreateRegionNetworkEndpointGroup records the call and calls CreateRegionNetworkEndpointGroupFn if it is set.
+func (f *FakeClient) CreateRegionNetworkEndpointGroup(project string, region string, n *compute.NetworkEndpointGroup) error {
+	f.record("CreateRegionNetworkEndpointGroup", project, region, n)
+	if f.CreateRegionNetworkEndpointGroupFn != nil {
+		return f.CreateRegionNetworkEndpointGroupFn(project, region, n)
+	}
+	return f.err("CreateRegionNetworkEndpointGroup")
+}
+
+// ListRegionNetworkEndpointGroups records the call and calls ListRegionNetworkEndpointGroupsFn if it is set.
+func (f *FakeClient) ListRegionNetworkEndpointGroups(project string, region string, opts ...daisyCompute.ListCallOption) ([]*compute.NetworkEndpointGroup, error) {
+	f.record("ListRegionNetworkEndpointGroups", project, region, opts)
+	if f.ListRegionNetworkEndpointGroupsFn != nil {
+		return f.ListRegionNetworkEndpointGroupsFn(project, region, opts...)
+	}
+	var r0 []*compute.NetworkEndpointGroup
+	return r0, f.err("ListRegionNetworkEndpointGroups")
+}
+
+// GetRegionNetworkEndpointGroup records the call and calls GetRegionNetworkEndpointGroupFn if it is set.
+func (f *FakeClient) GetRegionNetworkEndpointGroup(project string, region string, name string) (*compute.NetworkEndpointGroup, error) {
+	f.record("GetRegionNetworkEndpointGroup", project, region, name)
+	if f.GetRegionNetworkEndpointGroupFn != nil {
+		return f.GetRegionNetworkEndpointGroupFn(project, region, name)
+	}
+	var r0 *compute.NetworkEndpointGroup
+	return r0, f.err("GetRegionNetworkEndpointGroup")
+}
+
+// GetRegionAutoscaler records the call and calls GetRegionAutoscalerFn if it is set.
+func (f *FakeClient) GetRegionAutoscaler(project string, region string, name string) (*compute.Autoscaler, error) {
+	f.record("GetRegionAutoscaler", project, region, name)
+	if f.GetRegionAutoscalerFn != nil {
+		return f.GetRegionAutoscalerFn(project, region, name)
+	}
+	var r0 *compute.Autoscaler
+	return r0, f.err("GetRegionAutoscaler")
+}
+
+// ListRegionAutoscalers records the call and calls ListRegionAutoscalersFn if it is set.
+func (f *FakeClient) ListRegionAutoscalers(project string, region string, opts ...daisyCompute.ListCallOption) ([]*compute.Autoscaler, error) {
+	f.record("ListRegionAutoscalers", project, region, opts)
+	if f.ListRegionAutoscalersFn != nil {
+		return f.ListRegionAutoscalersFn(project, region, opts...)
+	}
+	var r0 []*compute.Autoscaler
+	return r0, f.err("ListRegionAutoscalers")
+}
+
+// GetRegionAutoscalerRecommendedSize records the call and calls GetRegionAutoscalerRecommendedSizeFn if it is set.
+func (f *FakeClient) GetRegionAutoscalerRecommendedSize(project string, region string, name string) (int64, error) {
+	f.record("GetRegionAutoscalerRecommendedSize", project, region, name)
+	if f.GetRegionAutoscalerRecommendedSizeFn != nil {
+		return f.GetRegionAutoscalerRecommendedSizeFn(project, region, name)
+	}
+	var r0 int64
+	return r0, f.err("GetRegionAutoscalerRecommendedSize")
+}
+
+// Retry records the call and calls RetryFn if it is set.
+func (f *FakeClient) Retry(fArg func(opts ...googleapi.CallOption) (*compute.Operation, error), opts ...googleapi.CallOption) (*compute.Operation, error) {
+	f.record("Retry", fArg, opts)
+	if f.RetryFn != nil {
+		return f.RetryFn(fArg, opts...)
+	}
+	var r0 *compute.Operation
+	return r0, f.err("Retry")
+}
+
+// RetryContext records the call and calls RetryContextFn if it is set.
+func (f *FakeClient) RetryContext(ctx context.Context, fArg func(opts ...googleapi.CallOption) (*compute.Operation, error), opts ...googleapi.CallOption) (*compute.Operation, error) {
+	f.record("RetryContext", ctx, fArg, opts)
+	if f.RetryContextFn != nil {
+		return f.RetryContextFn(ctx, fArg, opts...)
+	}
+	var r0 *compute.Operation
+	return r0, f.err("RetryContext")
+}
+
+// RetryBeta records the call and calls RetryBetaFn if it is set.
+func (f *FakeClient) RetryBeta(fArg func(opts ...googleapi.CallOption) (*computeBeta.Operation, error), opts ...googleapi.CallOption) (*computeBeta.Operation, error) {
+	f.record("RetryBeta", fArg, opts)
+	if f.RetryBetaFn != nil {
+		return f.RetryBetaFn(fArg, opts...)
+	}
+	var r0 *computeBeta.Operation
+	return r0, f.err("RetryBeta")
+}
+
+// BasePath records the call and calls BasePathFn if it is set.
+func (f *FakeClient) BasePath() string {
+	f.record("BasePath")
+	if f.BasePathFn != nil {
+		return f.BasePathFn()
+	}
+	var r0 string
+	return r0
+}
+
+// DefaultProject records the call and calls DefaultProjectFn if it is set.
+func (f *FakeClient) DefaultProject() *daisyCompute.ProjectClient {
+	f.record("DefaultProject")
+	if f.DefaultProjectFn != nil {
+		return f.DefaultProjectFn()
+	}
+	var r0 *daisyCompute.ProjectClient
+	return r0
+}
@@ -0,0 +1,58 @@
+//  Copyright 2020 Google Inc. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package fake
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"google.golang.org/api/compute/v1"
+)
+
+func TestFakeClient(t *testing.T) {
+	f := NewFakeClient()
+	want := &compute.Instance{Name: "foo"}
+
+	f.GetInstanceFn = func(project, zone, name string) (*compute.Instance, error) { return want, nil }
+	got, err := f.GetInstance("p", "z", "foo")
+	if err != nil {
+		t.Fatalf("GetInstance: unexpected error: %v", err)
+	}
+	if got != want {
+		t.Errorf("GetInstance: got %v, want %v", got, want)
+	}
+
+	wantErr := errors.New("boom")
+	f.Errors["CreateInstance"] = wantErr
+	if err := f.CreateInstance("p", "z", want); err != wantErr {
+		t.Errorf("CreateInstance: got error %v, want %v", err, wantErr)
+	}
+	if err := f.DeleteInstance("p", "z", "foo"); err != nil {
+		t.Errorf("DeleteInstance: unexpected error: %v", err)
+	}
+
+	wantCalls := []Call{
+		{"GetInstance", []interface{}{"p", "z", "foo"}},
+		{"CreateInstance", []interface{}{"p", "z", want}},
+		{"DeleteInstance", []interface{}{"p", "z", "foo"}},
+	}
+	if got := f.Calls(); !reflect.DeepEqual(got, wantCalls) {
+		t.Errorf("Calls: got %v, want %v", got, wantCalls)
+	}
+	if got := f.CallsTo("CreateInstance"); !reflect.DeepEqual(got, wantCalls[1:2]) {
+		t.Errorf("CallsTo: got %v, want %v", got, wantCalls[1:2])
+	}
+}
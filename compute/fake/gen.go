@@ -0,0 +1,231 @@
+//  Copyright 2020 Google Inc. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+//go:build ignore
+
+// gen generates fake_client.go from the Client interface in ../compute.go.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"log"
+	"os"
+	"regexp"
+	"strings"
+)
+
+const header = `//  Copyright 2020 Google Inc. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+// Code generated by gen.go. DO NOT EDIT.
+
+package fake
+
+`
+
+// imports lists the packages the generated code may refer to, standard
+// library first.
+var imports = []struct {
+	alias, ident, path string
+	std                bool
+}{
+	{"", "context", "context", true},
+	{"", "time", "time", true},
+	{"daisyCompute", "daisyCompute", "github.com/GoogleCloudPlatform/compute-daisy/compute", false},
+	{"computeAlpha", "computeAlpha", "google.golang.org/api/compute/v0.alpha", false},
+	{"computeBeta", "computeBeta", "google.golang.org/api/compute/v0.beta", false},
+	{"", "compute", "google.golang.org/api/compute/v1", false},
+	{"", "googleapi", "google.golang.org/api/googleapi", false},
+}
+
+type param struct {
+	name, typ string
+	variadic  bool
+}
+
+func main() {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "../compute.go", nil, 0)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var iface *ast.InterfaceType
+	ast.Inspect(f, func(n ast.Node) bool {
+		if ts, ok := n.(*ast.TypeSpec); ok && ts.Name.Name == "Client" {
+			iface = ts.Type.(*ast.InterfaceType)
+			return false
+		}
+		return true
+	})
+	if iface == nil {
+		log.Fatal("Client interface not found")
+	}
+
+	var fields, methods bytes.Buffer
+	for _, m := range iface.Methods.List {
+		ft := m.Type.(*ast.FuncType)
+		name := m.Names[0].Name
+		params := fieldList(ft.Params, "a")
+		results := fieldList(ft.Results, "r")
+
+		var sig, callArgs, recordArgs []string
+		for _, p := range params {
+			typ := p.typ
+			arg := p.name
+			if p.variadic {
+				typ = "..." + typ
+				arg += "..."
+			}
+			sig = append(sig, p.name+" "+typ)
+			callArgs = append(callArgs, arg)
+			recordArgs = append(recordArgs, p.name)
+		}
+		var resTypes []string
+		for _, r := range results {
+			resTypes = append(resTypes, r.typ)
+		}
+		fnType := fmt.Sprintf("func(%s) (%s)", strings.Join(sig, ", "), strings.Join(resTypes, ", "))
+
+		fmt.Fprintf(&fields, "\t%sFn %s\n", name, fnType)
+
+		fmt.Fprintf(&methods, "// %s records the call and calls %sFn if it is set.\n", name, name)
+		fmt.Fprintf(&methods, "func (f *FakeClient) %s(%s) (%s) {\n", name, strings.Join(sig, ", "), strings.Join(resTypes, ", "))
+		fmt.Fprintf(&methods, "\tf.record(%q", name)
+		for _, a := range recordArgs {
+			fmt.Fprintf(&methods, ", %s", a)
+		}
+		fmt.Fprintf(&methods, ")\n")
+		fmt.Fprintf(&methods, "\tif f.%sFn != nil {\n", name)
+		if len(results) > 0 {
+			fmt.Fprintf(&methods, "\t\treturn f.%sFn(%s)\n", name, strings.Join(callArgs, ", "))
+		} else {
+			fmt.Fprintf(&methods, "\t\tf.%sFn(%s)\n\t\treturn\n", name, strings.Join(callArgs, ", "))
+		}
+		fmt.Fprintf(&methods, "\t}\n")
+		var rets []string
+		for _, r := range results {
+			if r.typ == "error" {
+				rets = append(rets, fmt.Sprintf("f.err(%q)", name))
+				continue
+			}
+			fmt.Fprintf(&methods, "\tvar %s %s\n", r.name, r.typ)
+			rets = append(rets, r.name)
+		}
+		if len(rets) > 0 {
+			fmt.Fprintf(&methods, "\treturn %s\n", strings.Join(rets, ", "))
+		}
+		fmt.Fprintf(&methods, "}\n\n")
+	}
+
+	var out bytes.Buffer
+	out.WriteString(header)
+	out.WriteString("import (\n")
+	std := true
+	for _, imp := range imports {
+		if !regexp.MustCompile(`\b` + imp.ident + `\.`).Match(methods.Bytes()) {
+			continue
+		}
+		if std && !imp.std {
+			std = false
+			out.WriteString("\n")
+		}
+		fmt.Fprintf(&out, "\t%s %q\n", imp.alias, imp.path)
+	}
+	out.WriteString(")\n\n")
+	out.WriteString("// FakeClient is a Client that records its calls. Each method calls the\n")
+	out.WriteString("// matching Fn field if it is set, and otherwise returns zero values and the\n")
+	out.WriteString("// error configured in Errors.\n")
+	out.WriteString("type FakeClient struct {\n\tfakeState\n\n")
+	out.Write(fields.Bytes())
+	out.WriteString("}\n\n")
+	out.Write(methods.Bytes())
+
+	src, err := format.Source(out.Bytes())
+	if err != nil {
+		log.Fatalf("formatting generated code: %v\n%s", err, out.Bytes())
+	}
+	if err := os.WriteFile("fake_client.go", src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// fieldList flattens a parameter or result list, naming unnamed entries with
+// prefix and their index.
+func fieldList(fl *ast.FieldList, prefix string) []param {
+	if fl == nil {
+		return nil
+	}
+	var ps []param
+	for _, field := range fl.List {
+		typ := field.Type
+		variadic := false
+		if e, ok := typ.(*ast.Ellipsis); ok {
+			typ = e.Elt
+			variadic = true
+		}
+		s := types.ExprString(qualify(typ))
+		if len(field.Names) == 0 {
+			ps = append(ps, param{name: fmt.Sprintf("%s%d", prefix, len(ps)), typ: s, variadic: variadic})
+			continue
+		}
+		for _, n := range field.Names {
+			name := n.Name
+			if name == "f" {
+				// f is the receiver name in generated methods.
+				name = "fArg"
+			}
+			if prefix == "r" {
+				name = fmt.Sprintf("%s%d", prefix, len(ps))
+			}
+			ps = append(ps, param{name: name, typ: s, variadic: variadic})
+		}
+	}
+	return ps
+}
+
+// qualify prefixes exported identifiers declared in package compute with the
+// daisyCompute package name.
+func qualify(e ast.Expr) ast.Expr {
+	switch t := e.(type) {
+	case *ast.Ident:
+		if ast.IsExported(t.Name) {
+			return &ast.SelectorExpr{X: ast.NewIdent("daisyCompute"), Sel: t}
+		}
+	case *ast.StarExpr:
+		return &ast.StarExpr{X: qualify(t.X)}
+	case *ast.ArrayType:
+		return &ast.ArrayType{Len: t.Len, Elt: qualify(t.Elt)}
+	case *ast.MapType:
+		return &ast.MapType{Key: qualify(t.Key), Value: qualify(t.Value)}
+	}
+	return e
+}
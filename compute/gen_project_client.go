@@ -0,0 +1,169 @@
+//  Copyright 2020 Google Inc. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+//go:build ignore
+
+// gen_project_client generates project_client.go from the Client interface in
+// compute.go.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"log"
+	"os"
+	"regexp"
+	"strings"
+)
+
+const header = `//  Copyright 2020 Google Inc. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+// Code generated by gen_project_client.go. DO NOT EDIT.
+
+package compute
+
+`
+
+// imports lists the packages the generated code may refer to, standard
+// library first.
+var imports = []struct {
+	alias, ident, path string
+	std                bool
+}{
+	{"", "context", "context", true},
+	{"", "time", "time", true},
+	{"computeAlpha", "computeAlpha", "google.golang.org/api/compute/v0.alpha", false},
+	{"computeBeta", "computeBeta", "google.golang.org/api/compute/v0.beta", false},
+	{"", "compute", "google.golang.org/api/compute/v1", false},
+	{"", "googleapi", "google.golang.org/api/googleapi", false},
+}
+
+func main() {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "compute.go", nil, 0)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var iface *ast.InterfaceType
+	ast.Inspect(f, func(n ast.Node) bool {
+		if ts, ok := n.(*ast.TypeSpec); ok && ts.Name.Name == "Client" {
+			iface = ts.Type.(*ast.InterfaceType)
+			return false
+		}
+		return true
+	})
+	if iface == nil {
+		log.Fatal("Client interface not found")
+	}
+
+	var methods bytes.Buffer
+	for _, m := range iface.Methods.List {
+		ft := m.Type.(*ast.FuncType)
+		name := m.Names[0].Name
+
+		var names, typs []string
+		var variadic []bool
+		for _, field := range ft.Params.List {
+			typ := field.Type
+			isVariadic := false
+			if e, ok := typ.(*ast.Ellipsis); ok {
+				typ = e.Elt
+				isVariadic = true
+			}
+			for _, n := range field.Names {
+				names = append(names, n.Name)
+				typs = append(typs, types.ExprString(typ))
+				variadic = append(variadic, isVariadic)
+			}
+		}
+		if len(names) == 0 || names[0] != "project" || typs[0] != "string" {
+			continue
+		}
+
+		var sig []string
+		callArgs := []string{"pc.project"}
+		for i := 1; i < len(names); i++ {
+			typ, arg := typs[i], names[i]
+			if variadic[i] {
+				typ = "..." + typ
+				arg += "..."
+			}
+			sig = append(sig, names[i]+" "+typ)
+			callArgs = append(callArgs, arg)
+		}
+		var results []string
+		if ft.Results != nil {
+			for _, r := range ft.Results.List {
+				n := len(r.Names)
+				if n == 0 {
+					n = 1
+				}
+				for i := 0; i < n; i++ {
+					results = append(results, types.ExprString(r.Type))
+				}
+			}
+		}
+
+		fmt.Fprintf(&methods, "// %s calls Client.%s with pc's project.\n", name, name)
+		fmt.Fprintf(&methods, "func (pc *ProjectClient) %s(%s) (%s) {\n", name, strings.Join(sig, ", "), strings.Join(results, ", "))
+		ret := "return "
+		if len(results) == 0 {
+			ret = ""
+		}
+		fmt.Fprintf(&methods, "\t%spc.c.%s(%s)\n}\n\n", ret, name, strings.Join(callArgs, ", "))
+	}
+
+	var out bytes.Buffer
+	out.WriteString(header)
+	out.WriteString("import (\n")
+	std := true
+	for _, imp := range imports {
+		if !regexp.MustCompile(`\b` + imp.ident + `\.`).Match(methods.Bytes()) {
+			continue
+		}
+		if std && !imp.std {
+			std = false
+			out.WriteString("\n")
+		}
+		fmt.Fprintf(&out, "\t%s %q\n", imp.alias, imp.path)
+	}
+	out.WriteString(")\n\n")
+	out.Write(methods.Bytes())
+
+	src, err := format.Source(out.Bytes())
+	if err != nil {
+		log.Fatalf("formatting generated code: %v\n%s", err, out.Bytes())
+	}
+	if err := os.WriteFile("project_client.go", src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,47 @@
+//  Copyright 2020 Google Inc. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package compute
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// maxLabels is the maximum number of labels a resource can have.
+const maxLabels = 64
+
+var (
+	labelKeyRegex   = regexp.MustCompile(`^\p{Ll}[\p{Ll}\p{Lo}\p{N}_-]{0,62}$`)
+	labelValueRegex = regexp.MustCompile(`^[\p{Ll}\p{Lo}\p{N}_-]{0,63}$`)
+)
+
+// ValidateLabels checks labels against the GCE label requirements: at most 64
+// labels, keys of 1 to 63 characters starting with a lowercase letter, and
+// values of up to 63 characters. Keys and values may contain only lowercase
+// letters, digits, underscores and dashes.
+func ValidateLabels(labels map[string]string) error {
+	if len(labels) > maxLabels {
+		return fmt.Errorf("too many labels: %d, maximum is %d", len(labels), maxLabels)
+	}
+	for k, v := range labels {
+		if !labelKeyRegex.MatchString(k) {
+			return fmt.Errorf("invalid label key %q: must be 1-63 characters, start with a lowercase letter and contain only lowercase letters, digits, underscores and dashes", k)
+		}
+		if !labelValueRegex.MatchString(v) {
+			return fmt.Errorf("invalid value %q for label %q: must be at most 63 characters and contain only lowercase letters, digits, underscores and dashes", v, k)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+//  Copyright 2020 Google Inc. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package compute
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestValidateLabels(t *testing.T) {
+	tooMany := map[string]string{}
+	for i := 0; i <= maxLabels; i++ {
+		tooMany[fmt.Sprintf("key-%d", i)] = ""
+	}
+
+	tests := []struct {
+		desc    string
+		labels  map[string]string
+		wantErr bool
+	}{
+		{"nil", nil, false},
+		{"valid", map[string]string{"env": "test", "team_1": "", "ключ": "значение"}, false},
+		{"uppercase key", map[string]string{"Env": "test"}, true},
+		{"key starts with digit", map[string]string{"1env": "test"}, true},
+		{"key too long", map[string]string{strings.Repeat("a", 64): ""}, true},
+		{"empty key", map[string]string{"": "test"}, true},
+		{"invalid value", map[string]string{"env": "Test.1"}, true},
+		{"value too long", map[string]string{"env": strings.Repeat("a", 64)}, true},
+		{"too many labels", tooMany, true},
+	}
+	for _, tt := range tests {
+		if err := ValidateLabels(tt.labels); (err != nil) != tt.wantErr {
+			t.Errorf("%s: got error %v, want error %t", tt.desc, err, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+//  Copyright 2020 Google Inc. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package compute
+
+import (
+	"sync"
+	"time"
+
+	"google.golang.org/api/compute/v1"
+)
+
+// operationCacheTTL is how long a poll result for an operation that is not
+// yet done is shared with other waits on the same operation. It is shorter
+// than the poll interval so that a single wait never sees its own stale
+// result.
+var operationCacheTTL = 500 * time.Millisecond
+
+// operationCache shares operation poll results between concurrent waits on
+// the same operation, so that they make one operations.get or wait call
+// between them rather than one each.
+type operationCache struct {
+	mu      sync.Mutex
+	entries map[string]*operationCacheEntry
+}
+
+type operationCacheEntry struct {
+	done    chan struct{}
+	fetched time.Time
+	op      *compute.Operation
+	err     error
+}
+
+func newOperationCache() *operationCache {
+	return &operationCache{entries: map[string]*operationCacheEntry{}}
+}
+
+// get returns the result of a poll of the operation with the given self link
+// that is in flight or finished less than operationCacheTTL ago on clk, or
+// else calls fetch. Results for operations that are done, and errors, are not
+// kept for later calls, and results for operations that are not done are
+// dropped once they are older than operationCacheTTL, so that an operation
+// whose waiters gave up does not stay in the cache. A nil cache always calls
+// fetch.
+func (oc *operationCache) get(selfLink string, clk Clock, fetch operationGetterFunc) (*compute.Operation, error) {
+	if oc == nil {
+		return fetch()
+	}
+
+	oc.mu.Lock()
+	oc.prune(clk.Now())
+	if e, ok := oc.entries[selfLink]; ok {
+		oc.mu.Unlock()
+		<-e.done
+		return e.op, e.err
+	}
+	e := &operationCacheEntry{done: make(chan struct{})}
+	oc.entries[selfLink] = e
+	oc.mu.Unlock()
+
+	e.op, e.err = fetch()
+	// A blocking operations.wait call can take minutes, so the result's age
+	// is measured from when it came back rather than when it was asked for.
+	e.fetched = clk.Now()
+
+	oc.mu.Lock()
+	if e.err != nil || e.op == nil || e.op.Status == OpStatusDone {
+		if oc.entries[selfLink] == e {
+			delete(oc.entries, selfLink)
+		}
+	}
+	oc.mu.Unlock()
+	close(e.done)
+	return e.op, e.err
+}
+
+// prune deletes finished entries that are at least operationCacheTTL older
+// than now. Polls still in flight are kept. oc.mu must be held.
+func (oc *operationCache) prune(now time.Time) {
+	for k, e := range oc.entries {
+		select {
+		case <-e.done:
+			if now.Sub(e.fetched) >= operationCacheTTL {
+				delete(oc.entries, k)
+			}
+		default:
+		}
+	}
+}
@@ -0,0 +1,146 @@
+//  Copyright 2020 Google Inc. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package compute
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/api/compute/v1"
+)
+
+func TestOperationCacheSharesInFlightPoll(t *testing.T) {
+	oc := newOperationCache()
+	clk := NewFakeClock(time.Now())
+	release := make(chan struct{})
+	var mu sync.Mutex
+	var fetches int
+	fetch := func() (*compute.Operation, error) {
+		mu.Lock()
+		fetches++
+		mu.Unlock()
+		<-release
+		return &compute.Operation{Name: "op", Status: OpStatusRunning}, nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if op, err := oc.get("op", clk, fetch); err != nil || op.Status != OpStatusRunning {
+				t.Errorf("got (%v, %v), want running operation", op, err)
+			}
+		}()
+	}
+	// Callers that arrive after the fetch finishes reuse its result, as it is
+	// within the TTL.
+	for {
+		mu.Lock()
+		n := fetches
+		mu.Unlock()
+		if n > 0 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	close(release)
+	wg.Wait()
+	if fetches != 1 {
+		t.Errorf("got %d fetches for concurrent waits, want 1", fetches)
+	}
+
+	// Results within the TTL are reused, older ones are not.
+	clk.Sleep(operationCacheTTL / 2)
+	oc.get("op", clk, fetch)
+	if fetches != 1 {
+		t.Errorf("got %d fetches within TTL, want 1", fetches)
+	}
+	clk.Sleep(operationCacheTTL / 2)
+	oc.get("op", clk, fetch)
+	if fetches != 2 {
+		t.Errorf("got %d fetches after TTL, want 2", fetches)
+	}
+}
+
+func TestOperationCacheDoesNotKeepDoneOrErrors(t *testing.T) {
+	oc := newOperationCache()
+	clk := NewFakeClock(time.Now())
+	var fetches int
+	result := &compute.Operation{Name: "op", Status: OpStatusDone}
+	var resultErr error
+	fetch := func() (*compute.Operation, error) {
+		fetches++
+		return result, resultErr
+	}
+
+	oc.get("op", clk, fetch)
+	oc.get("op", clk, fetch)
+	if fetches != 2 {
+		t.Errorf("got %d fetches for a done operation, want 2", fetches)
+	}
+
+	result, resultErr = nil, errors.New("get failed")
+	oc.get("op", clk, fetch)
+	oc.get("op", clk, fetch)
+	if fetches != 4 {
+		t.Errorf("got %d fetches after errors, want 4", fetches)
+	}
+
+	var nilCache *operationCache
+	nilCache.get("op", clk, fetch)
+	if fetches != 5 {
+		t.Errorf("got %d fetches with a nil cache, want 5", fetches)
+	}
+}
+
+func TestOperationCacheTTLStartsAfterFetch(t *testing.T) {
+	oc := newOperationCache()
+	clk := NewFakeClock(time.Now())
+	var fetches int
+	fetch := func() (*compute.Operation, error) {
+		fetches++
+		// A blocking operations.wait call that takes minutes to return.
+		clk.Sleep(2 * time.Minute)
+		return &compute.Operation{Name: "op", Status: OpStatusRunning}, nil
+	}
+
+	oc.get("op", clk, fetch)
+	oc.get("op", clk, fetch)
+	if fetches != 1 {
+		t.Errorf("got %d fetches right after a slow poll, want 1", fetches)
+	}
+}
+
+func TestOperationCachePrunesExpiredEntries(t *testing.T) {
+	oc := newOperationCache()
+	clk := NewFakeClock(time.Now())
+	fetch := func() (*compute.Operation, error) {
+		return &compute.Operation{Name: "op", Status: OpStatusRunning}, nil
+	}
+
+	// Nobody polls "abandoned" again once its waiter gives up.
+	oc.get("abandoned", clk, fetch)
+	clk.Sleep(operationCacheTTL)
+	oc.get("other", clk, fetch)
+	if _, ok := oc.entries["abandoned"]; ok {
+		t.Error("expired entry for a running operation was not removed")
+	}
+	if _, ok := oc.entries["other"]; !ok {
+		t.Error("fresh entry for a running operation was removed")
+	}
+}
@@ -0,0 +1,144 @@
+//  Copyright 2020 Google Inc. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package compute
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/api/compute/v1"
+)
+
+// maxOperationsPerList is the number of operation names combined into a
+// single list filter.
+const maxOperationsPerList = 50
+
+// WithBatchedOperationPolling makes the client share operation polling between
+// concurrent waits. Instead of each wait polling its own operation, waits in
+// the same project and zone, region or global scope are served by a single
+// operations.list call per interval.
+func WithBatchedOperationPolling() Option {
+	return func(c *client) {
+		c.opPollers = &operationPollers{interval: 1 * time.Second, pollers: map[string]*operationPoller{}}
+	}
+}
+
+type operationListerFunc func(filter string) ([]*compute.Operation, error)
+
+type operationResult struct {
+	op  *compute.Operation
+	err error
+}
+
+// operationPollers holds one operationPoller per operation scope.
+type operationPollers struct {
+	interval time.Duration
+
+	mu      sync.Mutex
+	pollers map[string]*operationPoller
+}
+
+// poller returns the poller for the given scope, creating it if needed. The
+// poller sleeps on clk between lists.
+func (ps *operationPollers) poller(scope string, clk Clock, list operationListerFunc) *operationPoller {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	p, ok := ps.pollers[scope]
+	if !ok {
+		p = &operationPoller{interval: ps.interval, clock: clk, list: list, waiters: map[string][]chan operationResult{}}
+		ps.pollers[scope] = p
+	}
+	return p
+}
+
+// operationPoller polls all operations waited on in a single scope.
+type operationPoller struct {
+	interval time.Duration
+	clock    Clock
+	list     operationListerFunc
+
+	mu      sync.Mutex
+	waiters map[string][]chan operationResult
+	polling bool
+}
+
+// get returns the state of the named operation as of the next poll.
+func (p *operationPoller) get(name string) (*compute.Operation, error) {
+	ch := make(chan operationResult, 1)
+	p.mu.Lock()
+	p.waiters[name] = append(p.waiters[name], ch)
+	if !p.polling {
+		p.polling = true
+		go p.poll()
+	}
+	p.mu.Unlock()
+
+	r := <-ch
+	return r.op, r.err
+}
+
+// poll lists the operations being waited on until there are no waiters left.
+func (p *operationPoller) poll() {
+	for {
+		p.clock.Sleep(p.interval)
+
+		p.mu.Lock()
+		if len(p.waiters) == 0 {
+			p.polling = false
+			p.mu.Unlock()
+			return
+		}
+		waiters := p.waiters
+		p.waiters = map[string][]chan operationResult{}
+		p.mu.Unlock()
+
+		var names []string
+		for name := range waiters {
+			names = append(names, name)
+		}
+		for i := 0; i < len(names); i += maxOperationsPerList {
+			end := i + maxOperationsPerList
+			if end > len(names) {
+				end = len(names)
+			}
+			p.deliver(names[i:end], waiters)
+		}
+	}
+}
+
+// deliver lists the named operations and sends the results to their waiters.
+func (p *operationPoller) deliver(names []string, waiters map[string][]chan operationResult) {
+	var filters []string
+	for _, name := range names {
+		filters = append(filters, fmt.Sprintf("(name = %q)", name))
+	}
+	ops, err := p.list(strings.Join(filters, " OR "))
+
+	found := map[string]*compute.Operation{}
+	for _, op := range ops {
+		found[op.Name] = op
+	}
+	for _, name := range names {
+		r := operationResult{op: found[name], err: err}
+		if err == nil && r.op == nil {
+			r.err = fmt.Errorf("operation %s not found", name)
+		}
+		for _, ch := range waiters[name] {
+			ch <- r
+		}
+	}
+}
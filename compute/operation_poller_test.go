@@ -0,0 +1,114 @@
+//  Copyright 2020 Google Inc. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package compute
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/api/compute/v1"
+)
+
+var filterNameRegex = regexp.MustCompile(`name = "([^"]+)"`)
+
+func TestBatchedOperationPolling(t *testing.T) {
+	var mu sync.Mutex
+	var lists, waits int
+	polls := map[string]int{}
+	svr, c, err := NewTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch r.URL.Path {
+		case fmt.Sprintf("/projects/%s/zones/%s/operations", testProject, testZone):
+			lists++
+			ol := &compute.OperationList{}
+			for _, m := range filterNameRegex.FindAllStringSubmatch(r.URL.Query().Get("filter"), -1) {
+				name := m[1]
+				polls[name]++
+				op := &compute.Operation{Name: name, Status: "RUNNING"}
+				if polls[name] > 1 {
+					op.Status = "DONE"
+				}
+				if name == "failed" {
+					op.Status = "DONE"
+					op.Error = &compute.OperationError{Errors: []*compute.OperationErrorErrors{{Code: "QUOTA_EXCEEDED"}}}
+				}
+				if name != "missing" {
+					ol.Items = append(ol.Items, op)
+				}
+			}
+			json.NewEncoder(w).Encode(ol)
+		default:
+			waits++
+			w.WriteHeader(500)
+			fmt.Fprintln(w, "URL and Method not recognized:", r.Method, r.URL)
+		}
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer svr.Close()
+	WithBatchedOperationPolling()(&c.client)
+	// The poller sleeps on the client's fake clock, so an hour-long interval
+	// passes at once.
+	c.opPollers.interval = time.Hour
+	clk := NewFakeClock(time.Now())
+	c.client.clock = clk
+
+	// The poller's interval is the only sleep between polls.
+	if err := c.zoneOperationsWait(testProject, testZone, "single"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if slept := clk.Slept(); slept < 2*time.Hour || slept%time.Hour != 0 {
+		t.Errorf("got %s of sleep waiting on one operation, want only whole poller intervals of %s", slept, time.Hour)
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, 20)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = c.zoneOperationsWait(testProject, testZone, fmt.Sprintf("op-%d", i))
+		}(i)
+	}
+	wg.Wait()
+	for i, err := range errs {
+		if err != nil {
+			t.Errorf("op-%d: unexpected error: %v", i, err)
+		}
+	}
+	if lists >= len(errs) {
+		t.Errorf("want fewer list calls than operations, got %d list calls for %d operations", lists, len(errs))
+	}
+	if waits != 0 {
+		t.Errorf("want no per-operation calls, got %d", waits)
+	}
+
+	err = c.zoneOperationsWait(testProject, testZone, "failed")
+	var opErr *OperationError
+	if !errors.As(err, &opErr) || !opErr.HasCode("QUOTA_EXCEEDED") {
+		t.Errorf("want OperationError with QUOTA_EXCEEDED, got: %v", err)
+	}
+	if err := c.zoneOperationsWait(testProject, testZone, "missing"); err == nil {
+		t.Error("expected error waiting on an operation that cannot be found")
+	}
+}
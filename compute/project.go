@@ -0,0 +1,52 @@
+//  Copyright 2020 Google Inc. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+//go:generate go run gen_project_client.go
+
+package compute
+
+// WithDefaultProject sets the project that the client's DefaultProject
+// methods act on.
+func WithDefaultProject(project string) Option {
+	return func(c *client) {
+		c.defaultProject = project
+	}
+}
+
+// ProjectClient calls the methods of a Client that take a project with a
+// fixed project, so that the project need not be repeated. Each method has the
+// same name as the Client method it calls, without the project argument.
+type ProjectClient struct {
+	c       Client
+	project string
+}
+
+// NewProjectClient returns a ProjectClient that calls c with project.
+func NewProjectClient(c Client, project string) *ProjectClient {
+	return &ProjectClient{c: c, project: project}
+}
+
+// Project returns the project that pc acts on.
+func (pc *ProjectClient) Project() string {
+	return pc.project
+}
+
+// DefaultProject returns a ProjectClient for the project set with
+// WithDefaultProject, or nil if none was set.
+func (c *client) DefaultProject() *ProjectClient {
+	if c.defaultProject == "" {
+		return nil
+	}
+	return NewProjectClient(c.i, c.defaultProject)
+}
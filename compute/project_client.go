@@ -0,0 +1,1100 @@
+//  Copyright 2020 Google Inc. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+// Code generated by gen_project_client.go. DO NOT EDIT.
+
+package compute
+
+import (
+	"time"
+
+	computeAlpha "google.golang.org/api/compute/v0.alpha"
+	computeBeta "google.golang.org/api/compute/v0.beta"
+	"google.golang.org/api/compute/v1"
+)
+
+// AttachDisk calls Client.AttachDisk with pc's project.
+func (pc *ProjectClient) AttachDisk(zone string, instance string, d *compute.AttachedDisk) error {
+	return pc.c.AttachDisk(pc.project, zone, instance, d)
+}
+
+// DetachDisk calls Client.DetachDisk with pc's project.
+func (pc *ProjectClient) DetachDisk(zone string, instance string, disk string) error {
+	return pc.c.DetachDisk(pc.project, zone, instance, disk)
+}
+
+// CreateDiskFromImageAndAttach calls Client.CreateDiskFromImageAndAttach with pc's project.
+func (pc *ProjectClient) CreateDiskFromImageAndAttach(zone string, instance string, image string, d *compute.Disk) error {
+	return pc.c.CreateDiskFromImageAndAttach(pc.project, zone, instance, image, d)
+}
+
+// CreateDiskFromSnapshot calls Client.CreateDiskFromSnapshot with pc's project.
+func (pc *ProjectClient) CreateDiskFromSnapshot(zone string, diskName string, snapshotSelfLink string, sizeGb int64, diskType string) error {
+	return pc.c.CreateDiskFromSnapshot(pc.project, zone, diskName, snapshotSelfLink, sizeGb, diskType)
+}
+
+// CloneDiskReencrypt calls Client.CloneDiskReencrypt with pc's project.
+func (pc *ProjectClient) CloneDiskReencrypt(zone string, sourceDisk string, diskName string, key *compute.CustomerEncryptionKey) error {
+	return pc.c.CloneDiskReencrypt(pc.project, zone, sourceDisk, diskName, key)
+}
+
+// CreateDisk calls Client.CreateDisk with pc's project.
+func (pc *ProjectClient) CreateDisk(zone string, d *compute.Disk) error {
+	return pc.c.CreateDisk(pc.project, zone, d)
+}
+
+// CreateDiskAlpha calls Client.CreateDiskAlpha with pc's project.
+func (pc *ProjectClient) CreateDiskAlpha(zone string, d *computeAlpha.Disk) error {
+	return pc.c.CreateDiskAlpha(pc.project, zone, d)
+}
+
+// CreateDiskBeta calls Client.CreateDiskBeta with pc's project.
+func (pc *ProjectClient) CreateDiskBeta(zone string, d *computeBeta.Disk) error {
+	return pc.c.CreateDiskBeta(pc.project, zone, d)
+}
+
+// CreateForwardingRule calls Client.CreateForwardingRule with pc's project.
+func (pc *ProjectClient) CreateForwardingRule(region string, fr *compute.ForwardingRule) error {
+	return pc.c.CreateForwardingRule(pc.project, region, fr)
+}
+
+// CreateFirewallRule calls Client.CreateFirewallRule with pc's project.
+func (pc *ProjectClient) CreateFirewallRule(i *compute.Firewall) error {
+	return pc.c.CreateFirewallRule(pc.project, i)
+}
+
+// CreateFirewallRuleBeta calls Client.CreateFirewallRuleBeta with pc's project.
+func (pc *ProjectClient) CreateFirewallRuleBeta(fw *computeBeta.Firewall) error {
+	return pc.c.CreateFirewallRuleBeta(pc.project, fw)
+}
+
+// PatchFirewallRuleBeta calls Client.PatchFirewallRuleBeta with pc's project.
+func (pc *ProjectClient) PatchFirewallRuleBeta(name string, fw *computeBeta.Firewall) error {
+	return pc.c.PatchFirewallRuleBeta(pc.project, name, fw)
+}
+
+// CreateImage calls Client.CreateImage with pc's project.
+func (pc *ProjectClient) CreateImage(i *compute.Image) error {
+	return pc.c.CreateImage(pc.project, i)
+}
+
+// CreateImageAlpha calls Client.CreateImageAlpha with pc's project.
+func (pc *ProjectClient) CreateImageAlpha(i *computeAlpha.Image) error {
+	return pc.c.CreateImageAlpha(pc.project, i)
+}
+
+// CreateImageBeta calls Client.CreateImageBeta with pc's project.
+func (pc *ProjectClient) CreateImageBeta(i *computeBeta.Image) error {
+	return pc.c.CreateImageBeta(pc.project, i)
+}
+
+// CreateImageFromEncryptedDisk calls Client.CreateImageFromEncryptedDisk with pc's project.
+func (pc *ProjectClient) CreateImageFromEncryptedDisk(im *compute.Image, diskKey *compute.CustomerEncryptionKey) error {
+	return pc.c.CreateImageFromEncryptedDisk(pc.project, im, diskKey)
+}
+
+// CreateInstance calls Client.CreateInstance with pc's project.
+func (pc *ProjectClient) CreateInstance(zone string, i *compute.Instance) error {
+	return pc.c.CreateInstance(pc.project, zone, i)
+}
+
+// CreateInstanceAlpha calls Client.CreateInstanceAlpha with pc's project.
+func (pc *ProjectClient) CreateInstanceAlpha(zone string, i *computeAlpha.Instance) error {
+	return pc.c.CreateInstanceAlpha(pc.project, zone, i)
+}
+
+// CreateInstanceBeta calls Client.CreateInstanceBeta with pc's project.
+func (pc *ProjectClient) CreateInstanceBeta(zone string, i *computeBeta.Instance) error {
+	return pc.c.CreateInstanceBeta(pc.project, zone, i)
+}
+
+// CreateInstanceInZones calls Client.CreateInstanceInZones with pc's project.
+func (pc *ProjectClient) CreateInstanceInZones(zones []string, i *compute.Instance) (string, error) {
+	return pc.c.CreateInstanceInZones(pc.project, zones, i)
+}
+
+// CreateInstanceIfNotExists calls Client.CreateInstanceIfNotExists with pc's project.
+func (pc *ProjectClient) CreateInstanceIfNotExists(zone string, i *compute.Instance) (bool, error) {
+	return pc.c.CreateInstanceIfNotExists(pc.project, zone, i)
+}
+
+// CreateInstanceAndGet calls Client.CreateInstanceAndGet with pc's project.
+func (pc *ProjectClient) CreateInstanceAndGet(zone string, i *compute.Instance) (*compute.Instance, error) {
+	return pc.c.CreateInstanceAndGet(pc.project, zone, i)
+}
+
+// CreateInstanceFromMachineImage calls Client.CreateInstanceFromMachineImage with pc's project.
+func (pc *ProjectClient) CreateInstanceFromMachineImage(zone string, machineImage string, i *compute.Instance) error {
+	return pc.c.CreateInstanceFromMachineImage(pc.project, zone, machineImage, i)
+}
+
+// BulkInsertInstance calls Client.BulkInsertInstance with pc's project.
+func (pc *ProjectClient) BulkInsertInstance(zone string, r *compute.BulkInsertInstanceResource) error {
+	return pc.c.BulkInsertInstance(pc.project, zone, r)
+}
+
+// GetBulkInsertInstanceResult calls Client.GetBulkInsertInstanceResult with pc's project.
+func (pc *ProjectClient) GetBulkInsertInstanceResult(zone string, r *compute.BulkInsertInstanceResource) ([]string, []string, error) {
+	return pc.c.GetBulkInsertInstanceResult(pc.project, zone, r)
+}
+
+// CreateNetwork calls Client.CreateNetwork with pc's project.
+func (pc *ProjectClient) CreateNetwork(n *compute.Network) error {
+	return pc.c.CreateNetwork(pc.project, n)
+}
+
+// CreateSnapshot calls Client.CreateSnapshot with pc's project.
+func (pc *ProjectClient) CreateSnapshot(zone string, disk string, s *compute.Snapshot) error {
+	return pc.c.CreateSnapshot(pc.project, zone, disk, s)
+}
+
+// CreateSubnetwork calls Client.CreateSubnetwork with pc's project.
+func (pc *ProjectClient) CreateSubnetwork(region string, n *compute.Subnetwork) error {
+	return pc.c.CreateSubnetwork(pc.project, region, n)
+}
+
+// CreateTargetInstance calls Client.CreateTargetInstance with pc's project.
+func (pc *ProjectClient) CreateTargetInstance(zone string, ti *compute.TargetInstance) error {
+	return pc.c.CreateTargetInstance(pc.project, zone, ti)
+}
+
+// DeleteDisk calls Client.DeleteDisk with pc's project.
+func (pc *ProjectClient) DeleteDisk(zone string, name string) error {
+	return pc.c.DeleteDisk(pc.project, zone, name)
+}
+
+// DeleteRegionDisk calls Client.DeleteRegionDisk with pc's project.
+func (pc *ProjectClient) DeleteRegionDisk(region string, name string) error {
+	return pc.c.DeleteRegionDisk(pc.project, region, name)
+}
+
+// DeleteForwardingRule calls Client.DeleteForwardingRule with pc's project.
+func (pc *ProjectClient) DeleteForwardingRule(region string, name string) error {
+	return pc.c.DeleteForwardingRule(pc.project, region, name)
+}
+
+// DeleteFirewallRule calls Client.DeleteFirewallRule with pc's project.
+func (pc *ProjectClient) DeleteFirewallRule(name string) error {
+	return pc.c.DeleteFirewallRule(pc.project, name)
+}
+
+// DeleteImage calls Client.DeleteImage with pc's project.
+func (pc *ProjectClient) DeleteImage(name string) error {
+	return pc.c.DeleteImage(pc.project, name)
+}
+
+// DeleteInstance calls Client.DeleteInstance with pc's project.
+func (pc *ProjectClient) DeleteInstance(zone string, name string) error {
+	return pc.c.DeleteInstance(pc.project, zone, name)
+}
+
+// DeleteInstancesByFilter calls Client.DeleteInstancesByFilter with pc's project.
+func (pc *ProjectClient) DeleteInstancesByFilter(zone string, filter string) error {
+	return pc.c.DeleteInstancesByFilter(pc.project, zone, filter)
+}
+
+// TeardownByLabel calls Client.TeardownByLabel with pc's project.
+func (pc *ProjectClient) TeardownByLabel(labelKey string, labelValue string) error {
+	return pc.c.TeardownByLabel(pc.project, labelKey, labelValue)
+}
+
+// SetDeletionProtection calls Client.SetDeletionProtection with pc's project.
+func (pc *ProjectClient) SetDeletionProtection(zone string, instance string, enabled bool) error {
+	return pc.c.SetDeletionProtection(pc.project, zone, instance, enabled)
+}
+
+// UpdateNetworkInterface calls Client.UpdateNetworkInterface with pc's project.
+func (pc *ProjectClient) UpdateNetworkInterface(zone string, instance string, networkInterface string, ni *compute.NetworkInterface) error {
+	return pc.c.UpdateNetworkInterface(pc.project, zone, instance, networkInterface, ni)
+}
+
+// GetShieldedInstanceIdentity calls Client.GetShieldedInstanceIdentity with pc's project.
+func (pc *ProjectClient) GetShieldedInstanceIdentity(zone string, instance string) (*compute.ShieldedInstanceIdentity, error) {
+	return pc.c.GetShieldedInstanceIdentity(pc.project, zone, instance)
+}
+
+// GetEffectiveFirewalls calls Client.GetEffectiveFirewalls with pc's project.
+func (pc *ProjectClient) GetEffectiveFirewalls(zone string, instance string, networkInterface string) (*compute.InstancesGetEffectiveFirewallsResponse, error) {
+	return pc.c.GetEffectiveFirewalls(pc.project, zone, instance, networkInterface)
+}
+
+// GetScreenshot calls Client.GetScreenshot with pc's project.
+func (pc *ProjectClient) GetScreenshot(zone string, instance string) (*compute.Screenshot, error) {
+	return pc.c.GetScreenshot(pc.project, zone, instance)
+}
+
+// GetInstanceIamPolicy calls Client.GetInstanceIamPolicy with pc's project.
+func (pc *ProjectClient) GetInstanceIamPolicy(zone string, instance string) (*compute.Policy, error) {
+	return pc.c.GetInstanceIamPolicy(pc.project, zone, instance)
+}
+
+// SetInstanceIamPolicy calls Client.SetInstanceIamPolicy with pc's project.
+func (pc *ProjectClient) SetInstanceIamPolicy(zone string, instance string, policy *compute.Policy) error {
+	return pc.c.SetInstanceIamPolicy(pc.project, zone, instance, policy)
+}
+
+// TestInstanceIamPermissions calls Client.TestInstanceIamPermissions with pc's project.
+func (pc *ProjectClient) TestInstanceIamPermissions(zone string, instance string, permissions []string) ([]string, error) {
+	return pc.c.TestInstanceIamPermissions(pc.project, zone, instance, permissions)
+}
+
+// SetShieldedInstanceIntegrityPolicy calls Client.SetShieldedInstanceIntegrityPolicy with pc's project.
+func (pc *ProjectClient) SetShieldedInstanceIntegrityPolicy(zone string, instance string, p *compute.ShieldedInstanceIntegrityPolicy) error {
+	return pc.c.SetShieldedInstanceIntegrityPolicy(pc.project, zone, instance, p)
+}
+
+// StartInstance calls Client.StartInstance with pc's project.
+func (pc *ProjectClient) StartInstance(zone string, name string) error {
+	return pc.c.StartInstance(pc.project, zone, name)
+}
+
+// StopInstance calls Client.StopInstance with pc's project.
+func (pc *ProjectClient) StopInstance(zone string, name string) error {
+	return pc.c.StopInstance(pc.project, zone, name)
+}
+
+// StopInstanceWithOptions calls Client.StopInstanceWithOptions with pc's project.
+func (pc *ProjectClient) StopInstanceWithOptions(zone string, name string, discardLocalSSD bool) error {
+	return pc.c.StopInstanceWithOptions(pc.project, zone, name, discardLocalSSD)
+}
+
+// DeleteNetwork calls Client.DeleteNetwork with pc's project.
+func (pc *ProjectClient) DeleteNetwork(name string) error {
+	return pc.c.DeleteNetwork(pc.project, name)
+}
+
+// DeleteSubnetwork calls Client.DeleteSubnetwork with pc's project.
+func (pc *ProjectClient) DeleteSubnetwork(region string, name string) error {
+	return pc.c.DeleteSubnetwork(pc.project, region, name)
+}
+
+// DeleteTargetInstance calls Client.DeleteTargetInstance with pc's project.
+func (pc *ProjectClient) DeleteTargetInstance(zone string, name string) error {
+	return pc.c.DeleteTargetInstance(pc.project, zone, name)
+}
+
+// DeprecateImage calls Client.DeprecateImage with pc's project.
+func (pc *ProjectClient) DeprecateImage(name string, deprecationstatus *compute.DeprecationStatus) error {
+	return pc.c.DeprecateImage(pc.project, name, deprecationstatus)
+}
+
+// DeprecateImageAlpha calls Client.DeprecateImageAlpha with pc's project.
+func (pc *ProjectClient) DeprecateImageAlpha(name string, deprecationstatus *computeAlpha.DeprecationStatus) error {
+	return pc.c.DeprecateImageAlpha(pc.project, name, deprecationstatus)
+}
+
+// DeprecateImageFamily calls Client.DeprecateImageFamily with pc's project.
+func (pc *ProjectClient) DeprecateImageFamily(family string, keepLatest int, status *compute.DeprecationStatus) error {
+	return pc.c.DeprecateImageFamily(pc.project, family, keepLatest, status)
+}
+
+// GetMachineType calls Client.GetMachineType with pc's project.
+func (pc *ProjectClient) GetMachineType(zone string, machineType string) (*compute.MachineType, error) {
+	return pc.c.GetMachineType(pc.project, zone, machineType)
+}
+
+// GetAcceleratorType calls Client.GetAcceleratorType with pc's project.
+func (pc *ProjectClient) GetAcceleratorType(zone string, acceleratorType string) (*compute.AcceleratorType, error) {
+	return pc.c.GetAcceleratorType(pc.project, zone, acceleratorType)
+}
+
+// GetReservation calls Client.GetReservation with pc's project.
+func (pc *ProjectClient) GetReservation(zone string, name string) (*compute.Reservation, error) {
+	return pc.c.GetReservation(pc.project, zone, name)
+}
+
+// GetProject calls Client.GetProject with pc's project.
+func (pc *ProjectClient) GetProject() (*compute.Project, error) {
+	return pc.c.GetProject(pc.project)
+}
+
+// GetSerialPortOutput calls Client.GetSerialPortOutput with pc's project.
+func (pc *ProjectClient) GetSerialPortOutput(zone string, name string, port int64, start int64) (*compute.SerialPortOutput, error) {
+	return pc.c.GetSerialPortOutput(pc.project, zone, name, port, start)
+}
+
+// SendDiagnosticInterrupt calls Client.SendDiagnosticInterrupt with pc's project.
+func (pc *ProjectClient) SendDiagnosticInterrupt(zone string, instance string) error {
+	return pc.c.SendDiagnosticInterrupt(pc.project, zone, instance)
+}
+
+// GetZone calls Client.GetZone with pc's project.
+func (pc *ProjectClient) GetZone(zone string) (*compute.Zone, error) {
+	return pc.c.GetZone(pc.project, zone)
+}
+
+// GetInstance calls Client.GetInstance with pc's project.
+func (pc *ProjectClient) GetInstance(zone string, name string) (*compute.Instance, error) {
+	return pc.c.GetInstance(pc.project, zone, name)
+}
+
+// GetInstanceAlpha calls Client.GetInstanceAlpha with pc's project.
+func (pc *ProjectClient) GetInstanceAlpha(zone string, name string) (*computeAlpha.Instance, error) {
+	return pc.c.GetInstanceAlpha(pc.project, zone, name)
+}
+
+// GetInstanceBeta calls Client.GetInstanceBeta with pc's project.
+func (pc *ProjectClient) GetInstanceBeta(zone string, name string) (*computeBeta.Instance, error) {
+	return pc.c.GetInstanceBeta(pc.project, zone, name)
+}
+
+// GetDisk calls Client.GetDisk with pc's project.
+func (pc *ProjectClient) GetDisk(zone string, name string) (*compute.Disk, error) {
+	return pc.c.GetDisk(pc.project, zone, name)
+}
+
+// GetRegionDisk calls Client.GetRegionDisk with pc's project.
+func (pc *ProjectClient) GetRegionDisk(region string, name string) (*compute.Disk, error) {
+	return pc.c.GetRegionDisk(pc.project, region, name)
+}
+
+// GetDiskAlpha calls Client.GetDiskAlpha with pc's project.
+func (pc *ProjectClient) GetDiskAlpha(zone string, name string) (*computeAlpha.Disk, error) {
+	return pc.c.GetDiskAlpha(pc.project, zone, name)
+}
+
+// GetDiskBeta calls Client.GetDiskBeta with pc's project.
+func (pc *ProjectClient) GetDiskBeta(zone string, name string) (*computeBeta.Disk, error) {
+	return pc.c.GetDiskBeta(pc.project, zone, name)
+}
+
+// GetForwardingRule calls Client.GetForwardingRule with pc's project.
+func (pc *ProjectClient) GetForwardingRule(region string, name string) (*compute.ForwardingRule, error) {
+	return pc.c.GetForwardingRule(pc.project, region, name)
+}
+
+// GetFirewallRule calls Client.GetFirewallRule with pc's project.
+func (pc *ProjectClient) GetFirewallRule(name string) (*compute.Firewall, error) {
+	return pc.c.GetFirewallRule(pc.project, name)
+}
+
+// GetFirewallRuleBeta calls Client.GetFirewallRuleBeta with pc's project.
+func (pc *ProjectClient) GetFirewallRuleBeta(name string) (*computeBeta.Firewall, error) {
+	return pc.c.GetFirewallRuleBeta(pc.project, name)
+}
+
+// GetGuestAttributes calls Client.GetGuestAttributes with pc's project.
+func (pc *ProjectClient) GetGuestAttributes(zone string, name string, queryPath string, variableKey string) (*compute.GuestAttributes, error) {
+	return pc.c.GetGuestAttributes(pc.project, zone, name, queryPath, variableKey)
+}
+
+// GetImage calls Client.GetImage with pc's project.
+func (pc *ProjectClient) GetImage(name string) (*compute.Image, error) {
+	return pc.c.GetImage(pc.project, name)
+}
+
+// GetImageAlpha calls Client.GetImageAlpha with pc's project.
+func (pc *ProjectClient) GetImageAlpha(name string) (*computeAlpha.Image, error) {
+	return pc.c.GetImageAlpha(pc.project, name)
+}
+
+// GetImageBeta calls Client.GetImageBeta with pc's project.
+func (pc *ProjectClient) GetImageBeta(name string) (*computeBeta.Image, error) {
+	return pc.c.GetImageBeta(pc.project, name)
+}
+
+// GetImageFromFamily calls Client.GetImageFromFamily with pc's project.
+func (pc *ProjectClient) GetImageFromFamily(family string) (*compute.Image, error) {
+	return pc.c.GetImageFromFamily(pc.project, family)
+}
+
+// GetLicense calls Client.GetLicense with pc's project.
+func (pc *ProjectClient) GetLicense(name string) (*compute.License, error) {
+	return pc.c.GetLicense(pc.project, name)
+}
+
+// GetInstanceTemplate calls Client.GetInstanceTemplate with pc's project.
+func (pc *ProjectClient) GetInstanceTemplate(name string) (*compute.InstanceTemplate, error) {
+	return pc.c.GetInstanceTemplate(pc.project, name)
+}
+
+// GetInstanceGroupManager calls Client.GetInstanceGroupManager with pc's project.
+func (pc *ProjectClient) GetInstanceGroupManager(zone string, name string) (*compute.InstanceGroupManager, error) {
+	return pc.c.GetInstanceGroupManager(pc.project, zone, name)
+}
+
+// GetRegionInstanceGroupManager calls Client.GetRegionInstanceGroupManager with pc's project.
+func (pc *ProjectClient) GetRegionInstanceGroupManager(region string, name string) (*compute.InstanceGroupManager, error) {
+	return pc.c.GetRegionInstanceGroupManager(pc.project, region, name)
+}
+
+// WaitForInstanceGroupManagerStable calls Client.WaitForInstanceGroupManagerStable with pc's project.
+func (pc *ProjectClient) WaitForInstanceGroupManagerStable(zone string, name string, timeout time.Duration) error {
+	return pc.c.WaitForInstanceGroupManagerStable(pc.project, zone, name, timeout)
+}
+
+// WaitForRegionInstanceGroupManagerStable calls Client.WaitForRegionInstanceGroupManagerStable with pc's project.
+func (pc *ProjectClient) WaitForRegionInstanceGroupManagerStable(region string, name string, timeout time.Duration) error {
+	return pc.c.WaitForRegionInstanceGroupManagerStable(pc.project, region, name, timeout)
+}
+
+// GetNetwork calls Client.GetNetwork with pc's project.
+func (pc *ProjectClient) GetNetwork(name string) (*compute.Network, error) {
+	return pc.c.GetNetwork(pc.project, name)
+}
+
+// GetRegion calls Client.GetRegion with pc's project.
+func (pc *ProjectClient) GetRegion(region string) (*compute.Region, error) {
+	return pc.c.GetRegion(pc.project, region)
+}
+
+// GetSubnetwork calls Client.GetSubnetwork with pc's project.
+func (pc *ProjectClient) GetSubnetwork(region string, name string) (*compute.Subnetwork, error) {
+	return pc.c.GetSubnetwork(pc.project, region, name)
+}
+
+// GetTargetInstance calls Client.GetTargetInstance with pc's project.
+func (pc *ProjectClient) GetTargetInstance(zone string, name string) (*compute.TargetInstance, error) {
+	return pc.c.GetTargetInstance(pc.project, zone, name)
+}
+
+// GetBackendService calls Client.GetBackendService with pc's project.
+func (pc *ProjectClient) GetBackendService(name string) (*compute.BackendService, error) {
+	return pc.c.GetBackendService(pc.project, name)
+}
+
+// GetBackendServiceHealth calls Client.GetBackendServiceHealth with pc's project.
+func (pc *ProjectClient) GetBackendServiceHealth(backendService string, group string) (*compute.BackendServiceGroupHealth, error) {
+	return pc.c.GetBackendServiceHealth(pc.project, backendService, group)
+}
+
+// GetHealthCheck calls Client.GetHealthCheck with pc's project.
+func (pc *ProjectClient) GetHealthCheck(name string) (*compute.HealthCheck, error) {
+	return pc.c.GetHealthCheck(pc.project, name)
+}
+
+// CreateHTTPHealthCheck calls Client.CreateHTTPHealthCheck with pc's project.
+func (pc *ProjectClient) CreateHTTPHealthCheck(hc *compute.HttpHealthCheck) error {
+	return pc.c.CreateHTTPHealthCheck(pc.project, hc)
+}
+
+// GetHTTPHealthCheck calls Client.GetHTTPHealthCheck with pc's project.
+func (pc *ProjectClient) GetHTTPHealthCheck(name string) (*compute.HttpHealthCheck, error) {
+	return pc.c.GetHTTPHealthCheck(pc.project, name)
+}
+
+// DeleteHTTPHealthCheck calls Client.DeleteHTTPHealthCheck with pc's project.
+func (pc *ProjectClient) DeleteHTTPHealthCheck(name string) error {
+	return pc.c.DeleteHTTPHealthCheck(pc.project, name)
+}
+
+// CreateHTTPSHealthCheck calls Client.CreateHTTPSHealthCheck with pc's project.
+func (pc *ProjectClient) CreateHTTPSHealthCheck(hc *compute.HttpsHealthCheck) error {
+	return pc.c.CreateHTTPSHealthCheck(pc.project, hc)
+}
+
+// GetHTTPSHealthCheck calls Client.GetHTTPSHealthCheck with pc's project.
+func (pc *ProjectClient) GetHTTPSHealthCheck(name string) (*compute.HttpsHealthCheck, error) {
+	return pc.c.GetHTTPSHealthCheck(pc.project, name)
+}
+
+// DeleteHTTPSHealthCheck calls Client.DeleteHTTPSHealthCheck with pc's project.
+func (pc *ProjectClient) DeleteHTTPSHealthCheck(name string) error {
+	return pc.c.DeleteHTTPSHealthCheck(pc.project, name)
+}
+
+// CreateSecurityPolicy calls Client.CreateSecurityPolicy with pc's project.
+func (pc *ProjectClient) CreateSecurityPolicy(sp *compute.SecurityPolicy) error {
+	return pc.c.CreateSecurityPolicy(pc.project, sp)
+}
+
+// GetSecurityPolicy calls Client.GetSecurityPolicy with pc's project.
+func (pc *ProjectClient) GetSecurityPolicy(name string) (*compute.SecurityPolicy, error) {
+	return pc.c.GetSecurityPolicy(pc.project, name)
+}
+
+// DeleteSecurityPolicy calls Client.DeleteSecurityPolicy with pc's project.
+func (pc *ProjectClient) DeleteSecurityPolicy(name string) error {
+	return pc.c.DeleteSecurityPolicy(pc.project, name)
+}
+
+// AddSecurityPolicyRule calls Client.AddSecurityPolicyRule with pc's project.
+func (pc *ProjectClient) AddSecurityPolicyRule(securityPolicy string, r *compute.SecurityPolicyRule) error {
+	return pc.c.AddSecurityPolicyRule(pc.project, securityPolicy, r)
+}
+
+// PatchSecurityPolicyRule calls Client.PatchSecurityPolicyRule with pc's project.
+func (pc *ProjectClient) PatchSecurityPolicyRule(securityPolicy string, priority int64, r *compute.SecurityPolicyRule) error {
+	return pc.c.PatchSecurityPolicyRule(pc.project, securityPolicy, priority, r)
+}
+
+// CreatePacketMirroring calls Client.CreatePacketMirroring with pc's project.
+func (pc *ProjectClient) CreatePacketMirroring(region string, pm *compute.PacketMirroring) error {
+	return pc.c.CreatePacketMirroring(pc.project, region, pm)
+}
+
+// GetPacketMirroring calls Client.GetPacketMirroring with pc's project.
+func (pc *ProjectClient) GetPacketMirroring(region string, name string) (*compute.PacketMirroring, error) {
+	return pc.c.GetPacketMirroring(pc.project, region, name)
+}
+
+// DeletePacketMirroring calls Client.DeletePacketMirroring with pc's project.
+func (pc *ProjectClient) DeletePacketMirroring(region string, name string) error {
+	return pc.c.DeletePacketMirroring(pc.project, region, name)
+}
+
+// GetURLMap calls Client.GetURLMap with pc's project.
+func (pc *ProjectClient) GetURLMap(name string) (*compute.UrlMap, error) {
+	return pc.c.GetURLMap(pc.project, name)
+}
+
+// GetTargetHTTPProxy calls Client.GetTargetHTTPProxy with pc's project.
+func (pc *ProjectClient) GetTargetHTTPProxy(name string) (*compute.TargetHttpProxy, error) {
+	return pc.c.GetTargetHTTPProxy(pc.project, name)
+}
+
+// GetNetworkEndpointGroup calls Client.GetNetworkEndpointGroup with pc's project.
+func (pc *ProjectClient) GetNetworkEndpointGroup(zone string, name string) (*compute.NetworkEndpointGroup, error) {
+	return pc.c.GetNetworkEndpointGroup(pc.project, zone, name)
+}
+
+// InstanceStatus calls Client.InstanceStatus with pc's project.
+func (pc *ProjectClient) InstanceStatus(zone string, name string) (string, error) {
+	return pc.c.InstanceStatus(pc.project, zone, name)
+}
+
+// InstanceStopped calls Client.InstanceStopped with pc's project.
+func (pc *ProjectClient) InstanceStopped(zone string, name string) (bool, error) {
+	return pc.c.InstanceStopped(pc.project, zone, name)
+}
+
+// WaitForInstanceRunning calls Client.WaitForInstanceRunning with pc's project.
+func (pc *ProjectClient) WaitForInstanceRunning(zone string, name string, timeout time.Duration) error {
+	return pc.c.WaitForInstanceRunning(pc.project, zone, name, timeout)
+}
+
+// WaitForInstanceStopped calls Client.WaitForInstanceStopped with pc's project.
+func (pc *ProjectClient) WaitForInstanceStopped(zone string, name string, timeout time.Duration) error {
+	return pc.c.WaitForInstanceStopped(pc.project, zone, name, timeout)
+}
+
+// WaitForGuestAttribute calls Client.WaitForGuestAttribute with pc's project.
+func (pc *ProjectClient) WaitForGuestAttribute(zone string, instance string, namespace string, key string, wantValue string, timeout time.Duration) error {
+	return pc.c.WaitForGuestAttribute(pc.project, zone, instance, namespace, key, wantValue, timeout)
+}
+
+// WaitForOperationWithProgress calls Client.WaitForOperationWithProgress with pc's project.
+func (pc *ProjectClient) WaitForOperationWithProgress(selfLink string, onProgress func(percent int)) error {
+	return pc.c.WaitForOperationWithProgress(pc.project, selfLink, onProgress)
+}
+
+// WaitForOperationWithConfig calls Client.WaitForOperationWithConfig with pc's project.
+func (pc *ProjectClient) WaitForOperationWithConfig(selfLink string, wc WaitConfig) error {
+	return pc.c.WaitForOperationWithConfig(pc.project, selfLink, wc)
+}
+
+// DeleteOperation calls Client.DeleteOperation with pc's project.
+func (pc *ProjectClient) DeleteOperation(selfLink string) error {
+	return pc.c.DeleteOperation(pc.project, selfLink)
+}
+
+// ListZoneOperations calls Client.ListZoneOperations with pc's project.
+func (pc *ProjectClient) ListZoneOperations(zone string, opts ...ListCallOption) ([]*compute.Operation, error) {
+	return pc.c.ListZoneOperations(pc.project, zone, opts...)
+}
+
+// ListRegionOperations calls Client.ListRegionOperations with pc's project.
+func (pc *ProjectClient) ListRegionOperations(region string, opts ...ListCallOption) ([]*compute.Operation, error) {
+	return pc.c.ListRegionOperations(pc.project, region, opts...)
+}
+
+// ListGlobalOperations calls Client.ListGlobalOperations with pc's project.
+func (pc *ProjectClient) ListGlobalOperations(opts ...ListCallOption) ([]*compute.Operation, error) {
+	return pc.c.ListGlobalOperations(pc.project, opts...)
+}
+
+// GetOperationsForTarget calls Client.GetOperationsForTarget with pc's project.
+func (pc *ProjectClient) GetOperationsForTarget(scope string, targetSelfLink string) ([]*compute.Operation, error) {
+	return pc.c.GetOperationsForTarget(pc.project, scope, targetSelfLink)
+}
+
+// ListMachineTypes calls Client.ListMachineTypes with pc's project.
+func (pc *ProjectClient) ListMachineTypes(zone string, opts ...ListCallOption) ([]*compute.MachineType, error) {
+	return pc.c.ListMachineTypes(pc.project, zone, opts...)
+}
+
+// ListAcceleratorTypes calls Client.ListAcceleratorTypes with pc's project.
+func (pc *ProjectClient) ListAcceleratorTypes(zone string, opts ...ListCallOption) ([]*compute.AcceleratorType, error) {
+	return pc.c.ListAcceleratorTypes(pc.project, zone, opts...)
+}
+
+// ListLicenses calls Client.ListLicenses with pc's project.
+func (pc *ProjectClient) ListLicenses(opts ...ListCallOption) ([]*compute.License, error) {
+	return pc.c.ListLicenses(pc.project, opts...)
+}
+
+// ListZones calls Client.ListZones with pc's project.
+func (pc *ProjectClient) ListZones(opts ...ListCallOption) ([]*compute.Zone, error) {
+	return pc.c.ListZones(pc.project, opts...)
+}
+
+// PickZone calls Client.PickZone with pc's project.
+func (pc *ProjectClient) PickZone(region string, machineType string) (string, error) {
+	return pc.c.PickZone(pc.project, region, machineType)
+}
+
+// ListRegions calls Client.ListRegions with pc's project.
+func (pc *ProjectClient) ListRegions(opts ...ListCallOption) ([]*compute.Region, error) {
+	return pc.c.ListRegions(pc.project, opts...)
+}
+
+// AggregatedListInstances calls Client.AggregatedListInstances with pc's project.
+func (pc *ProjectClient) AggregatedListInstances(opts ...ListCallOption) ([]*compute.Instance, error) {
+	return pc.c.AggregatedListInstances(pc.project, opts...)
+}
+
+// AggregatedListInstancesByZone calls Client.AggregatedListInstancesByZone with pc's project.
+func (pc *ProjectClient) AggregatedListInstancesByZone(opts ...ListCallOption) (map[string][]*compute.Instance, error) {
+	return pc.c.AggregatedListInstancesByZone(pc.project, opts...)
+}
+
+// ListInstances calls Client.ListInstances with pc's project.
+func (pc *ProjectClient) ListInstances(zone string, opts ...ListCallOption) ([]*compute.Instance, error) {
+	return pc.c.ListInstances(pc.project, zone, opts...)
+}
+
+// ListInstancesByStatus calls Client.ListInstancesByStatus with pc's project.
+func (pc *ProjectClient) ListInstancesByStatus(zone string, status string) ([]*compute.Instance, error) {
+	return pc.c.ListInstancesByStatus(pc.project, zone, status)
+}
+
+// GetInstanceReferrers calls Client.GetInstanceReferrers with pc's project.
+func (pc *ProjectClient) GetInstanceReferrers(zone string, instance string) ([]*compute.Reference, error) {
+	return pc.c.GetInstanceReferrers(pc.project, zone, instance)
+}
+
+// ListDiskUsers calls Client.ListDiskUsers with pc's project.
+func (pc *ProjectClient) ListDiskUsers(zone string, disk string) ([]*compute.Instance, error) {
+	return pc.c.ListDiskUsers(pc.project, zone, disk)
+}
+
+// ListAttachedAccelerators calls Client.ListAttachedAccelerators with pc's project.
+func (pc *ProjectClient) ListAttachedAccelerators(zone string) (map[string][]*compute.AcceleratorConfig, error) {
+	return pc.c.ListAttachedAccelerators(pc.project, zone)
+}
+
+// AggregatedListDisks calls Client.AggregatedListDisks with pc's project.
+func (pc *ProjectClient) AggregatedListDisks(opts ...ListCallOption) ([]*compute.Disk, error) {
+	return pc.c.AggregatedListDisks(pc.project, opts...)
+}
+
+// AggregatedListDisksByZone calls Client.AggregatedListDisksByZone with pc's project.
+func (pc *ProjectClient) AggregatedListDisksByZone(opts ...ListCallOption) (map[string][]*compute.Disk, error) {
+	return pc.c.AggregatedListDisksByZone(pc.project, opts...)
+}
+
+// ListDisks calls Client.ListDisks with pc's project.
+func (pc *ProjectClient) ListDisks(zone string, opts ...ListCallOption) ([]*compute.Disk, error) {
+	return pc.c.ListDisks(pc.project, zone, opts...)
+}
+
+// ListUnattachedDisks calls Client.ListUnattachedDisks with pc's project.
+func (pc *ProjectClient) ListUnattachedDisks(zone string) ([]*compute.Disk, error) {
+	return pc.c.ListUnattachedDisks(pc.project, zone)
+}
+
+// DeleteUnattachedDisks calls Client.DeleteUnattachedDisks with pc's project.
+func (pc *ProjectClient) DeleteUnattachedDisks(zone string) error {
+	return pc.c.DeleteUnattachedDisks(pc.project, zone)
+}
+
+// AggregatedListForwardingRules calls Client.AggregatedListForwardingRules with pc's project.
+func (pc *ProjectClient) AggregatedListForwardingRules(opts ...ListCallOption) ([]*compute.ForwardingRule, error) {
+	return pc.c.AggregatedListForwardingRules(pc.project, opts...)
+}
+
+// ListForwardingRules calls Client.ListForwardingRules with pc's project.
+func (pc *ProjectClient) ListForwardingRules(zone string, opts ...ListCallOption) ([]*compute.ForwardingRule, error) {
+	return pc.c.ListForwardingRules(pc.project, zone, opts...)
+}
+
+// ListFirewallRules calls Client.ListFirewallRules with pc's project.
+func (pc *ProjectClient) ListFirewallRules(opts ...ListCallOption) ([]*compute.Firewall, error) {
+	return pc.c.ListFirewallRules(pc.project, opts...)
+}
+
+// ListImages calls Client.ListImages with pc's project.
+func (pc *ProjectClient) ListImages(opts ...ListCallOption) ([]*compute.Image, error) {
+	return pc.c.ListImages(pc.project, opts...)
+}
+
+// ListImagesAlpha calls Client.ListImagesAlpha with pc's project.
+func (pc *ProjectClient) ListImagesAlpha(opts ...ListCallOption) ([]*computeAlpha.Image, error) {
+	return pc.c.ListImagesAlpha(pc.project, opts...)
+}
+
+// GetSnapshot calls Client.GetSnapshot with pc's project.
+func (pc *ProjectClient) GetSnapshot(name string) (*compute.Snapshot, error) {
+	return pc.c.GetSnapshot(pc.project, name)
+}
+
+// SetSnapshotLabels calls Client.SetSnapshotLabels with pc's project.
+func (pc *ProjectClient) SetSnapshotLabels(name string, labels map[string]string, fingerprint string) error {
+	return pc.c.SetSnapshotLabels(pc.project, name, labels, fingerprint)
+}
+
+// ListSnapshots calls Client.ListSnapshots with pc's project.
+func (pc *ProjectClient) ListSnapshots(opts ...ListCallOption) ([]*compute.Snapshot, error) {
+	return pc.c.ListSnapshots(pc.project, opts...)
+}
+
+// DeleteSnapshot calls Client.DeleteSnapshot with pc's project.
+func (pc *ProjectClient) DeleteSnapshot(name string) error {
+	return pc.c.DeleteSnapshot(pc.project, name)
+}
+
+// ListNetworks calls Client.ListNetworks with pc's project.
+func (pc *ProjectClient) ListNetworks(opts ...ListCallOption) ([]*compute.Network, error) {
+	return pc.c.ListNetworks(pc.project, opts...)
+}
+
+// AggregatedListSubnetworks calls Client.AggregatedListSubnetworks with pc's project.
+func (pc *ProjectClient) AggregatedListSubnetworks(opts ...ListCallOption) ([]*compute.Subnetwork, error) {
+	return pc.c.AggregatedListSubnetworks(pc.project, opts...)
+}
+
+// ListSubnetworks calls Client.ListSubnetworks with pc's project.
+func (pc *ProjectClient) ListSubnetworks(region string, opts ...ListCallOption) ([]*compute.Subnetwork, error) {
+	return pc.c.ListSubnetworks(pc.project, region, opts...)
+}
+
+// ListTargetInstances calls Client.ListTargetInstances with pc's project.
+func (pc *ProjectClient) ListTargetInstances(zone string, opts ...ListCallOption) ([]*compute.TargetInstance, error) {
+	return pc.c.ListTargetInstances(pc.project, zone, opts...)
+}
+
+// ResizeDisk calls Client.ResizeDisk with pc's project.
+func (pc *ProjectClient) ResizeDisk(zone string, disk string, drr *compute.DisksResizeRequest) error {
+	return pc.c.ResizeDisk(pc.project, zone, disk, drr)
+}
+
+// SetInstanceMetadata calls Client.SetInstanceMetadata with pc's project.
+func (pc *ProjectClient) SetInstanceMetadata(zone string, name string, md *compute.Metadata) error {
+	return pc.c.SetInstanceMetadata(pc.project, zone, name, md)
+}
+
+// ResetWindowsPassword calls Client.ResetWindowsPassword with pc's project.
+func (pc *ProjectClient) ResetWindowsPassword(zone string, instance string, username string) (string, error) {
+	return pc.c.ResetWindowsPassword(pc.project, zone, instance, username)
+}
+
+// SetCommonInstanceMetadata calls Client.SetCommonInstanceMetadata with pc's project.
+func (pc *ProjectClient) SetCommonInstanceMetadata(md *compute.Metadata) error {
+	return pc.c.SetCommonInstanceMetadata(pc.project, md)
+}
+
+// SetProjectMetadataItem calls Client.SetProjectMetadataItem with pc's project.
+func (pc *ProjectClient) SetProjectMetadataItem(key string, value string) error {
+	return pc.c.SetProjectMetadataItem(pc.project, key, value)
+}
+
+// SetUsageExportBucket calls Client.SetUsageExportBucket with pc's project.
+func (pc *ProjectClient) SetUsageExportBucket(cfg *compute.UsageExportLocation) error {
+	return pc.c.SetUsageExportBucket(pc.project, cfg)
+}
+
+// GetXpnHost calls Client.GetXpnHost with pc's project.
+func (pc *ProjectClient) GetXpnHost() (*compute.Project, error) {
+	return pc.c.GetXpnHost(pc.project)
+}
+
+// ListXpnHosts calls Client.ListXpnHosts with pc's project.
+func (pc *ProjectClient) ListXpnHosts(organization string) ([]*compute.Project, error) {
+	return pc.c.ListXpnHosts(pc.project, organization)
+}
+
+// EnableXpnResource calls Client.EnableXpnResource with pc's project.
+func (pc *ProjectClient) EnableXpnResource(req *compute.ProjectsEnableXpnResourceRequest) error {
+	return pc.c.EnableXpnResource(pc.project, req)
+}
+
+// DisableXpnResource calls Client.DisableXpnResource with pc's project.
+func (pc *ProjectClient) DisableXpnResource(req *compute.ProjectsDisableXpnResourceRequest) error {
+	return pc.c.DisableXpnResource(pc.project, req)
+}
+
+// SetDiskAutoDelete calls Client.SetDiskAutoDelete with pc's project.
+func (pc *ProjectClient) SetDiskAutoDelete(zone string, instance string, autoDelete bool, deviceName string) error {
+	return pc.c.SetDiskAutoDelete(pc.project, zone, instance, autoDelete, deviceName)
+}
+
+// ListMachineImages calls Client.ListMachineImages with pc's project.
+func (pc *ProjectClient) ListMachineImages(opts ...ListCallOption) ([]*compute.MachineImage, error) {
+	return pc.c.ListMachineImages(pc.project, opts...)
+}
+
+// DeleteMachineImage calls Client.DeleteMachineImage with pc's project.
+func (pc *ProjectClient) DeleteMachineImage(name string) error {
+	return pc.c.DeleteMachineImage(pc.project, name)
+}
+
+// CreateMachineImage calls Client.CreateMachineImage with pc's project.
+func (pc *ProjectClient) CreateMachineImage(i *compute.MachineImage) error {
+	return pc.c.CreateMachineImage(pc.project, i)
+}
+
+// GetMachineImage calls Client.GetMachineImage with pc's project.
+func (pc *ProjectClient) GetMachineImage(name string) (*compute.MachineImage, error) {
+	return pc.c.GetMachineImage(pc.project, name)
+}
+
+// Suspend calls Client.Suspend with pc's project.
+func (pc *ProjectClient) Suspend(zone string, instance string) error {
+	return pc.c.Suspend(pc.project, zone, instance)
+}
+
+// Resume calls Client.Resume with pc's project.
+func (pc *ProjectClient) Resume(zone string, instance string) error {
+	return pc.c.Resume(pc.project, zone, instance)
+}
+
+// ResumeWithEncryptionKey calls Client.ResumeWithEncryptionKey with pc's project.
+func (pc *ProjectClient) ResumeWithEncryptionKey(zone string, instance string, req *computeBeta.InstancesResumeRequest) error {
+	return pc.c.ResumeWithEncryptionKey(pc.project, zone, instance, req)
+}
+
+// PerformMaintenance calls Client.PerformMaintenance with pc's project.
+func (pc *ProjectClient) PerformMaintenance(zone string, instance string) error {
+	return pc.c.PerformMaintenance(pc.project, zone, instance)
+}
+
+// SetInstanceName calls Client.SetInstanceName with pc's project.
+func (pc *ProjectClient) SetInstanceName(zone string, instance string, newName string) error {
+	return pc.c.SetInstanceName(pc.project, zone, instance, newName)
+}
+
+// UpdateInstance calls Client.UpdateInstance with pc's project.
+func (pc *ProjectClient) UpdateInstance(zone string, i *compute.Instance, fields ...string) error {
+	return pc.c.UpdateInstance(pc.project, zone, i, fields...)
+}
+
+// DeleteRegionTargetHTTPProxy calls Client.DeleteRegionTargetHTTPProxy with pc's project.
+func (pc *ProjectClient) DeleteRegionTargetHTTPProxy(region string, name string) error {
+	return pc.c.DeleteRegionTargetHTTPProxy(pc.project, region, name)
+}
+
+// CreateRegionTargetHTTPProxy calls Client.CreateRegionTargetHTTPProxy with pc's project.
+func (pc *ProjectClient) CreateRegionTargetHTTPProxy(region string, p *compute.TargetHttpProxy) error {
+	return pc.c.CreateRegionTargetHTTPProxy(pc.project, region, p)
+}
+
+// ListRegionTargetHTTPProxies calls Client.ListRegionTargetHTTPProxies with pc's project.
+func (pc *ProjectClient) ListRegionTargetHTTPProxies(region string, opts ...ListCallOption) ([]*compute.TargetHttpProxy, error) {
+	return pc.c.ListRegionTargetHTTPProxies(pc.project, region, opts...)
+}
+
+// GetRegionTargetHTTPProxy calls Client.GetRegionTargetHTTPProxy with pc's project.
+func (pc *ProjectClient) GetRegionTargetHTTPProxy(region string, name string) (*compute.TargetHttpProxy, error) {
+	return pc.c.GetRegionTargetHTTPProxy(pc.project, region, name)
+}
+
+// DeleteRegionSSLCertificate calls Client.DeleteRegionSSLCertificate with pc's project.
+func (pc *ProjectClient) DeleteRegionSSLCertificate(region string, name string) error {
+	return pc.c.DeleteRegionSSLCertificate(pc.project, region, name)
+}
+
+// CreateRegionSSLCertificate calls Client.CreateRegionSSLCertificate with pc's project.
+func (pc *ProjectClient) CreateRegionSSLCertificate(region string, sc *compute.SslCertificate) error {
+	return pc.c.CreateRegionSSLCertificate(pc.project, region, sc)
+}
+
+// GetRegionSSLCertificate calls Client.GetRegionSSLCertificate with pc's project.
+func (pc *ProjectClient) GetRegionSSLCertificate(region string, name string) (*compute.SslCertificate, error) {
+	return pc.c.GetRegionSSLCertificate(pc.project, region, name)
+}
+
+// DeleteRegionTargetHTTPSProxy calls Client.DeleteRegionTargetHTTPSProxy with pc's project.
+func (pc *ProjectClient) DeleteRegionTargetHTTPSProxy(region string, name string) error {
+	return pc.c.DeleteRegionTargetHTTPSProxy(pc.project, region, name)
+}
+
+// CreateRegionTargetHTTPSProxy calls Client.CreateRegionTargetHTTPSProxy with pc's project.
+func (pc *ProjectClient) CreateRegionTargetHTTPSProxy(region string, p *compute.TargetHttpsProxy) error {
+	return pc.c.CreateRegionTargetHTTPSProxy(pc.project, region, p)
+}
+
+// GetRegionTargetHTTPSProxy calls Client.GetRegionTargetHTTPSProxy with pc's project.
+func (pc *ProjectClient) GetRegionTargetHTTPSProxy(region string, name string) (*compute.TargetHttpsProxy, error) {
+	return pc.c.GetRegionTargetHTTPSProxy(pc.project, region, name)
+}
+
+// SetRegionSSLCertificates calls Client.SetRegionSSLCertificates with pc's project.
+func (pc *ProjectClient) SetRegionSSLCertificates(region string, proxy string, sslCertificates []string) error {
+	return pc.c.SetRegionSSLCertificates(pc.project, region, proxy, sslCertificates)
+}
+
+// DeleteRegionURLMap calls Client.DeleteRegionURLMap with pc's project.
+func (pc *ProjectClient) DeleteRegionURLMap(region string, name string) error {
+	return pc.c.DeleteRegionURLMap(pc.project, region, name)
+}
+
+// CreateRegionURLMap calls Client.CreateRegionURLMap with pc's project.
+func (pc *ProjectClient) CreateRegionURLMap(region string, u *compute.UrlMap) error {
+	return pc.c.CreateRegionURLMap(pc.project, region, u)
+}
+
+// ListRegionURLMaps calls Client.ListRegionURLMaps with pc's project.
+func (pc *ProjectClient) ListRegionURLMaps(region string, opts ...ListCallOption) ([]*compute.UrlMap, error) {
+	return pc.c.ListRegionURLMaps(pc.project, region, opts...)
+}
+
+// GetRegionURLMap calls Client.GetRegionURLMap with pc's project.
+func (pc *ProjectClient) GetRegionURLMap(region string, name string) (*compute.UrlMap, error) {
+	return pc.c.GetRegionURLMap(pc.project, region, name)
+}
+
+// ValidateRegionURLMap calls Client.ValidateRegionURLMap with pc's project.
+func (pc *ProjectClient) ValidateRegionURLMap(region string, u *compute.UrlMap) (*compute.UrlMapsValidateResponse, error) {
+	return pc.c.ValidateRegionURLMap(pc.project, region, u)
+}
+
+// ValidateURLMap calls Client.ValidateURLMap with pc's project.
+func (pc *ProjectClient) ValidateURLMap(u *compute.UrlMap) (*compute.UrlMapsValidateResponse, error) {
+	return pc.c.ValidateURLMap(pc.project, u)
+}
+
+// DeleteRegionBackendService calls Client.DeleteRegionBackendService with pc's project.
+func (pc *ProjectClient) DeleteRegionBackendService(region string, name string) error {
+	return pc.c.DeleteRegionBackendService(pc.project, region, name)
+}
+
+// CreateRegionBackendService calls Client.CreateRegionBackendService with pc's project.
+func (pc *ProjectClient) CreateRegionBackendService(region string, b *compute.BackendService) error {
+	return pc.c.CreateRegionBackendService(pc.project, region, b)
+}
+
+// PatchRegionBackendService calls Client.PatchRegionBackendService with pc's project.
+func (pc *ProjectClient) PatchRegionBackendService(region string, name string, b *compute.BackendService) error {
+	return pc.c.PatchRegionBackendService(pc.project, region, name, b)
+}
+
+// ListRegionBackendServices calls Client.ListRegionBackendServices with pc's project.
+func (pc *ProjectClient) ListRegionBackendServices(region string, opts ...ListCallOption) ([]*compute.BackendService, error) {
+	return pc.c.ListRegionBackendServices(pc.project, region, opts...)
+}
+
+// GetRegionBackendService calls Client.GetRegionBackendService with pc's project.
+func (pc *ProjectClient) GetRegionBackendService(region string, name string) (*compute.BackendService, error) {
+	return pc.c.GetRegionBackendService(pc.project, region, name)
+}
+
+// GetRegionBackendServiceHealth calls Client.GetRegionBackendServiceHealth with pc's project.
+func (pc *ProjectClient) GetRegionBackendServiceHealth(region string, backendService string, group string) (*compute.BackendServiceGroupHealth, error) {
+	return pc.c.GetRegionBackendServiceHealth(pc.project, region, backendService, group)
+}
+
+// WaitForBackendServiceHealthy calls Client.WaitForBackendServiceHealthy with pc's project.
+func (pc *ProjectClient) WaitForBackendServiceHealthy(region string, backendService string, group string, minHealthy int, timeout time.Duration) error {
+	return pc.c.WaitForBackendServiceHealthy(pc.project, region, backendService, group, minHealthy, timeout)
+}
+
+// DeleteRegionHealthCheck calls Client.DeleteRegionHealthCheck with pc's project.
+func (pc *ProjectClient) DeleteRegionHealthCheck(region string, name string) error {
+	return pc.c.DeleteRegionHealthCheck(pc.project, region, name)
+}
+
+// CreateRegionHealthCheck calls Client.CreateRegionHealthCheck with pc's project.
+func (pc *ProjectClient) CreateRegionHealthCheck(region string, h *compute.HealthCheck) error {
+	return pc.c.CreateRegionHealthCheck(pc.project, region, h)
+}
+
+// ListRegionHealthChecks calls Client.ListRegionHealthChecks with pc's project.
+func (pc *ProjectClient) ListRegionHealthChecks(region string, opts ...ListCallOption) ([]*compute.HealthCheck, error) {
+	return pc.c.ListRegionHealthChecks(pc.project, region, opts...)
+}
+
+// GetRegionHealthCheck calls Client.GetRegionHealthCheck with pc's project.
+func (pc *ProjectClient) GetRegionHealthCheck(region string, name string) (*compute.HealthCheck, error) {
+	return pc.c.GetRegionHealthCheck(pc.project, region, name)
+}
+
+// CreateInstanceGroup calls Client.CreateInstanceGroup with pc's project.
+func (pc *ProjectClient) CreateInstanceGroup(zone string, ig *compute.InstanceGroup) error {
+	return pc.c.CreateInstanceGroup(pc.project, zone, ig)
+}
+
+// DeleteInstanceGroup calls Client.DeleteInstanceGroup with pc's project.
+func (pc *ProjectClient) DeleteInstanceGroup(zone string, name string) error {
+	return pc.c.DeleteInstanceGroup(pc.project, zone, name)
+}
+
+// GetInstanceGroup calls Client.GetInstanceGroup with pc's project.
+func (pc *ProjectClient) GetInstanceGroup(zone string, name string) (*compute.InstanceGroup, error) {
+	return pc.c.GetInstanceGroup(pc.project, zone, name)
+}
+
+// AddInstanceGroupInstances calls Client.AddInstanceGroupInstances with pc's project.
+func (pc *ProjectClient) AddInstanceGroupInstances(zone string, name string, instances []string) error {
+	return pc.c.AddInstanceGroupInstances(pc.project, zone, name, instances)
+}
+
+// RemoveInstanceGroupInstances calls Client.RemoveInstanceGroupInstances with pc's project.
+func (pc *ProjectClient) RemoveInstanceGroupInstances(zone string, name string, instances []string) error {
+	return pc.c.RemoveInstanceGroupInstances(pc.project, zone, name, instances)
+}
+
+// AttachNetworkEndpoints calls Client.AttachNetworkEndpoints with pc's project.
+func (pc *ProjectClient) AttachNetworkEndpoints(zone string, neg string, endpoints []*compute.NetworkEndpoint) error {
+	return pc.c.AttachNetworkEndpoints(pc.project, zone, neg, endpoints)
+}
+
+// DetachNetworkEndpoints calls Client.DetachNetworkEndpoints with pc's project.
+func (pc *ProjectClient) DetachNetworkEndpoints(zone string, neg string, endpoints []*compute.NetworkEndpoint) error {
+	return pc.c.DetachNetworkEndpoints(pc.project, zone, neg, endpoints)
+}
+
+// AttachRegionNetworkEndpoints calls Client.AttachRegionNetworkEndpoints with pc's project.
+func (pc *ProjectClient) AttachRegionNetworkEndpoints(region string, neg string, endpoints []*compute.NetworkEndpoint) error {
+	return pc.c.AttachRegionNetworkEndpoints(pc.project, region, neg, endpoints)
+}
+
+// DetachRegionNetworkEndpoints calls Client.DetachRegionNetworkEndpoints with pc's project.
+func (pc *ProjectClient) DetachRegionNetworkEndpoints(region string, neg string, endpoints []*compute.NetworkEndpoint) error {
+	return pc.c.DetachRegionNetworkEndpoints(pc.project, region, neg, endpoints)
+}
+
+// DeleteRegionNetworkEndpointGroup calls Client.DeleteRegionNetworkEndpointGroup with pc's project.
+func (pc *ProjectClient) DeleteRegionNetworkEndpointGroup(region string, name string) error {
+	return pc.c.DeleteRegionNetworkEndpointGroup(pc.project, region, name)
+}
+
+// CreateRegionNetworkEndpointGroup calls Client.CreateRegionNetworkEndpointGroup with pc's project.
+func (pc *ProjectClient) CreateRegionNetworkEndpointGroup(region string, n *compute.NetworkEndpointGroup) error {
+	return pc.c.CreateRegionNetworkEndpointGroup(pc.project, region, n)
+}
+
+// ListRegionNetworkEndpointGroups calls Client.ListRegionNetworkEndpointGroups with pc's project.
+func (pc *ProjectClient) ListRegionNetworkEndpointGroups(region string, opts ...ListCallOption) ([]*compute.NetworkEndpointGroup, error) {
+	return pc.c.ListRegionNetworkEndpointGroups(pc.project, region, opts...)
+}
+
+// GetRegionNetworkEndpointGroup calls Client.GetRegionNetworkEndpointGroup with pc's project.
+func (pc *ProjectClient) GetRegionNetworkEndpointGroup(region string, name string) (*compute.NetworkEndpointGroup, error) {
+	return pc.c.GetRegionNetworkEndpointGroup(pc.project, region, name)
+}
+
+// GetRegionAutoscaler calls Client.GetRegionAutoscaler with pc's project.
+func (pc *ProjectClient) GetRegionAutoscaler(region string, name string) (*compute.Autoscaler, error) {
+	return pc.c.GetRegionAutoscaler(pc.project, region, name)
+}
+
+// ListRegionAutoscalers calls Client.ListRegionAutoscalers with pc's project.
+func (pc *ProjectClient) ListRegionAutoscalers(region string, opts ...ListCallOption) ([]*compute.Autoscaler, error) {
+	return pc.c.ListRegionAutoscalers(pc.project, region, opts...)
+}
+
+// GetRegionAutoscalerRecommendedSize calls Client.GetRegionAutoscalerRecommendedSize with pc's project.
+func (pc *ProjectClient) GetRegionAutoscalerRecommendedSize(region string, name string) (int64, error) {
+	return pc.c.GetRegionAutoscalerRecommendedSize(pc.project, region, name)
+}
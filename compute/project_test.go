@@ -0,0 +1,76 @@
+//  Copyright 2020 Google Inc. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package compute
+
+import (
+	"testing"
+
+	"google.golang.org/api/compute/v1"
+)
+
+func TestProjectClient(t *testing.T) {
+	_, c, err := NewTestClient(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var gotProject, gotZone string
+	c.CreateInstanceFn = func(project, zone string, _ *compute.Instance) error {
+		gotProject, gotZone = project, zone
+		return nil
+	}
+	var gotOpts []ListCallOption
+	c.ListImagesFn = func(project string, opts ...ListCallOption) ([]*compute.Image, error) {
+		gotProject, gotOpts = project, opts
+		return nil, nil
+	}
+
+	pc := NewProjectClient(c, "p")
+	if err := pc.CreateInstance("z", &compute.Instance{}); err != nil {
+		t.Fatal(err)
+	}
+	if gotProject != "p" || gotZone != "z" {
+		t.Errorf("CreateInstance called with (%q, %q), want (\"p\", \"z\")", gotProject, gotZone)
+	}
+	gotProject = ""
+	pc.ListImages(Filter("foo"))
+	if gotProject != "p" || len(gotOpts) != 1 {
+		t.Errorf("ListImages called with (%q, %v), want (\"p\", [foo])", gotProject, gotOpts)
+	}
+}
+
+func TestDefaultProject(t *testing.T) {
+	_, c, err := NewTestClient(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pc := c.DefaultProject(); pc != nil {
+		t.Errorf("got ProjectClient for %q without a default project, want nil", pc.Project())
+	}
+
+	WithDefaultProject("p")(&c.client)
+	var gotProject string
+	c.DeleteImageFn = func(project, _ string) error {
+		gotProject = project
+		return nil
+	}
+	pc := c.DefaultProject()
+	if pc == nil || pc.Project() != "p" {
+		t.Fatalf("got %v, want ProjectClient for \"p\"", pc)
+	}
+	pc.DeleteImage("im")
+	if gotProject != "p" {
+		t.Errorf("DeleteImage called with project %q, want \"p\"", gotProject)
+	}
+}
@@ -0,0 +1,35 @@
+//  Copyright 2020 Google Inc. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package compute
+
+// Instance statuses, as reported in compute.Instance.Status.
+const (
+	StatusProvisioning = "PROVISIONING"
+	StatusStaging      = "STAGING"
+	StatusRunning      = "RUNNING"
+	StatusStopping     = "STOPPING"
+	StatusStopped      = "STOPPED"
+	StatusSuspending   = "SUSPENDING"
+	StatusSuspended    = "SUSPENDED"
+	StatusRepairing    = "REPAIRING"
+	StatusTerminated   = "TERMINATED"
+)
+
+// Operation statuses, as reported in compute.Operation.Status.
+const (
+	OpStatusPending = "PENDING"
+	OpStatusRunning = "RUNNING"
+	OpStatusDone    = "DONE"
+)
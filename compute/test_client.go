@@ -18,6 +18,7 @@ import (
 	"context"
 	"net/http"
 	"net/http/httptest"
+	"time"
 
 	computeAlpha "google.golang.org/api/compute/v0.alpha"
 	computeBeta "google.golang.org/api/compute/v0.beta"
@@ -27,7 +28,8 @@ import (
 )
 
 // NewTestClient returns a TestClient with a replacement http handler function.
-// Methods on the new TestClient are overrideable as well.
+// Methods on the new TestClient are overrideable as well. The client uses a
+// FakeClock, so operation polling and retry backoff do not sleep.
 func NewTestClient(handleFunc http.HandlerFunc) (*httptest.Server, *TestClient, error) {
 	ts := httptest.NewServer(handleFunc)
 	opts := []option.ClientOption{
@@ -42,99 +44,223 @@ func NewTestClient(handleFunc http.HandlerFunc) (*httptest.Server, *TestClient,
 	tc := &TestClient{}
 	tc.client = *c.(*client)
 	tc.client.i = tc
+	tc.client.clock = NewFakeClock(time.Now())
 	return ts, tc, nil
 }
 
+var _ Client = (*TestClient)(nil)
+
 // TestClient is a Client with overrideable methods.
 type TestClient struct {
 	client
 
-	AttachDiskFn                       func(project, zone, instance string, d *compute.AttachedDisk) error
-	DetachDiskFn                       func(project, zone, instance, disk string) error
-	CreateDiskFn                       func(project, zone string, d *compute.Disk) error
-	CreateForwardingRuleFn             func(project, region string, fr *compute.ForwardingRule) error
-	CreateFirewallRuleFn               func(project string, i *compute.Firewall) error
-	CreateImageFn                      func(project string, i *compute.Image) error
-	CreateInstanceFn                   func(project, zone string, i *compute.Instance) error
-	CreateNetworkFn                    func(project string, n *compute.Network) error
-	CreateSnapshotFn                   func(project, zone, disk string, s *compute.Snapshot) error
-	CreateSubnetworkFn                 func(project, region string, n *compute.Subnetwork) error
-	CreateTargetInstanceFn             func(project, zone string, ti *compute.TargetInstance) error
-	StartInstanceFn                    func(project, zone, name string) error
-	StopInstanceFn                     func(project, zone, name string) error
-	DeleteDiskFn                       func(project, zone, name string) error
-	DeleteForwardingRuleFn             func(project, region, name string) error
-	DeleteFirewallRuleFn               func(project, name string) error
-	DeleteImageFn                      func(project, name string) error
-	DeleteInstanceFn                   func(project, zone, name string) error
-	DeleteNetworkFn                    func(project, name string) error
-	DeleteSubnetworkFn                 func(project, region, name string) error
-	DeleteTargetInstanceFn             func(project, zone, name string) error
-	DeprecateImageFn                   func(project, name string, deprecationstatus *compute.DeprecationStatus) error
-	GetMachineTypeFn                   func(project, zone, machineType string) (*compute.MachineType, error)
-	ListMachineTypesFn                 func(project, zone string, opts ...ListCallOption) ([]*compute.MachineType, error)
-	GetProjectFn                       func(project string) (*compute.Project, error)
-	GetSerialPortOutputFn              func(project, zone, name string, port, start int64) (*compute.SerialPortOutput, error)
-	GetGuestAttributesFn               func(project, zone, name, queryPath, variableKey string) (*compute.GuestAttributes, error)
-	GetZoneFn                          func(project, zone string) (*compute.Zone, error)
-	ListZonesFn                        func(project string, opts ...ListCallOption) ([]*compute.Zone, error)
-	GetInstanceFn                      func(project, zone, name string) (*compute.Instance, error)
-	AggregatedListInstancesFn          func(project string, opts ...ListCallOption) ([]*compute.Instance, error)
-	ListInstancesFn                    func(project, zone string, opts ...ListCallOption) ([]*compute.Instance, error)
-	ListSnapshotsFn                    func(project string, opts ...ListCallOption) ([]*compute.Snapshot, error)
-	GetSnapshotFn                      func(project, name string) (*compute.Snapshot, error)
-	DeleteSnapshotFn                   func(project, name string) error
-	GetDiskFn                          func(project, zone, name string) (*compute.Disk, error)
-	AggregatedListDisksFn              func(project string, opts ...ListCallOption) ([]*compute.Disk, error)
-	ListDisksFn                        func(project, zone string, opts ...ListCallOption) ([]*compute.Disk, error)
-	GetForwardingRuleFn                func(project, region, name string) (*compute.ForwardingRule, error)
-	AggregatedListForwardingRulesFn    func(project string, opts ...ListCallOption) ([]*compute.ForwardingRule, error)
-	ListForwardingRulesFn              func(project, region string, opts ...ListCallOption) ([]*compute.ForwardingRule, error)
-	GetFirewallRuleFn                  func(project, name string) (*compute.Firewall, error)
-	ListFirewallRulesFn                func(project string, opts ...ListCallOption) ([]*compute.Firewall, error)
-	GetImageFn                         func(project, name string) (*compute.Image, error)
-	GetImageFromFamilyFn               func(project, family string) (*compute.Image, error)
-	ListImagesFn                       func(project string, opts ...ListCallOption) ([]*compute.Image, error)
-	GetLicenseFn                       func(project, name string) (*compute.License, error)
-	ListLicensesFn                     func(project string, opts ...ListCallOption) ([]*compute.License, error)
-	GetNetworkFn                       func(project, name string) (*compute.Network, error)
-	GetRegionFn                        func(project, name string) (*compute.Region, error)
-	AggregatedListSubnetworksFn        func(project string, opts ...ListCallOption) ([]*compute.Subnetwork, error)
-	ListNetworksFn                     func(project string, opts ...ListCallOption) ([]*compute.Network, error)
-	GetSubnetworkFn                    func(project, region, name string) (*compute.Subnetwork, error)
-	ListSubnetworksFn                  func(project, region string, opts ...ListCallOption) ([]*compute.Subnetwork, error)
-	GetTargetInstanceFn                func(project, zone, name string) (*compute.TargetInstance, error)
-	ListTargetInstancesFn              func(project, zone string, opts ...ListCallOption) ([]*compute.TargetInstance, error)
-	InstanceStatusFn                   func(project, zone, name string) (string, error)
-	InstanceStoppedFn                  func(project, zone, name string) (bool, error)
-	ResizeDiskFn                       func(project, zone, disk string, drr *compute.DisksResizeRequest) error
-	SetInstanceMetadataFn              func(project, zone, name string, md *compute.Metadata) error
-	SetCommonInstanceMetadataFn        func(project string, md *compute.Metadata) error
-	ListMachineImagesFn                func(project string, opts ...ListCallOption) ([]*compute.MachineImage, error)
-	DeleteMachineImageFn               func(project, name string) error
-	CreateMachineImageFn               func(project string, i *compute.MachineImage) error
-	GetMachineImageFn                  func(project, name string) (*compute.MachineImage, error)
-	RetryFn                            func(f func(opts ...googleapi.CallOption) (*compute.Operation, error), opts ...googleapi.CallOption) (op *compute.Operation, err error)
-	DeleteRegionTargetHTTPProxyFn      func(project, region, name string) error
-	CreateRegionTargetHTTPProxyFn      func(project, region string, p *compute.TargetHttpProxy) error
-	ListRegionTargetHTTPProxiesFn      func(project, region string, opts ...ListCallOption) ([]*compute.TargetHttpProxy, error)
-	GetRegionTargetHTTPProxyFn         func(project, region, name string) (*compute.TargetHttpProxy, error)
-	DeleteRegionURLMapFn               func(project, region, name string) error
-	CreateRegionURLMapFn               func(project, region string, u *compute.UrlMap) error
-	ListRegionURLMapsFn                func(project, region string, opts ...ListCallOption) ([]*compute.UrlMap, error)
-	GetRegionURLMapFn                  func(project, region, name string) (*compute.UrlMap, error)
-	DeleteRegionBackendServiceFn       func(project, region, name string) error
-	CreateRegionBackendServiceFn       func(project, region string, b *compute.BackendService) error
-	ListRegionBackendServicesFn        func(project, region string, opts ...ListCallOption) ([]*compute.BackendService, error)
-	GetRegionBackendServiceFn          func(project, region, name string) (*compute.BackendService, error)
-	DeleteRegionHealthCheckFn          func(project, region, name string) error
-	CreateRegionHealthCheckFn          func(project, region string, h *compute.HealthCheck) error
-	ListRegionHealthChecksFn           func(project, region string, opts ...ListCallOption) ([]*compute.HealthCheck, error)
-	GetRegionHealthCheckFn             func(project, region, name string) (*compute.HealthCheck, error)
-	DeleteRegionNetworkEndpointGroupFn func(project, region, name string) error
-	CreateRegionNetworkEndpointGroupFn func(project, region string, n *compute.NetworkEndpointGroup) error
-	ListRegionNetworkEndpointGroupsFn  func(project, region string, opts ...ListCallOption) ([]*compute.NetworkEndpointGroup, error)
-	GetRegionNetworkEndpointGroupFn    func(project, region, name string) (*compute.NetworkEndpointGroup, error)
+	AttachDiskFn                              func(project, zone, instance string, d *compute.AttachedDisk) error
+	DetachDiskFn                              func(project, zone, instance, disk string) error
+	CreateDiskFn                              func(project, zone string, d *compute.Disk) error
+	CreateForwardingRuleFn                    func(project, region string, fr *compute.ForwardingRule) error
+	CreateFirewallRuleFn                      func(project string, i *compute.Firewall) error
+	CreateImageFn                             func(project string, i *compute.Image) error
+	CreateInstanceFn                          func(project, zone string, i *compute.Instance) error
+	CreateNetworkFn                           func(project string, n *compute.Network) error
+	CreateSnapshotFn                          func(project, zone, disk string, s *compute.Snapshot) error
+	CreateSubnetworkFn                        func(project, region string, n *compute.Subnetwork) error
+	CreateTargetInstanceFn                    func(project, zone string, ti *compute.TargetInstance) error
+	StartInstanceFn                           func(project, zone, name string) error
+	StopInstanceFn                            func(project, zone, name string) error
+	DeleteDiskFn                              func(project, zone, name string) error
+	DeleteForwardingRuleFn                    func(project, region, name string) error
+	DeleteFirewallRuleFn                      func(project, name string) error
+	DeleteImageFn                             func(project, name string) error
+	DeleteInstanceFn                          func(project, zone, name string) error
+	DeleteNetworkFn                           func(project, name string) error
+	DeleteSubnetworkFn                        func(project, region, name string) error
+	DeleteTargetInstanceFn                    func(project, zone, name string) error
+	DeprecateImageFn                          func(project, name string, deprecationstatus *compute.DeprecationStatus) error
+	GetMachineTypeFn                          func(project, zone, machineType string) (*compute.MachineType, error)
+	ListMachineTypesFn                        func(project, zone string, opts ...ListCallOption) ([]*compute.MachineType, error)
+	GetProjectFn                              func(project string) (*compute.Project, error)
+	GetSerialPortOutputFn                     func(project, zone, name string, port, start int64) (*compute.SerialPortOutput, error)
+	GetGuestAttributesFn                      func(project, zone, name, queryPath, variableKey string) (*compute.GuestAttributes, error)
+	GetZoneFn                                 func(project, zone string) (*compute.Zone, error)
+	ListZonesFn                               func(project string, opts ...ListCallOption) ([]*compute.Zone, error)
+	GetInstanceFn                             func(project, zone, name string) (*compute.Instance, error)
+	AggregatedListInstancesFn                 func(project string, opts ...ListCallOption) ([]*compute.Instance, error)
+	ListInstancesFn                           func(project, zone string, opts ...ListCallOption) ([]*compute.Instance, error)
+	ListSnapshotsFn                           func(project string, opts ...ListCallOption) ([]*compute.Snapshot, error)
+	GetSnapshotFn                             func(project, name string) (*compute.Snapshot, error)
+	DeleteSnapshotFn                          func(project, name string) error
+	GetDiskFn                                 func(project, zone, name string) (*compute.Disk, error)
+	AggregatedListDisksFn                     func(project string, opts ...ListCallOption) ([]*compute.Disk, error)
+	ListDisksFn                               func(project, zone string, opts ...ListCallOption) ([]*compute.Disk, error)
+	GetForwardingRuleFn                       func(project, region, name string) (*compute.ForwardingRule, error)
+	AggregatedListForwardingRulesFn           func(project string, opts ...ListCallOption) ([]*compute.ForwardingRule, error)
+	ListForwardingRulesFn                     func(project, region string, opts ...ListCallOption) ([]*compute.ForwardingRule, error)
+	GetFirewallRuleFn                         func(project, name string) (*compute.Firewall, error)
+	ListFirewallRulesFn                       func(project string, opts ...ListCallOption) ([]*compute.Firewall, error)
+	GetImageFn                                func(project, name string) (*compute.Image, error)
+	GetImageFromFamilyFn                      func(project, family string) (*compute.Image, error)
+	ListImagesFn                              func(project string, opts ...ListCallOption) ([]*compute.Image, error)
+	GetLicenseFn                              func(project, name string) (*compute.License, error)
+	ListLicensesFn                            func(project string, opts ...ListCallOption) ([]*compute.License, error)
+	GetNetworkFn                              func(project, name string) (*compute.Network, error)
+	GetRegionFn                               func(project, name string) (*compute.Region, error)
+	AggregatedListSubnetworksFn               func(project string, opts ...ListCallOption) ([]*compute.Subnetwork, error)
+	ListNetworksFn                            func(project string, opts ...ListCallOption) ([]*compute.Network, error)
+	GetSubnetworkFn                           func(project, region, name string) (*compute.Subnetwork, error)
+	ListSubnetworksFn                         func(project, region string, opts ...ListCallOption) ([]*compute.Subnetwork, error)
+	GetTargetInstanceFn                       func(project, zone, name string) (*compute.TargetInstance, error)
+	ListTargetInstancesFn                     func(project, zone string, opts ...ListCallOption) ([]*compute.TargetInstance, error)
+	InstanceStatusFn                          func(project, zone, name string) (string, error)
+	InstanceStoppedFn                         func(project, zone, name string) (bool, error)
+	ResizeDiskFn                              func(project, zone, disk string, drr *compute.DisksResizeRequest) error
+	SetInstanceMetadataFn                     func(project, zone, name string, md *compute.Metadata) error
+	SetCommonInstanceMetadataFn               func(project string, md *compute.Metadata) error
+	ListMachineImagesFn                       func(project string, opts ...ListCallOption) ([]*compute.MachineImage, error)
+	DeleteMachineImageFn                      func(project, name string) error
+	CreateMachineImageFn                      func(project string, i *compute.MachineImage) error
+	GetMachineImageFn                         func(project, name string) (*compute.MachineImage, error)
+	RetryFn                                   func(f func(opts ...googleapi.CallOption) (*compute.Operation, error), opts ...googleapi.CallOption) (op *compute.Operation, err error)
+	DeleteRegionTargetHTTPProxyFn             func(project, region, name string) error
+	CreateRegionTargetHTTPProxyFn             func(project, region string, p *compute.TargetHttpProxy) error
+	ListRegionTargetHTTPProxiesFn             func(project, region string, opts ...ListCallOption) ([]*compute.TargetHttpProxy, error)
+	GetRegionTargetHTTPProxyFn                func(project, region, name string) (*compute.TargetHttpProxy, error)
+	DeleteRegionURLMapFn                      func(project, region, name string) error
+	CreateRegionURLMapFn                      func(project, region string, u *compute.UrlMap) error
+	ListRegionURLMapsFn                       func(project, region string, opts ...ListCallOption) ([]*compute.UrlMap, error)
+	GetRegionURLMapFn                         func(project, region, name string) (*compute.UrlMap, error)
+	DeleteRegionBackendServiceFn              func(project, region, name string) error
+	CreateRegionBackendServiceFn              func(project, region string, b *compute.BackendService) error
+	ListRegionBackendServicesFn               func(project, region string, opts ...ListCallOption) ([]*compute.BackendService, error)
+	GetRegionBackendServiceFn                 func(project, region, name string) (*compute.BackendService, error)
+	DeleteRegionHealthCheckFn                 func(project, region, name string) error
+	CreateRegionHealthCheckFn                 func(project, region string, h *compute.HealthCheck) error
+	ListRegionHealthChecksFn                  func(project, region string, opts ...ListCallOption) ([]*compute.HealthCheck, error)
+	GetRegionHealthCheckFn                    func(project, region, name string) (*compute.HealthCheck, error)
+	DeleteRegionNetworkEndpointGroupFn        func(project, region, name string) error
+	CreateRegionNetworkEndpointGroupFn        func(project, region string, n *compute.NetworkEndpointGroup) error
+	ListRegionNetworkEndpointGroupsFn         func(project, region string, opts ...ListCallOption) ([]*compute.NetworkEndpointGroup, error)
+	GetRegionNetworkEndpointGroupFn           func(project, region, name string) (*compute.NetworkEndpointGroup, error)
+	ListAttachedAcceleratorsFn                func(project, zone string) (map[string][]*compute.AcceleratorConfig, error)
+	ListRegionsFn                             func(project string, opts ...ListCallOption) ([]*compute.Region, error)
+	ResumeWithEncryptionKeyFn                 func(project, zone, instance string, req *computeBeta.InstancesResumeRequest) error
+	CreateInstanceInZonesFn                   func(project string, zones []string, i *compute.Instance) (string, error)
+	WaitForInstanceRunningFn                  func(project, zone, name string, timeout time.Duration) error
+	SetProjectMetadataItemFn                  func(project, key, value string) error
+	GetAcceleratorTypeFn                      func(project, zone, acceleratorType string) (*compute.AcceleratorType, error)
+	ListAcceleratorTypesFn                    func(project, zone string, opts ...ListCallOption) ([]*compute.AcceleratorType, error)
+	BulkInsertInstanceFn                      func(project, zone string, r *compute.BulkInsertInstanceResource) error
+	GetBulkInsertInstanceResultFn             func(project, zone string, r *compute.BulkInsertInstanceResource) (running, failed []string, err error)
+	PatchRegionBackendServiceFn               func(project, region, name string, b *compute.BackendService) error
+	ValidateRegionURLMapFn                    func(project, region string, u *compute.UrlMap) (*compute.UrlMapsValidateResponse, error)
+	ValidateURLMapFn                          func(project string, u *compute.UrlMap) (*compute.UrlMapsValidateResponse, error)
+	CreateInstanceGroupFn                     func(project, zone string, ig *compute.InstanceGroup) error
+	DeleteInstanceGroupFn                     func(project, zone, name string) error
+	GetInstanceGroupFn                        func(project, zone, name string) (*compute.InstanceGroup, error)
+	AddInstanceGroupInstancesFn               func(project, zone, name string, instances []string) error
+	RemoveInstanceGroupInstancesFn            func(project, zone, name string, instances []string) error
+	AttachNetworkEndpointsFn                  func(project, zone, neg string, endpoints []*compute.NetworkEndpoint) error
+	DetachNetworkEndpointsFn                  func(project, zone, neg string, endpoints []*compute.NetworkEndpoint) error
+	AttachRegionNetworkEndpointsFn            func(project, region, neg string, endpoints []*compute.NetworkEndpoint) error
+	DetachRegionNetworkEndpointsFn            func(project, region, neg string, endpoints []*compute.NetworkEndpoint) error
+	CreateDiskFromImageAndAttachFn            func(project, zone, instance, image string, d *compute.Disk) error
+	GetRegionAutoscalerFn                     func(project, region, name string) (*compute.Autoscaler, error)
+	ListRegionAutoscalersFn                   func(project, region string, opts ...ListCallOption) ([]*compute.Autoscaler, error)
+	GetRegionAutoscalerRecommendedSizeFn      func(project, region, name string) (int64, error)
+	DeleteInstancesByFilterFn                 func(project, zone, filter string) error
+	SetDeletionProtectionFn                   func(project, zone, instance string, enabled bool) error
+	WaitForGuestAttributeFn                   func(project, zone, instance, namespace, key, wantValue string, timeout time.Duration) error
+	WaitForGuestAttributeContextFn            func(ctx context.Context, project, zone, instance, namespace, key, wantValue string) error
+	DeleteRegionSSLCertificateFn              func(project, region, name string) error
+	CreateRegionSSLCertificateFn              func(project, region string, sc *compute.SslCertificate) error
+	GetRegionSSLCertificateFn                 func(project, region, name string) (*compute.SslCertificate, error)
+	DeleteRegionTargetHTTPSProxyFn            func(project, region, name string) error
+	CreateRegionTargetHTTPSProxyFn            func(project, region string, p *compute.TargetHttpsProxy) error
+	GetRegionTargetHTTPSProxyFn               func(project, region, name string) (*compute.TargetHttpsProxy, error)
+	SetRegionSSLCertificatesFn                func(project, region, proxy string, sslCertificates []string) error
+	WaitForOperationWithProgressFn            func(project, selfLink string, onProgress func(percent int)) error
+	CreateInstanceIfNotExistsFn               func(project, zone string, i *compute.Instance) (bool, error)
+	GetBackendServiceFn                       func(project, name string) (*compute.BackendService, error)
+	GetHealthCheckFn                          func(project, name string) (*compute.HealthCheck, error)
+	GetURLMapFn                               func(project, name string) (*compute.UrlMap, error)
+	GetTargetHTTPProxyFn                      func(project, name string) (*compute.TargetHttpProxy, error)
+	GetNetworkEndpointGroupFn                 func(project, zone, name string) (*compute.NetworkEndpointGroup, error)
+	UpdateNetworkInterfaceFn                  func(project, zone, instance, networkInterface string, ni *compute.NetworkInterface) error
+	AggregatedListInstancesByZoneFn           func(project string, opts ...ListCallOption) (map[string][]*compute.Instance, error)
+	AggregatedListDisksByZoneFn               func(project string, opts ...ListCallOption) (map[string][]*compute.Disk, error)
+	DeleteOperationFn                         func(project, selfLink string) error
+	SetSnapshotLabelsFn                       func(project, name string, labels map[string]string, fingerprint string) error
+	StopInstanceWithOptionsFn                 func(project, zone, name string, discardLocalSSD bool) error
+	WaitForInstanceStoppedFn                  func(project, zone, name string, timeout time.Duration) error
+	ListZoneOperationsFn                      func(project, zone string, opts ...ListCallOption) ([]*compute.Operation, error)
+	ListRegionOperationsFn                    func(project, region string, opts ...ListCallOption) ([]*compute.Operation, error)
+	ListGlobalOperationsFn                    func(project string, opts ...ListCallOption) ([]*compute.Operation, error)
+	ResetWindowsPasswordFn                    func(project, zone, instance, username string) (string, error)
+	GetLatestImageFromFamiliesFn              func(projects []string, family string) (*compute.Image, error)
+	CreateInstanceFromMachineImageFn          func(project, zone, machineImage string, i *compute.Instance) error
+	GetShieldedInstanceIdentityFn             func(project, zone, instance string) (*compute.ShieldedInstanceIdentity, error)
+	SetShieldedInstanceIntegrityPolicyFn      func(project, zone, instance string, p *compute.ShieldedInstanceIntegrityPolicy) error
+	CreateHTTPHealthCheckFn                   func(project string, hc *compute.HttpHealthCheck) error
+	GetHTTPHealthCheckFn                      func(project, name string) (*compute.HttpHealthCheck, error)
+	DeleteHTTPHealthCheckFn                   func(project, name string) error
+	CreateHTTPSHealthCheckFn                  func(project string, hc *compute.HttpsHealthCheck) error
+	GetHTTPSHealthCheckFn                     func(project, name string) (*compute.HttpsHealthCheck, error)
+	DeleteHTTPSHealthCheckFn                  func(project, name string) error
+	GetInstanceReferrersFn                    func(project, zone, instance string) ([]*compute.Reference, error)
+	ListDiskUsersFn                           func(project, zone, disk string) ([]*compute.Instance, error)
+	CreateSecurityPolicyFn                    func(project string, sp *compute.SecurityPolicy) error
+	GetSecurityPolicyFn                       func(project, name string) (*compute.SecurityPolicy, error)
+	DeleteSecurityPolicyFn                    func(project, name string) error
+	AddSecurityPolicyRuleFn                   func(project, securityPolicy string, r *compute.SecurityPolicyRule) error
+	PatchSecurityPolicyRuleFn                 func(project, securityPolicy string, priority int64, r *compute.SecurityPolicyRule) error
+	CreatePacketMirroringFn                   func(project, region string, pm *compute.PacketMirroring) error
+	GetPacketMirroringFn                      func(project, region, name string) (*compute.PacketMirroring, error)
+	DeletePacketMirroringFn                   func(project, region, name string) error
+	TeardownByLabelFn                         func(project, labelKey, labelValue string) error
+	GetReservationFn                          func(project, zone, name string) (*compute.Reservation, error)
+	CreateInstanceAndGetFn                    func(project, zone string, i *compute.Instance) (*compute.Instance, error)
+	SetUsageExportBucketFn                    func(project string, cfg *compute.UsageExportLocation) error
+	GetXpnHostFn                              func(project string) (*compute.Project, error)
+	ListXpnHostsFn                            func(project, organization string) ([]*compute.Project, error)
+	EnableXpnResourceFn                       func(project string, req *compute.ProjectsEnableXpnResourceRequest) error
+	DisableXpnResourceFn                      func(project string, req *compute.ProjectsDisableXpnResourceRequest) error
+	GetEffectiveFirewallsFn                   func(project, zone, instance, networkInterface string) (*compute.InstancesGetEffectiveFirewallsResponse, error)
+	CreateDiskFromSnapshotFn                  func(project, zone, diskName, snapshotSelfLink string, sizeGb int64, diskType string) error
+	ListInstancesByStatusFn                   func(project, zone, status string) ([]*compute.Instance, error)
+	GetScreenshotFn                           func(project, zone, instance string) (*compute.Screenshot, error)
+	CloneDiskReencryptFn                      func(project, zone, sourceDisk, diskName string, key *compute.CustomerEncryptionKey) error
+	GetOperationsForTargetFn                  func(project, scope, targetSelfLink string) ([]*compute.Operation, error)
+	DefaultProjectFn                          func() *ProjectClient
+	DeprecateImageFamilyFn                    func(project, family string, keepLatest int, status *compute.DeprecationStatus) error
+	PerformMaintenanceFn                      func(project, zone, instance string) error
+	GetBackendServiceHealthFn                 func(project, backendService, group string) (*compute.BackendServiceGroupHealth, error)
+	GetRegionBackendServiceHealthFn           func(project, region, backendService, group string) (*compute.BackendServiceGroupHealth, error)
+	SetInstanceNameFn                         func(project, zone, instance, newName string) error
+	ListUnattachedDisksFn                     func(project, zone string) ([]*compute.Disk, error)
+	DeleteUnattachedDisksFn                   func(project, zone string) error
+	CreateImageFromEncryptedDiskFn            func(project string, im *compute.Image, diskKey *compute.CustomerEncryptionKey) error
+	UpdateInstanceFn                          func(project, zone string, i *compute.Instance, fields ...string) error
+	PickZoneFn                                func(project, region, machineType string) (string, error)
+	GetInstanceIamPolicyFn                    func(project, zone, instance string) (*compute.Policy, error)
+	SetInstanceIamPolicyFn                    func(project, zone, instance string, policy *compute.Policy) error
+	TestInstanceIamPermissionsFn              func(project, zone, instance string, permissions []string) ([]string, error)
+	CopyImageToLocationFn                     func(srcProject, srcImage, dstProject, dstImage string, storageLocations []string) error
+	RetryContextFn                            func(ctx context.Context, f func(opts ...googleapi.CallOption) (*compute.Operation, error), opts ...googleapi.CallOption) (op *compute.Operation, err error)
+	GetFirewallPolicyFn                       func(policy string) (*compute.FirewallPolicy, error)
+	AddFirewallPolicyAssociationFn            func(policy string, assoc *compute.FirewallPolicyAssociation) error
+	AddFirewallPolicyRuleFn                   func(policy string, rule *compute.FirewallPolicyRule) error
+	SendDiagnosticInterruptFn                 func(project, zone, instance string) error
+	CreateFirewallRuleBetaFn                  func(project string, fw *computeBeta.Firewall) error
+	PatchFirewallRuleBetaFn                   func(project, name string, fw *computeBeta.Firewall) error
+	GetFirewallRuleBetaFn                     func(project, name string) (*computeBeta.Firewall, error)
+	WaitForBackendServiceHealthyFn            func(project, region, backendService, group string, minHealthy int, timeout time.Duration) error
+	GetInstanceTemplateFn                     func(project, name string) (*compute.InstanceTemplate, error)
+	GetInstanceGroupManagerFn                 func(project, zone, name string) (*compute.InstanceGroupManager, error)
+	GetRegionInstanceGroupManagerFn           func(project, region, name string) (*compute.InstanceGroupManager, error)
+	WaitForInstanceGroupManagerStableFn       func(project, zone, name string, timeout time.Duration) error
+	WaitForRegionInstanceGroupManagerStableFn func(project, region, name string, timeout time.Duration) error
+	WaitForOperationWithConfigFn              func(project, selfLink string, wc WaitConfig) error
+	DeleteRegionDiskFn                        func(project, region, name string) error
+	GetRegionDiskFn                           func(project, region, name string) (*compute.Disk, error)
 
 	// Alpha API calls
 	CreateInstanceAlphaFn func(project, zone string, i *computeAlpha.Instance) error
@@ -142,9 +268,15 @@ type TestClient struct {
 	// Beta API calls
 	CreateInstanceBetaFn func(project, zone string, i *computeBeta.Instance) error
 
-	zoneOperationsWaitFn   func(project, zone, name string) error
-	regionOperationsWaitFn func(project, region, name string) error
-	globalOperationsWaitFn func(project, name string) error
+	zoneOperationsWaitFn         func(project, zone, name string) error
+	regionOperationsWaitFn       func(project, region, name string) error
+	globalOperationsWaitFn       func(project, name string) error
+	organizationOperationsWaitFn func(name string) error
+
+	zoneOperationsWaitBetaFn    func(project, zone, name string) error
+	globalOperationsWaitBetaFn  func(project, name string) error
+	zoneOperationsWaitAlphaFn   func(project, zone, name string) error
+	globalOperationsWaitAlphaFn func(project, name string) error
 }
 
 // Retry uses the override method RetryFn or the real implementation.
@@ -518,9 +650,9 @@ func (c *TestClient) GetLicense(project, name string) (*compute.License, error)
 // ListLicenses uses the override method ListLicensesFn or the real implementation.
 func (c *TestClient) ListLicenses(project string, opts ...ListCallOption) ([]*compute.License, error) {
 	if c.ListLicensesFn != nil {
-		return c.ListLicensesFn(project)
+		return c.ListLicensesFn(project, opts...)
 	}
-	return c.client.ListLicenses(project)
+	return c.client.ListLicenses(project, opts...)
 }
 
 // GetNetwork uses the override method GetNetworkFn or the real implementation.
@@ -667,6 +799,14 @@ func (c *TestClient) globalOperationsWait(project, name string) error {
 	return c.client.globalOperationsWait(project, name)
 }
 
+// organizationOperationsWait uses the override method organizationOperationsWaitFn or the real implementation.
+func (c *TestClient) organizationOperationsWait(name string) error {
+	if c.organizationOperationsWaitFn != nil {
+		return c.organizationOperationsWaitFn(name)
+	}
+	return c.client.organizationOperationsWait(name)
+}
+
 // ListMachineImages uses the override method ListMachineImagesFn or the real implementation.
 func (c *TestClient) ListMachineImages(project string, opts ...ListCallOption) ([]*compute.MachineImage, error) {
 	if c.ListMachineImagesFn != nil {
@@ -874,3 +1014,1003 @@ func (c *TestClient) GetRegionNetworkEndpointGroup(project, region, name string)
 	}
 	return c.client.GetRegionNetworkEndpointGroup(project, region, name)
 }
+
+// ListAttachedAccelerators uses the override method ListAttachedAcceleratorsFn or the real implementation.
+func (c *TestClient) ListAttachedAccelerators(project, zone string) (map[string][]*compute.AcceleratorConfig, error) {
+	if c.ListAttachedAcceleratorsFn != nil {
+		return c.ListAttachedAcceleratorsFn(project, zone)
+	}
+	return c.client.ListAttachedAccelerators(project, zone)
+}
+
+// ListRegions uses the override method ListRegionsFn or the real implementation.
+func (c *TestClient) ListRegions(project string, opts ...ListCallOption) ([]*compute.Region, error) {
+	if c.ListRegionsFn != nil {
+		return c.ListRegionsFn(project, opts...)
+	}
+	return c.client.ListRegions(project, opts...)
+}
+
+// ResumeWithEncryptionKey uses the override method ResumeWithEncryptionKeyFn or the real implementation.
+func (c *TestClient) ResumeWithEncryptionKey(project, zone, instance string, req *computeBeta.InstancesResumeRequest) error {
+	if c.ResumeWithEncryptionKeyFn != nil {
+		return c.ResumeWithEncryptionKeyFn(project, zone, instance, req)
+	}
+	return c.client.ResumeWithEncryptionKey(project, zone, instance, req)
+}
+
+// CreateInstanceInZones uses the override method CreateInstanceInZonesFn or the real implementation.
+func (c *TestClient) CreateInstanceInZones(project string, zones []string, i *compute.Instance) (string, error) {
+	if c.CreateInstanceInZonesFn != nil {
+		return c.CreateInstanceInZonesFn(project, zones, i)
+	}
+	return c.client.CreateInstanceInZones(project, zones, i)
+}
+
+// WaitForInstanceRunning uses the override method WaitForInstanceRunningFn or the real implementation.
+func (c *TestClient) WaitForInstanceRunning(project, zone, name string, timeout time.Duration) error {
+	if c.WaitForInstanceRunningFn != nil {
+		return c.WaitForInstanceRunningFn(project, zone, name, timeout)
+	}
+	return c.client.WaitForInstanceRunning(project, zone, name, timeout)
+}
+
+// SetProjectMetadataItem uses the override method SetProjectMetadataItemFn or the real implementation.
+func (c *TestClient) SetProjectMetadataItem(project, key, value string) error {
+	if c.SetProjectMetadataItemFn != nil {
+		return c.SetProjectMetadataItemFn(project, key, value)
+	}
+	return c.client.SetProjectMetadataItem(project, key, value)
+}
+
+// GetAcceleratorType uses the override method GetAcceleratorTypeFn or the real implementation.
+func (c *TestClient) GetAcceleratorType(project, zone, acceleratorType string) (*compute.AcceleratorType, error) {
+	if c.GetAcceleratorTypeFn != nil {
+		return c.GetAcceleratorTypeFn(project, zone, acceleratorType)
+	}
+	return c.client.GetAcceleratorType(project, zone, acceleratorType)
+}
+
+// ListAcceleratorTypes uses the override method ListAcceleratorTypesFn or the real implementation.
+func (c *TestClient) ListAcceleratorTypes(project, zone string, opts ...ListCallOption) ([]*compute.AcceleratorType, error) {
+	if c.ListAcceleratorTypesFn != nil {
+		return c.ListAcceleratorTypesFn(project, zone, opts...)
+	}
+	return c.client.ListAcceleratorTypes(project, zone, opts...)
+}
+
+// BulkInsertInstance uses the override method BulkInsertInstanceFn or the real implementation.
+func (c *TestClient) BulkInsertInstance(project, zone string, r *compute.BulkInsertInstanceResource) error {
+	if c.BulkInsertInstanceFn != nil {
+		return c.BulkInsertInstanceFn(project, zone, r)
+	}
+	return c.client.BulkInsertInstance(project, zone, r)
+}
+
+// GetBulkInsertInstanceResult uses the override method GetBulkInsertInstanceResultFn or the real implementation.
+func (c *TestClient) GetBulkInsertInstanceResult(project, zone string, r *compute.BulkInsertInstanceResource) (running, failed []string, err error) {
+	if c.GetBulkInsertInstanceResultFn != nil {
+		return c.GetBulkInsertInstanceResultFn(project, zone, r)
+	}
+	return c.client.GetBulkInsertInstanceResult(project, zone, r)
+}
+
+// PatchRegionBackendService uses the override method PatchRegionBackendServiceFn or the real implementation.
+func (c *TestClient) PatchRegionBackendService(project, region, name string, b *compute.BackendService) error {
+	if c.PatchRegionBackendServiceFn != nil {
+		return c.PatchRegionBackendServiceFn(project, region, name, b)
+	}
+	return c.client.PatchRegionBackendService(project, region, name, b)
+}
+
+// ValidateRegionURLMap uses the override method ValidateRegionURLMapFn or the real implementation.
+func (c *TestClient) ValidateRegionURLMap(project, region string, u *compute.UrlMap) (*compute.UrlMapsValidateResponse, error) {
+	if c.ValidateRegionURLMapFn != nil {
+		return c.ValidateRegionURLMapFn(project, region, u)
+	}
+	return c.client.ValidateRegionURLMap(project, region, u)
+}
+
+// ValidateURLMap uses the override method ValidateURLMapFn or the real implementation.
+func (c *TestClient) ValidateURLMap(project string, u *compute.UrlMap) (*compute.UrlMapsValidateResponse, error) {
+	if c.ValidateURLMapFn != nil {
+		return c.ValidateURLMapFn(project, u)
+	}
+	return c.client.ValidateURLMap(project, u)
+}
+
+// CreateInstanceGroup uses the override method CreateInstanceGroupFn or the real implementation.
+func (c *TestClient) CreateInstanceGroup(project, zone string, ig *compute.InstanceGroup) error {
+	if c.CreateInstanceGroupFn != nil {
+		return c.CreateInstanceGroupFn(project, zone, ig)
+	}
+	return c.client.CreateInstanceGroup(project, zone, ig)
+}
+
+// DeleteInstanceGroup uses the override method DeleteInstanceGroupFn or the real implementation.
+func (c *TestClient) DeleteInstanceGroup(project, zone, name string) error {
+	if c.DeleteInstanceGroupFn != nil {
+		return c.DeleteInstanceGroupFn(project, zone, name)
+	}
+	return c.client.DeleteInstanceGroup(project, zone, name)
+}
+
+// GetInstanceGroup uses the override method GetInstanceGroupFn or the real implementation.
+func (c *TestClient) GetInstanceGroup(project, zone, name string) (*compute.InstanceGroup, error) {
+	if c.GetInstanceGroupFn != nil {
+		return c.GetInstanceGroupFn(project, zone, name)
+	}
+	return c.client.GetInstanceGroup(project, zone, name)
+}
+
+// AddInstanceGroupInstances uses the override method AddInstanceGroupInstancesFn or the real implementation.
+func (c *TestClient) AddInstanceGroupInstances(project, zone, name string, instances []string) error {
+	if c.AddInstanceGroupInstancesFn != nil {
+		return c.AddInstanceGroupInstancesFn(project, zone, name, instances)
+	}
+	return c.client.AddInstanceGroupInstances(project, zone, name, instances)
+}
+
+// RemoveInstanceGroupInstances uses the override method RemoveInstanceGroupInstancesFn or the real implementation.
+func (c *TestClient) RemoveInstanceGroupInstances(project, zone, name string, instances []string) error {
+	if c.RemoveInstanceGroupInstancesFn != nil {
+		return c.RemoveInstanceGroupInstancesFn(project, zone, name, instances)
+	}
+	return c.client.RemoveInstanceGroupInstances(project, zone, name, instances)
+}
+
+// AttachNetworkEndpoints uses the override method AttachNetworkEndpointsFn or the real implementation.
+func (c *TestClient) AttachNetworkEndpoints(project, zone, neg string, endpoints []*compute.NetworkEndpoint) error {
+	if c.AttachNetworkEndpointsFn != nil {
+		return c.AttachNetworkEndpointsFn(project, zone, neg, endpoints)
+	}
+	return c.client.AttachNetworkEndpoints(project, zone, neg, endpoints)
+}
+
+// DetachNetworkEndpoints uses the override method DetachNetworkEndpointsFn or the real implementation.
+func (c *TestClient) DetachNetworkEndpoints(project, zone, neg string, endpoints []*compute.NetworkEndpoint) error {
+	if c.DetachNetworkEndpointsFn != nil {
+		return c.DetachNetworkEndpointsFn(project, zone, neg, endpoints)
+	}
+	return c.client.DetachNetworkEndpoints(project, zone, neg, endpoints)
+}
+
+// AttachRegionNetworkEndpoints uses the override method AttachRegionNetworkEndpointsFn or the real implementation.
+func (c *TestClient) AttachRegionNetworkEndpoints(project, region, neg string, endpoints []*compute.NetworkEndpoint) error {
+	if c.AttachRegionNetworkEndpointsFn != nil {
+		return c.AttachRegionNetworkEndpointsFn(project, region, neg, endpoints)
+	}
+	return c.client.AttachRegionNetworkEndpoints(project, region, neg, endpoints)
+}
+
+// DetachRegionNetworkEndpoints uses the override method DetachRegionNetworkEndpointsFn or the real implementation.
+func (c *TestClient) DetachRegionNetworkEndpoints(project, region, neg string, endpoints []*compute.NetworkEndpoint) error {
+	if c.DetachRegionNetworkEndpointsFn != nil {
+		return c.DetachRegionNetworkEndpointsFn(project, region, neg, endpoints)
+	}
+	return c.client.DetachRegionNetworkEndpoints(project, region, neg, endpoints)
+}
+
+// CreateDiskFromImageAndAttach uses the override method CreateDiskFromImageAndAttachFn or the real implementation.
+func (c *TestClient) CreateDiskFromImageAndAttach(project, zone, instance, image string, d *compute.Disk) error {
+	if c.CreateDiskFromImageAndAttachFn != nil {
+		return c.CreateDiskFromImageAndAttachFn(project, zone, instance, image, d)
+	}
+	return c.client.CreateDiskFromImageAndAttach(project, zone, instance, image, d)
+}
+
+// GetRegionAutoscaler uses the override method GetRegionAutoscalerFn or the real implementation.
+func (c *TestClient) GetRegionAutoscaler(project, region, name string) (*compute.Autoscaler, error) {
+	if c.GetRegionAutoscalerFn != nil {
+		return c.GetRegionAutoscalerFn(project, region, name)
+	}
+	return c.client.GetRegionAutoscaler(project, region, name)
+}
+
+// ListRegionAutoscalers uses the override method ListRegionAutoscalersFn or the real implementation.
+func (c *TestClient) ListRegionAutoscalers(project, region string, opts ...ListCallOption) ([]*compute.Autoscaler, error) {
+	if c.ListRegionAutoscalersFn != nil {
+		return c.ListRegionAutoscalersFn(project, region, opts...)
+	}
+	return c.client.ListRegionAutoscalers(project, region, opts...)
+}
+
+// GetRegionAutoscalerRecommendedSize uses the override method GetRegionAutoscalerRecommendedSizeFn or the real implementation.
+func (c *TestClient) GetRegionAutoscalerRecommendedSize(project, region, name string) (int64, error) {
+	if c.GetRegionAutoscalerRecommendedSizeFn != nil {
+		return c.GetRegionAutoscalerRecommendedSizeFn(project, region, name)
+	}
+	return c.client.GetRegionAutoscalerRecommendedSize(project, region, name)
+}
+
+// DeleteInstancesByFilter uses the override method DeleteInstancesByFilterFn or the real implementation.
+func (c *TestClient) DeleteInstancesByFilter(project, zone, filter string) error {
+	if c.DeleteInstancesByFilterFn != nil {
+		return c.DeleteInstancesByFilterFn(project, zone, filter)
+	}
+	return c.client.DeleteInstancesByFilter(project, zone, filter)
+}
+
+// zoneOperationsWaitBeta uses the override method zoneOperationsWaitBetaFn or the real implementation.
+func (c *TestClient) zoneOperationsWaitBeta(project, zone, name string) error {
+	if c.zoneOperationsWaitBetaFn != nil {
+		return c.zoneOperationsWaitBetaFn(project, zone, name)
+	}
+	return c.client.zoneOperationsWaitBeta(project, zone, name)
+}
+
+// globalOperationsWaitBeta uses the override method globalOperationsWaitBetaFn or the real implementation.
+func (c *TestClient) globalOperationsWaitBeta(project, name string) error {
+	if c.globalOperationsWaitBetaFn != nil {
+		return c.globalOperationsWaitBetaFn(project, name)
+	}
+	return c.client.globalOperationsWaitBeta(project, name)
+}
+
+// zoneOperationsWaitAlpha uses the override method zoneOperationsWaitAlphaFn or the real implementation.
+func (c *TestClient) zoneOperationsWaitAlpha(project, zone, name string) error {
+	if c.zoneOperationsWaitAlphaFn != nil {
+		return c.zoneOperationsWaitAlphaFn(project, zone, name)
+	}
+	return c.client.zoneOperationsWaitAlpha(project, zone, name)
+}
+
+// globalOperationsWaitAlpha uses the override method globalOperationsWaitAlphaFn or the real implementation.
+func (c *TestClient) globalOperationsWaitAlpha(project, name string) error {
+	if c.globalOperationsWaitAlphaFn != nil {
+		return c.globalOperationsWaitAlphaFn(project, name)
+	}
+	return c.client.globalOperationsWaitAlpha(project, name)
+}
+
+// SetDeletionProtection uses the override method SetDeletionProtectionFn or the real implementation.
+func (c *TestClient) SetDeletionProtection(project, zone, instance string, enabled bool) error {
+	if c.SetDeletionProtectionFn != nil {
+		return c.SetDeletionProtectionFn(project, zone, instance, enabled)
+	}
+	return c.client.SetDeletionProtection(project, zone, instance, enabled)
+}
+
+// WaitForGuestAttribute uses the override method WaitForGuestAttributeFn or the real implementation.
+func (c *TestClient) WaitForGuestAttribute(project, zone, instance, namespace, key, wantValue string, timeout time.Duration) error {
+	if c.WaitForGuestAttributeFn != nil {
+		return c.WaitForGuestAttributeFn(project, zone, instance, namespace, key, wantValue, timeout)
+	}
+	return c.client.WaitForGuestAttribute(project, zone, instance, namespace, key, wantValue, timeout)
+}
+
+// WaitForGuestAttributeContext uses the override method WaitForGuestAttributeContextFn or the real implementation.
+func (c *TestClient) WaitForGuestAttributeContext(ctx context.Context, project, zone, instance, namespace, key, wantValue string) error {
+	if c.WaitForGuestAttributeContextFn != nil {
+		return c.WaitForGuestAttributeContextFn(ctx, project, zone, instance, namespace, key, wantValue)
+	}
+	return c.client.WaitForGuestAttributeContext(ctx, project, zone, instance, namespace, key, wantValue)
+}
+
+// DeleteRegionSSLCertificate uses the override method DeleteRegionSSLCertificateFn or the real implementation.
+func (c *TestClient) DeleteRegionSSLCertificate(project, region, name string) error {
+	if c.DeleteRegionSSLCertificateFn != nil {
+		return c.DeleteRegionSSLCertificateFn(project, region, name)
+	}
+	return c.client.DeleteRegionSSLCertificate(project, region, name)
+}
+
+// CreateRegionSSLCertificate uses the override method CreateRegionSSLCertificateFn or the real implementation.
+func (c *TestClient) CreateRegionSSLCertificate(project, region string, sc *compute.SslCertificate) error {
+	if c.CreateRegionSSLCertificateFn != nil {
+		return c.CreateRegionSSLCertificateFn(project, region, sc)
+	}
+	return c.client.CreateRegionSSLCertificate(project, region, sc)
+}
+
+// GetRegionSSLCertificate uses the override method GetRegionSSLCertificateFn or the real implementation.
+func (c *TestClient) GetRegionSSLCertificate(project, region, name string) (*compute.SslCertificate, error) {
+	if c.GetRegionSSLCertificateFn != nil {
+		return c.GetRegionSSLCertificateFn(project, region, name)
+	}
+	return c.client.GetRegionSSLCertificate(project, region, name)
+}
+
+// DeleteRegionTargetHTTPSProxy uses the override method DeleteRegionTargetHTTPSProxyFn or the real implementation.
+func (c *TestClient) DeleteRegionTargetHTTPSProxy(project, region, name string) error {
+	if c.DeleteRegionTargetHTTPSProxyFn != nil {
+		return c.DeleteRegionTargetHTTPSProxyFn(project, region, name)
+	}
+	return c.client.DeleteRegionTargetHTTPSProxy(project, region, name)
+}
+
+// CreateRegionTargetHTTPSProxy uses the override method CreateRegionTargetHTTPSProxyFn or the real implementation.
+func (c *TestClient) CreateRegionTargetHTTPSProxy(project, region string, p *compute.TargetHttpsProxy) error {
+	if c.CreateRegionTargetHTTPSProxyFn != nil {
+		return c.CreateRegionTargetHTTPSProxyFn(project, region, p)
+	}
+	return c.client.CreateRegionTargetHTTPSProxy(project, region, p)
+}
+
+// GetRegionTargetHTTPSProxy uses the override method GetRegionTargetHTTPSProxyFn or the real implementation.
+func (c *TestClient) GetRegionTargetHTTPSProxy(project, region, name string) (*compute.TargetHttpsProxy, error) {
+	if c.GetRegionTargetHTTPSProxyFn != nil {
+		return c.GetRegionTargetHTTPSProxyFn(project, region, name)
+	}
+	return c.client.GetRegionTargetHTTPSProxy(project, region, name)
+}
+
+// SetRegionSSLCertificates uses the override method SetRegionSSLCertificatesFn or the real implementation.
+func (c *TestClient) SetRegionSSLCertificates(project, region, proxy string, sslCertificates []string) error {
+	if c.SetRegionSSLCertificatesFn != nil {
+		return c.SetRegionSSLCertificatesFn(project, region, proxy, sslCertificates)
+	}
+	return c.client.SetRegionSSLCertificates(project, region, proxy, sslCertificates)
+}
+
+// WaitForOperationWithProgress uses the override method WaitForOperationWithProgressFn or the real implementation.
+func (c *TestClient) WaitForOperationWithProgress(project, selfLink string, onProgress func(percent int)) error {
+	if c.WaitForOperationWithProgressFn != nil {
+		return c.WaitForOperationWithProgressFn(project, selfLink, onProgress)
+	}
+	return c.client.WaitForOperationWithProgress(project, selfLink, onProgress)
+}
+
+// CreateInstanceIfNotExists uses the override method CreateInstanceIfNotExistsFn or the real implementation.
+func (c *TestClient) CreateInstanceIfNotExists(project, zone string, i *compute.Instance) (bool, error) {
+	if c.CreateInstanceIfNotExistsFn != nil {
+		return c.CreateInstanceIfNotExistsFn(project, zone, i)
+	}
+	return c.client.CreateInstanceIfNotExists(project, zone, i)
+}
+
+// GetBackendService uses the override method GetBackendServiceFn or the real implementation.
+func (c *TestClient) GetBackendService(project, name string) (*compute.BackendService, error) {
+	if c.GetBackendServiceFn != nil {
+		return c.GetBackendServiceFn(project, name)
+	}
+	return c.client.GetBackendService(project, name)
+}
+
+// GetHealthCheck uses the override method GetHealthCheckFn or the real implementation.
+func (c *TestClient) GetHealthCheck(project, name string) (*compute.HealthCheck, error) {
+	if c.GetHealthCheckFn != nil {
+		return c.GetHealthCheckFn(project, name)
+	}
+	return c.client.GetHealthCheck(project, name)
+}
+
+// GetURLMap uses the override method GetURLMapFn or the real implementation.
+func (c *TestClient) GetURLMap(project, name string) (*compute.UrlMap, error) {
+	if c.GetURLMapFn != nil {
+		return c.GetURLMapFn(project, name)
+	}
+	return c.client.GetURLMap(project, name)
+}
+
+// GetTargetHTTPProxy uses the override method GetTargetHTTPProxyFn or the real implementation.
+func (c *TestClient) GetTargetHTTPProxy(project, name string) (*compute.TargetHttpProxy, error) {
+	if c.GetTargetHTTPProxyFn != nil {
+		return c.GetTargetHTTPProxyFn(project, name)
+	}
+	return c.client.GetTargetHTTPProxy(project, name)
+}
+
+// GetNetworkEndpointGroup uses the override method GetNetworkEndpointGroupFn or the real implementation.
+func (c *TestClient) GetNetworkEndpointGroup(project, zone, name string) (*compute.NetworkEndpointGroup, error) {
+	if c.GetNetworkEndpointGroupFn != nil {
+		return c.GetNetworkEndpointGroupFn(project, zone, name)
+	}
+	return c.client.GetNetworkEndpointGroup(project, zone, name)
+}
+
+// UpdateNetworkInterface uses the override method UpdateNetworkInterfaceFn or the real implementation.
+func (c *TestClient) UpdateNetworkInterface(project, zone, instance, networkInterface string, ni *compute.NetworkInterface) error {
+	if c.UpdateNetworkInterfaceFn != nil {
+		return c.UpdateNetworkInterfaceFn(project, zone, instance, networkInterface, ni)
+	}
+	return c.client.UpdateNetworkInterface(project, zone, instance, networkInterface, ni)
+}
+
+// AggregatedListInstancesByZone uses the override method AggregatedListInstancesByZoneFn or the real implementation.
+func (c *TestClient) AggregatedListInstancesByZone(project string, opts ...ListCallOption) (map[string][]*compute.Instance, error) {
+	if c.AggregatedListInstancesByZoneFn != nil {
+		return c.AggregatedListInstancesByZoneFn(project, opts...)
+	}
+	return c.client.AggregatedListInstancesByZone(project, opts...)
+}
+
+// AggregatedListDisksByZone uses the override method AggregatedListDisksByZoneFn or the real implementation.
+func (c *TestClient) AggregatedListDisksByZone(project string, opts ...ListCallOption) (map[string][]*compute.Disk, error) {
+	if c.AggregatedListDisksByZoneFn != nil {
+		return c.AggregatedListDisksByZoneFn(project, opts...)
+	}
+	return c.client.AggregatedListDisksByZone(project, opts...)
+}
+
+// DeleteOperation uses the override method DeleteOperationFn or the real implementation.
+func (c *TestClient) DeleteOperation(project, selfLink string) error {
+	if c.DeleteOperationFn != nil {
+		return c.DeleteOperationFn(project, selfLink)
+	}
+	return c.client.DeleteOperation(project, selfLink)
+}
+
+// SetSnapshotLabels uses the override method SetSnapshotLabelsFn or the real implementation.
+func (c *TestClient) SetSnapshotLabels(project, name string, labels map[string]string, fingerprint string) error {
+	if c.SetSnapshotLabelsFn != nil {
+		return c.SetSnapshotLabelsFn(project, name, labels, fingerprint)
+	}
+	return c.client.SetSnapshotLabels(project, name, labels, fingerprint)
+}
+
+// StopInstanceWithOptions uses the override method StopInstanceWithOptionsFn or the real implementation.
+func (c *TestClient) StopInstanceWithOptions(project, zone, name string, discardLocalSSD bool) error {
+	if c.StopInstanceWithOptionsFn != nil {
+		return c.StopInstanceWithOptionsFn(project, zone, name, discardLocalSSD)
+	}
+	return c.client.StopInstanceWithOptions(project, zone, name, discardLocalSSD)
+}
+
+// WaitForInstanceStopped uses the override method WaitForInstanceStoppedFn or the real implementation.
+func (c *TestClient) WaitForInstanceStopped(project, zone, name string, timeout time.Duration) error {
+	if c.WaitForInstanceStoppedFn != nil {
+		return c.WaitForInstanceStoppedFn(project, zone, name, timeout)
+	}
+	return c.client.WaitForInstanceStopped(project, zone, name, timeout)
+}
+
+// ListZoneOperations uses the override method ListZoneOperationsFn or the real implementation.
+func (c *TestClient) ListZoneOperations(project, zone string, opts ...ListCallOption) ([]*compute.Operation, error) {
+	if c.ListZoneOperationsFn != nil {
+		return c.ListZoneOperationsFn(project, zone, opts...)
+	}
+	return c.client.ListZoneOperations(project, zone, opts...)
+}
+
+// ListRegionOperations uses the override method ListRegionOperationsFn or the real implementation.
+func (c *TestClient) ListRegionOperations(project, region string, opts ...ListCallOption) ([]*compute.Operation, error) {
+	if c.ListRegionOperationsFn != nil {
+		return c.ListRegionOperationsFn(project, region, opts...)
+	}
+	return c.client.ListRegionOperations(project, region, opts...)
+}
+
+// ListGlobalOperations uses the override method ListGlobalOperationsFn or the real implementation.
+func (c *TestClient) ListGlobalOperations(project string, opts ...ListCallOption) ([]*compute.Operation, error) {
+	if c.ListGlobalOperationsFn != nil {
+		return c.ListGlobalOperationsFn(project, opts...)
+	}
+	return c.client.ListGlobalOperations(project, opts...)
+}
+
+// ResetWindowsPassword uses the override method ResetWindowsPasswordFn or the real implementation.
+func (c *TestClient) ResetWindowsPassword(project, zone, instance, username string) (string, error) {
+	if c.ResetWindowsPasswordFn != nil {
+		return c.ResetWindowsPasswordFn(project, zone, instance, username)
+	}
+	return c.client.ResetWindowsPassword(project, zone, instance, username)
+}
+
+// GetLatestImageFromFamilies uses the override method GetLatestImageFromFamiliesFn or the real implementation.
+func (c *TestClient) GetLatestImageFromFamilies(projects []string, family string) (*compute.Image, error) {
+	if c.GetLatestImageFromFamiliesFn != nil {
+		return c.GetLatestImageFromFamiliesFn(projects, family)
+	}
+	return c.client.GetLatestImageFromFamilies(projects, family)
+}
+
+// CreateInstanceFromMachineImage uses the override method CreateInstanceFromMachineImageFn or the real implementation.
+func (c *TestClient) CreateInstanceFromMachineImage(project, zone, machineImage string, i *compute.Instance) error {
+	if c.CreateInstanceFromMachineImageFn != nil {
+		return c.CreateInstanceFromMachineImageFn(project, zone, machineImage, i)
+	}
+	return c.client.CreateInstanceFromMachineImage(project, zone, machineImage, i)
+}
+
+// GetShieldedInstanceIdentity uses the override method GetShieldedInstanceIdentityFn or the real implementation.
+func (c *TestClient) GetShieldedInstanceIdentity(project, zone, instance string) (*compute.ShieldedInstanceIdentity, error) {
+	if c.GetShieldedInstanceIdentityFn != nil {
+		return c.GetShieldedInstanceIdentityFn(project, zone, instance)
+	}
+	return c.client.GetShieldedInstanceIdentity(project, zone, instance)
+}
+
+// SetShieldedInstanceIntegrityPolicy uses the override method SetShieldedInstanceIntegrityPolicyFn or the real implementation.
+func (c *TestClient) SetShieldedInstanceIntegrityPolicy(project, zone, instance string, p *compute.ShieldedInstanceIntegrityPolicy) error {
+	if c.SetShieldedInstanceIntegrityPolicyFn != nil {
+		return c.SetShieldedInstanceIntegrityPolicyFn(project, zone, instance, p)
+	}
+	return c.client.SetShieldedInstanceIntegrityPolicy(project, zone, instance, p)
+}
+
+// CreateHTTPHealthCheck uses the override method CreateHTTPHealthCheckFn or the real implementation.
+func (c *TestClient) CreateHTTPHealthCheck(project string, hc *compute.HttpHealthCheck) error {
+	if c.CreateHTTPHealthCheckFn != nil {
+		return c.CreateHTTPHealthCheckFn(project, hc)
+	}
+	return c.client.CreateHTTPHealthCheck(project, hc)
+}
+
+// GetHTTPHealthCheck uses the override method GetHTTPHealthCheckFn or the real implementation.
+func (c *TestClient) GetHTTPHealthCheck(project, name string) (*compute.HttpHealthCheck, error) {
+	if c.GetHTTPHealthCheckFn != nil {
+		return c.GetHTTPHealthCheckFn(project, name)
+	}
+	return c.client.GetHTTPHealthCheck(project, name)
+}
+
+// DeleteHTTPHealthCheck uses the override method DeleteHTTPHealthCheckFn or the real implementation.
+func (c *TestClient) DeleteHTTPHealthCheck(project, name string) error {
+	if c.DeleteHTTPHealthCheckFn != nil {
+		return c.DeleteHTTPHealthCheckFn(project, name)
+	}
+	return c.client.DeleteHTTPHealthCheck(project, name)
+}
+
+// CreateHTTPSHealthCheck uses the override method CreateHTTPSHealthCheckFn or the real implementation.
+func (c *TestClient) CreateHTTPSHealthCheck(project string, hc *compute.HttpsHealthCheck) error {
+	if c.CreateHTTPSHealthCheckFn != nil {
+		return c.CreateHTTPSHealthCheckFn(project, hc)
+	}
+	return c.client.CreateHTTPSHealthCheck(project, hc)
+}
+
+// GetHTTPSHealthCheck uses the override method GetHTTPSHealthCheckFn or the real implementation.
+func (c *TestClient) GetHTTPSHealthCheck(project, name string) (*compute.HttpsHealthCheck, error) {
+	if c.GetHTTPSHealthCheckFn != nil {
+		return c.GetHTTPSHealthCheckFn(project, name)
+	}
+	return c.client.GetHTTPSHealthCheck(project, name)
+}
+
+// DeleteHTTPSHealthCheck uses the override method DeleteHTTPSHealthCheckFn or the real implementation.
+func (c *TestClient) DeleteHTTPSHealthCheck(project, name string) error {
+	if c.DeleteHTTPSHealthCheckFn != nil {
+		return c.DeleteHTTPSHealthCheckFn(project, name)
+	}
+	return c.client.DeleteHTTPSHealthCheck(project, name)
+}
+
+// GetInstanceReferrers uses the override method GetInstanceReferrersFn or the real implementation.
+func (c *TestClient) GetInstanceReferrers(project, zone, instance string) ([]*compute.Reference, error) {
+	if c.GetInstanceReferrersFn != nil {
+		return c.GetInstanceReferrersFn(project, zone, instance)
+	}
+	return c.client.GetInstanceReferrers(project, zone, instance)
+}
+
+// ListDiskUsers uses the override method ListDiskUsersFn or the real implementation.
+func (c *TestClient) ListDiskUsers(project, zone, disk string) ([]*compute.Instance, error) {
+	if c.ListDiskUsersFn != nil {
+		return c.ListDiskUsersFn(project, zone, disk)
+	}
+	return c.client.ListDiskUsers(project, zone, disk)
+}
+
+// CreateSecurityPolicy uses the override method CreateSecurityPolicyFn or the real implementation.
+func (c *TestClient) CreateSecurityPolicy(project string, sp *compute.SecurityPolicy) error {
+	if c.CreateSecurityPolicyFn != nil {
+		return c.CreateSecurityPolicyFn(project, sp)
+	}
+	return c.client.CreateSecurityPolicy(project, sp)
+}
+
+// GetSecurityPolicy uses the override method GetSecurityPolicyFn or the real implementation.
+func (c *TestClient) GetSecurityPolicy(project, name string) (*compute.SecurityPolicy, error) {
+	if c.GetSecurityPolicyFn != nil {
+		return c.GetSecurityPolicyFn(project, name)
+	}
+	return c.client.GetSecurityPolicy(project, name)
+}
+
+// DeleteSecurityPolicy uses the override method DeleteSecurityPolicyFn or the real implementation.
+func (c *TestClient) DeleteSecurityPolicy(project, name string) error {
+	if c.DeleteSecurityPolicyFn != nil {
+		return c.DeleteSecurityPolicyFn(project, name)
+	}
+	return c.client.DeleteSecurityPolicy(project, name)
+}
+
+// AddSecurityPolicyRule uses the override method AddSecurityPolicyRuleFn or the real implementation.
+func (c *TestClient) AddSecurityPolicyRule(project, securityPolicy string, r *compute.SecurityPolicyRule) error {
+	if c.AddSecurityPolicyRuleFn != nil {
+		return c.AddSecurityPolicyRuleFn(project, securityPolicy, r)
+	}
+	return c.client.AddSecurityPolicyRule(project, securityPolicy, r)
+}
+
+// PatchSecurityPolicyRule uses the override method PatchSecurityPolicyRuleFn or the real implementation.
+func (c *TestClient) PatchSecurityPolicyRule(project, securityPolicy string, priority int64, r *compute.SecurityPolicyRule) error {
+	if c.PatchSecurityPolicyRuleFn != nil {
+		return c.PatchSecurityPolicyRuleFn(project, securityPolicy, priority, r)
+	}
+	return c.client.PatchSecurityPolicyRule(project, securityPolicy, priority, r)
+}
+
+// CreatePacketMirroring uses the override method CreatePacketMirroringFn or the real implementation.
+func (c *TestClient) CreatePacketMirroring(project, region string, pm *compute.PacketMirroring) error {
+	if c.CreatePacketMirroringFn != nil {
+		return c.CreatePacketMirroringFn(project, region, pm)
+	}
+	return c.client.CreatePacketMirroring(project, region, pm)
+}
+
+// GetPacketMirroring uses the override method GetPacketMirroringFn or the real implementation.
+func (c *TestClient) GetPacketMirroring(project, region, name string) (*compute.PacketMirroring, error) {
+	if c.GetPacketMirroringFn != nil {
+		return c.GetPacketMirroringFn(project, region, name)
+	}
+	return c.client.GetPacketMirroring(project, region, name)
+}
+
+// DeletePacketMirroring uses the override method DeletePacketMirroringFn or the real implementation.
+func (c *TestClient) DeletePacketMirroring(project, region, name string) error {
+	if c.DeletePacketMirroringFn != nil {
+		return c.DeletePacketMirroringFn(project, region, name)
+	}
+	return c.client.DeletePacketMirroring(project, region, name)
+}
+
+// TeardownByLabel uses the override method TeardownByLabelFn or the real implementation.
+func (c *TestClient) TeardownByLabel(project, labelKey, labelValue string) error {
+	if c.TeardownByLabelFn != nil {
+		return c.TeardownByLabelFn(project, labelKey, labelValue)
+	}
+	return c.client.TeardownByLabel(project, labelKey, labelValue)
+}
+
+// GetReservation uses the override method GetReservationFn or the real implementation.
+func (c *TestClient) GetReservation(project, zone, name string) (*compute.Reservation, error) {
+	if c.GetReservationFn != nil {
+		return c.GetReservationFn(project, zone, name)
+	}
+	return c.client.GetReservation(project, zone, name)
+}
+
+// CreateInstanceAndGet uses the override method CreateInstanceAndGetFn or the real implementation.
+func (c *TestClient) CreateInstanceAndGet(project, zone string, i *compute.Instance) (*compute.Instance, error) {
+	if c.CreateInstanceAndGetFn != nil {
+		return c.CreateInstanceAndGetFn(project, zone, i)
+	}
+	return c.client.CreateInstanceAndGet(project, zone, i)
+}
+
+// SetUsageExportBucket uses the override method SetUsageExportBucketFn or the real implementation.
+func (c *TestClient) SetUsageExportBucket(project string, cfg *compute.UsageExportLocation) error {
+	if c.SetUsageExportBucketFn != nil {
+		return c.SetUsageExportBucketFn(project, cfg)
+	}
+	return c.client.SetUsageExportBucket(project, cfg)
+}
+
+// GetXpnHost uses the override method GetXpnHostFn or the real implementation.
+func (c *TestClient) GetXpnHost(project string) (*compute.Project, error) {
+	if c.GetXpnHostFn != nil {
+		return c.GetXpnHostFn(project)
+	}
+	return c.client.GetXpnHost(project)
+}
+
+// ListXpnHosts uses the override method ListXpnHostsFn or the real implementation.
+func (c *TestClient) ListXpnHosts(project, organization string) ([]*compute.Project, error) {
+	if c.ListXpnHostsFn != nil {
+		return c.ListXpnHostsFn(project, organization)
+	}
+	return c.client.ListXpnHosts(project, organization)
+}
+
+// EnableXpnResource uses the override method EnableXpnResourceFn or the real implementation.
+func (c *TestClient) EnableXpnResource(project string, req *compute.ProjectsEnableXpnResourceRequest) error {
+	if c.EnableXpnResourceFn != nil {
+		return c.EnableXpnResourceFn(project, req)
+	}
+	return c.client.EnableXpnResource(project, req)
+}
+
+// DisableXpnResource uses the override method DisableXpnResourceFn or the real implementation.
+func (c *TestClient) DisableXpnResource(project string, req *compute.ProjectsDisableXpnResourceRequest) error {
+	if c.DisableXpnResourceFn != nil {
+		return c.DisableXpnResourceFn(project, req)
+	}
+	return c.client.DisableXpnResource(project, req)
+}
+
+// GetEffectiveFirewalls uses the override method GetEffectiveFirewallsFn or the real implementation.
+func (c *TestClient) GetEffectiveFirewalls(project, zone, instance, networkInterface string) (*compute.InstancesGetEffectiveFirewallsResponse, error) {
+	if c.GetEffectiveFirewallsFn != nil {
+		return c.GetEffectiveFirewallsFn(project, zone, instance, networkInterface)
+	}
+	return c.client.GetEffectiveFirewalls(project, zone, instance, networkInterface)
+}
+
+// CreateDiskFromSnapshot uses the override method CreateDiskFromSnapshotFn or the real implementation.
+func (c *TestClient) CreateDiskFromSnapshot(project, zone, diskName, snapshotSelfLink string, sizeGb int64, diskType string) error {
+	if c.CreateDiskFromSnapshotFn != nil {
+		return c.CreateDiskFromSnapshotFn(project, zone, diskName, snapshotSelfLink, sizeGb, diskType)
+	}
+	return c.client.CreateDiskFromSnapshot(project, zone, diskName, snapshotSelfLink, sizeGb, diskType)
+}
+
+// ListInstancesByStatus uses the override method ListInstancesByStatusFn or the real implementation.
+func (c *TestClient) ListInstancesByStatus(project, zone, status string) ([]*compute.Instance, error) {
+	if c.ListInstancesByStatusFn != nil {
+		return c.ListInstancesByStatusFn(project, zone, status)
+	}
+	return c.client.ListInstancesByStatus(project, zone, status)
+}
+
+// GetScreenshot uses the override method GetScreenshotFn or the real implementation.
+func (c *TestClient) GetScreenshot(project, zone, instance string) (*compute.Screenshot, error) {
+	if c.GetScreenshotFn != nil {
+		return c.GetScreenshotFn(project, zone, instance)
+	}
+	return c.client.GetScreenshot(project, zone, instance)
+}
+
+// CloneDiskReencrypt uses the override method CloneDiskReencryptFn or the real implementation.
+func (c *TestClient) CloneDiskReencrypt(project, zone, sourceDisk, diskName string, key *compute.CustomerEncryptionKey) error {
+	if c.CloneDiskReencryptFn != nil {
+		return c.CloneDiskReencryptFn(project, zone, sourceDisk, diskName, key)
+	}
+	return c.client.CloneDiskReencrypt(project, zone, sourceDisk, diskName, key)
+}
+
+// GetOperationsForTarget uses the override method GetOperationsForTargetFn or the real implementation.
+func (c *TestClient) GetOperationsForTarget(project, scope, targetSelfLink string) ([]*compute.Operation, error) {
+	if c.GetOperationsForTargetFn != nil {
+		return c.GetOperationsForTargetFn(project, scope, targetSelfLink)
+	}
+	return c.client.GetOperationsForTarget(project, scope, targetSelfLink)
+}
+
+// DefaultProject uses the override method DefaultProjectFn or the real implementation.
+func (c *TestClient) DefaultProject() *ProjectClient {
+	if c.DefaultProjectFn != nil {
+		return c.DefaultProjectFn()
+	}
+	return c.client.DefaultProject()
+}
+
+// DeprecateImageFamily uses the override method DeprecateImageFamilyFn or the real implementation.
+func (c *TestClient) DeprecateImageFamily(project, family string, keepLatest int, status *compute.DeprecationStatus) error {
+	if c.DeprecateImageFamilyFn != nil {
+		return c.DeprecateImageFamilyFn(project, family, keepLatest, status)
+	}
+	return c.client.DeprecateImageFamily(project, family, keepLatest, status)
+}
+
+// PerformMaintenance uses the override method PerformMaintenanceFn or the real implementation.
+func (c *TestClient) PerformMaintenance(project, zone, instance string) error {
+	if c.PerformMaintenanceFn != nil {
+		return c.PerformMaintenanceFn(project, zone, instance)
+	}
+	return c.client.PerformMaintenance(project, zone, instance)
+}
+
+// GetBackendServiceHealth uses the override method GetBackendServiceHealthFn or the real implementation.
+func (c *TestClient) GetBackendServiceHealth(project, backendService, group string) (*compute.BackendServiceGroupHealth, error) {
+	if c.GetBackendServiceHealthFn != nil {
+		return c.GetBackendServiceHealthFn(project, backendService, group)
+	}
+	return c.client.GetBackendServiceHealth(project, backendService, group)
+}
+
+// GetRegionBackendServiceHealth uses the override method GetRegionBackendServiceHealthFn or the real implementation.
+func (c *TestClient) GetRegionBackendServiceHealth(project, region, backendService, group string) (*compute.BackendServiceGroupHealth, error) {
+	if c.GetRegionBackendServiceHealthFn != nil {
+		return c.GetRegionBackendServiceHealthFn(project, region, backendService, group)
+	}
+	return c.client.GetRegionBackendServiceHealth(project, region, backendService, group)
+}
+
+// SetInstanceName uses the override method SetInstanceNameFn or the real implementation.
+func (c *TestClient) SetInstanceName(project, zone, instance, newName string) error {
+	if c.SetInstanceNameFn != nil {
+		return c.SetInstanceNameFn(project, zone, instance, newName)
+	}
+	return c.client.SetInstanceName(project, zone, instance, newName)
+}
+
+// ListUnattachedDisks uses the override method ListUnattachedDisksFn or the real implementation.
+func (c *TestClient) ListUnattachedDisks(project, zone string) ([]*compute.Disk, error) {
+	if c.ListUnattachedDisksFn != nil {
+		return c.ListUnattachedDisksFn(project, zone)
+	}
+	return c.client.ListUnattachedDisks(project, zone)
+}
+
+// DeleteUnattachedDisks uses the override method DeleteUnattachedDisksFn or the real implementation.
+func (c *TestClient) DeleteUnattachedDisks(project, zone string) error {
+	if c.DeleteUnattachedDisksFn != nil {
+		return c.DeleteUnattachedDisksFn(project, zone)
+	}
+	return c.client.DeleteUnattachedDisks(project, zone)
+}
+
+// CreateImageFromEncryptedDisk uses the override method CreateImageFromEncryptedDiskFn or the real implementation.
+func (c *TestClient) CreateImageFromEncryptedDisk(project string, im *compute.Image, diskKey *compute.CustomerEncryptionKey) error {
+	if c.CreateImageFromEncryptedDiskFn != nil {
+		return c.CreateImageFromEncryptedDiskFn(project, im, diskKey)
+	}
+	return c.client.CreateImageFromEncryptedDisk(project, im, diskKey)
+}
+
+// UpdateInstance uses the override method UpdateInstanceFn or the real implementation.
+func (c *TestClient) UpdateInstance(project, zone string, i *compute.Instance, fields ...string) error {
+	if c.UpdateInstanceFn != nil {
+		return c.UpdateInstanceFn(project, zone, i, fields...)
+	}
+	return c.client.UpdateInstance(project, zone, i, fields...)
+}
+
+// PickZone uses the override method PickZoneFn or the real implementation.
+func (c *TestClient) PickZone(project, region, machineType string) (string, error) {
+	if c.PickZoneFn != nil {
+		return c.PickZoneFn(project, region, machineType)
+	}
+	return c.client.PickZone(project, region, machineType)
+}
+
+// GetInstanceIamPolicy uses the override method GetInstanceIamPolicyFn or the real implementation.
+func (c *TestClient) GetInstanceIamPolicy(project, zone, instance string) (*compute.Policy, error) {
+	if c.GetInstanceIamPolicyFn != nil {
+		return c.GetInstanceIamPolicyFn(project, zone, instance)
+	}
+	return c.client.GetInstanceIamPolicy(project, zone, instance)
+}
+
+// SetInstanceIamPolicy uses the override method SetInstanceIamPolicyFn or the real implementation.
+func (c *TestClient) SetInstanceIamPolicy(project, zone, instance string, policy *compute.Policy) error {
+	if c.SetInstanceIamPolicyFn != nil {
+		return c.SetInstanceIamPolicyFn(project, zone, instance, policy)
+	}
+	return c.client.SetInstanceIamPolicy(project, zone, instance, policy)
+}
+
+// TestInstanceIamPermissions uses the override method TestInstanceIamPermissionsFn or the real implementation.
+func (c *TestClient) TestInstanceIamPermissions(project, zone, instance string, permissions []string) ([]string, error) {
+	if c.TestInstanceIamPermissionsFn != nil {
+		return c.TestInstanceIamPermissionsFn(project, zone, instance, permissions)
+	}
+	return c.client.TestInstanceIamPermissions(project, zone, instance, permissions)
+}
+
+// CopyImageToLocation uses the override method CopyImageToLocationFn or the real implementation.
+func (c *TestClient) CopyImageToLocation(srcProject, srcImage, dstProject, dstImage string, storageLocations []string) error {
+	if c.CopyImageToLocationFn != nil {
+		return c.CopyImageToLocationFn(srcProject, srcImage, dstProject, dstImage, storageLocations)
+	}
+	return c.client.CopyImageToLocation(srcProject, srcImage, dstProject, dstImage, storageLocations)
+}
+
+// RetryContext uses the override method RetryContextFn or the real implementation.
+func (c *TestClient) RetryContext(ctx context.Context, f func(opts ...googleapi.CallOption) (*compute.Operation, error), opts ...googleapi.CallOption) (op *compute.Operation, err error) {
+	if c.RetryContextFn != nil {
+		return c.RetryContextFn(ctx, f, opts...)
+	}
+	return c.client.RetryContext(ctx, f, opts...)
+}
+
+// GetFirewallPolicy uses the override method GetFirewallPolicyFn or the real implementation.
+func (c *TestClient) GetFirewallPolicy(policy string) (*compute.FirewallPolicy, error) {
+	if c.GetFirewallPolicyFn != nil {
+		return c.GetFirewallPolicyFn(policy)
+	}
+	return c.client.GetFirewallPolicy(policy)
+}
+
+// AddFirewallPolicyAssociation uses the override method AddFirewallPolicyAssociationFn or the real implementation.
+func (c *TestClient) AddFirewallPolicyAssociation(policy string, assoc *compute.FirewallPolicyAssociation) error {
+	if c.AddFirewallPolicyAssociationFn != nil {
+		return c.AddFirewallPolicyAssociationFn(policy, assoc)
+	}
+	return c.client.AddFirewallPolicyAssociation(policy, assoc)
+}
+
+// AddFirewallPolicyRule uses the override method AddFirewallPolicyRuleFn or the real implementation.
+func (c *TestClient) AddFirewallPolicyRule(policy string, rule *compute.FirewallPolicyRule) error {
+	if c.AddFirewallPolicyRuleFn != nil {
+		return c.AddFirewallPolicyRuleFn(policy, rule)
+	}
+	return c.client.AddFirewallPolicyRule(policy, rule)
+}
+
+// SendDiagnosticInterrupt uses the override method SendDiagnosticInterruptFn or the real implementation.
+func (c *TestClient) SendDiagnosticInterrupt(project, zone, instance string) error {
+	if c.SendDiagnosticInterruptFn != nil {
+		return c.SendDiagnosticInterruptFn(project, zone, instance)
+	}
+	return c.client.SendDiagnosticInterrupt(project, zone, instance)
+}
+
+// CreateFirewallRuleBeta uses the override method CreateFirewallRuleBetaFn or the real implementation.
+func (c *TestClient) CreateFirewallRuleBeta(project string, fw *computeBeta.Firewall) error {
+	if c.CreateFirewallRuleBetaFn != nil {
+		return c.CreateFirewallRuleBetaFn(project, fw)
+	}
+	return c.client.CreateFirewallRuleBeta(project, fw)
+}
+
+// PatchFirewallRuleBeta uses the override method PatchFirewallRuleBetaFn or the real implementation.
+func (c *TestClient) PatchFirewallRuleBeta(project, name string, fw *computeBeta.Firewall) error {
+	if c.PatchFirewallRuleBetaFn != nil {
+		return c.PatchFirewallRuleBetaFn(project, name, fw)
+	}
+	return c.client.PatchFirewallRuleBeta(project, name, fw)
+}
+
+// GetFirewallRuleBeta uses the override method GetFirewallRuleBetaFn or the real implementation.
+func (c *TestClient) GetFirewallRuleBeta(project, name string) (*computeBeta.Firewall, error) {
+	if c.GetFirewallRuleBetaFn != nil {
+		return c.GetFirewallRuleBetaFn(project, name)
+	}
+	return c.client.GetFirewallRuleBeta(project, name)
+}
+
+// WaitForBackendServiceHealthy uses the override method WaitForBackendServiceHealthyFn or the real implementation.
+func (c *TestClient) WaitForBackendServiceHealthy(project, region, backendService, group string, minHealthy int, timeout time.Duration) error {
+	if c.WaitForBackendServiceHealthyFn != nil {
+		return c.WaitForBackendServiceHealthyFn(project, region, backendService, group, minHealthy, timeout)
+	}
+	return c.client.WaitForBackendServiceHealthy(project, region, backendService, group, minHealthy, timeout)
+}
+
+// GetInstanceTemplate uses the override method GetInstanceTemplateFn or the real implementation.
+func (c *TestClient) GetInstanceTemplate(project, name string) (*compute.InstanceTemplate, error) {
+	if c.GetInstanceTemplateFn != nil {
+		return c.GetInstanceTemplateFn(project, name)
+	}
+	return c.client.GetInstanceTemplate(project, name)
+}
+
+// GetInstanceGroupManager uses the override method GetInstanceGroupManagerFn or the real implementation.
+func (c *TestClient) GetInstanceGroupManager(project, zone, name string) (*compute.InstanceGroupManager, error) {
+	if c.GetInstanceGroupManagerFn != nil {
+		return c.GetInstanceGroupManagerFn(project, zone, name)
+	}
+	return c.client.GetInstanceGroupManager(project, zone, name)
+}
+
+// GetRegionInstanceGroupManager uses the override method GetRegionInstanceGroupManagerFn or the real implementation.
+func (c *TestClient) GetRegionInstanceGroupManager(project, region, name string) (*compute.InstanceGroupManager, error) {
+	if c.GetRegionInstanceGroupManagerFn != nil {
+		return c.GetRegionInstanceGroupManagerFn(project, region, name)
+	}
+	return c.client.GetRegionInstanceGroupManager(project, region, name)
+}
+
+// WaitForInstanceGroupManagerStable uses the override method WaitForInstanceGroupManagerStableFn or the real implementation.
+func (c *TestClient) WaitForInstanceGroupManagerStable(project, zone, name string, timeout time.Duration) error {
+	if c.WaitForInstanceGroupManagerStableFn != nil {
+		return c.WaitForInstanceGroupManagerStableFn(project, zone, name, timeout)
+	}
+	return c.client.WaitForInstanceGroupManagerStable(project, zone, name, timeout)
+}
+
+// WaitForRegionInstanceGroupManagerStable uses the override method WaitForRegionInstanceGroupManagerStableFn or the real implementation.
+func (c *TestClient) WaitForRegionInstanceGroupManagerStable(project, region, name string, timeout time.Duration) error {
+	if c.WaitForRegionInstanceGroupManagerStableFn != nil {
+		return c.WaitForRegionInstanceGroupManagerStableFn(project, region, name, timeout)
+	}
+	return c.client.WaitForRegionInstanceGroupManagerStable(project, region, name, timeout)
+}
+
+// WaitForOperationWithConfig uses the override method WaitForOperationWithConfigFn or the real implementation.
+func (c *TestClient) WaitForOperationWithConfig(project, selfLink string, wc WaitConfig) error {
+	if c.WaitForOperationWithConfigFn != nil {
+		return c.WaitForOperationWithConfigFn(project, selfLink, wc)
+	}
+	return c.client.WaitForOperationWithConfig(project, selfLink, wc)
+}
+
+// DeleteRegionDisk uses the override method DeleteRegionDiskFn or the real implementation.
+func (c *TestClient) DeleteRegionDisk(project, region, name string) error {
+	if c.DeleteRegionDiskFn != nil {
+		return c.DeleteRegionDiskFn(project, region, name)
+	}
+	return c.client.DeleteRegionDisk(project, region, name)
+}
+
+// GetRegionDisk uses the override method GetRegionDiskFn or the real implementation.
+func (c *TestClient) GetRegionDisk(project, region, name string) (*compute.Disk, error) {
+	if c.GetRegionDiskFn != nil {
+		return c.GetRegionDiskFn(project, region, name)
+	}
+	return c.client.GetRegionDisk(project, region, name)
+}
@@ -15,10 +15,13 @@
 package compute
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"testing"
+	"time"
 
+	computeBeta "google.golang.org/api/compute/v0.beta"
 	"google.golang.org/api/compute/v1"
 	"google.golang.org/api/googleapi"
 )
@@ -43,6 +46,9 @@ func TestTestClient(t *testing.T) {
 		{"retry", func() {
 			c.Retry(func(_ ...googleapi.CallOption) (*compute.Operation, error) { realCalled = true; return nil, nil })
 		}, ""},
+		{"retry context", func() {
+			c.RetryContext(context.Background(), func(_ ...googleapi.CallOption) (*compute.Operation, error) { realCalled = true; return nil, nil })
+		}, ""},
 		{"attach disk", func() { c.AttachDisk("a", "b", "c", &compute.AttachedDisk{}) }, "/projects/a/zones/b/instances/c/attachDisk?alt=json&prettyPrint=false"},
 		{"detach disk", func() { c.DetachDisk("a", "b", "c", "d") }, "/projects/a/zones/b/instances/c/detachDisk?alt=json&deviceName=d&prettyPrint=false"},
 		{"resize disk", func() { c.ResizeDisk("a", "b", "c", &compute.DisksResizeRequest{SizeGb: 128}) }, "/projects/a/zones/b/disks/c/resize?alt=json&prettyPrint=false"},
@@ -50,10 +56,21 @@ func TestTestClient(t *testing.T) {
 		{"create firewall rule", func() { c.CreateFirewallRule("a", &compute.Firewall{}) }, "/projects/a/global/firewalls?alt=json&prettyPrint=false"},
 		{"create image", func() { c.CreateImage("a", &compute.Image{}) }, "/projects/a/global/images?alt=json&prettyPrint=false"},
 		{"create instance", func() { c.CreateInstance("a", "b", &compute.Instance{}) }, "/projects/a/zones/b/instances?alt=json&prettyPrint=false"},
+		{"create instance and get", func() { c.CreateInstanceAndGet("a", "b", &compute.Instance{}) }, "/projects/a/zones/b/instances?alt=json&prettyPrint=false"},
+		{"update network interface", func() { c.UpdateNetworkInterface("a", "b", "c", "nic0", &compute.NetworkInterface{}) }, "/projects/a/zones/b/instances/c/updateNetworkInterface?alt=json&networkInterface=nic0&prettyPrint=false"},
+		{"get shielded instance identity", func() { c.GetShieldedInstanceIdentity("a", "b", "c") }, "/projects/a/zones/b/instances/c/getShieldedInstanceIdentity?alt=json&prettyPrint=false"},
+		{"set shielded instance integrity policy", func() {
+			c.SetShieldedInstanceIntegrityPolicy("a", "b", "c", &compute.ShieldedInstanceIntegrityPolicy{})
+		}, "/projects/a/zones/b/instances/c/setShieldedInstanceIntegrityPolicy?alt=json&prettyPrint=false"},
+		{"set deletion protection", func() { c.SetDeletionProtection("a", "b", "c", false) }, "/projects/a/zones/b/instances/c/setDeletionProtection?alt=json&deletionProtection=false&prettyPrint=false"},
+		{"bulk insert instance", func() {
+			c.BulkInsertInstance("a", "b", &compute.BulkInsertInstanceResource{SourceInstanceTemplate: "global/instanceTemplates/t"})
+		}, "/projects/a/zones/b/instances/bulkInsert?alt=json&prettyPrint=false"},
 		{"create network", func() { c.CreateNetwork("a", &compute.Network{}) }, "/projects/a/global/networks?alt=json&prettyPrint=false"},
 		{"create subnetwork", func() { c.CreateSubnetwork("a", "b", &compute.Subnetwork{}) }, "/projects/a/regions/b/subnetworks?alt=json&prettyPrint=false"},
 		{"instances start", func() { c.StartInstance("a", "b", "c") }, "/projects/a/zones/b/instances/c/start?alt=json&prettyPrint=false"},
 		{"instances stop", func() { c.StopInstance("a", "b", "c") }, "/projects/a/zones/b/instances/c/stop?alt=json&prettyPrint=false"},
+		{"instances stop with options", func() { c.StopInstanceWithOptions("a", "b", "c", true) }, "/projects/a/zones/b/instances/c/stop?alt=json&discardLocalSsd=true&prettyPrint=false"},
 		{"delete disk", func() { c.DeleteDisk("a", "b", "c") }, "/projects/a/zones/b/disks/c?alt=json&prettyPrint=false"},
 		{"delete firewall rule", func() { c.DeleteFirewallRule("a", "b") }, "/projects/a/global/firewalls/b?alt=json&prettyPrint=false"},
 		{"delete image", func() { c.DeleteImage("a", "b") }, "/projects/a/global/images/b?alt=json&prettyPrint=false"},
@@ -65,12 +82,16 @@ func TestTestClient(t *testing.T) {
 		{"get project", func() { c.GetProject("a") }, "/projects/a?alt=json&prettyPrint=false"},
 		{"get machine type", func() { c.GetMachineType("a", "b", "c") }, "/projects/a/zones/b/machineTypes/c?alt=json&prettyPrint=false"},
 		{"list machine types", func() { c.ListMachineTypes("a", "b", listOpts...) }, "/projects/a/zones/b/machineTypes?alt=json&filter=foo&orderBy=foo&pageToken=&prettyPrint=false"},
+		{"get accelerator type", func() { c.GetAcceleratorType("a", "b", "c") }, "/projects/a/zones/b/acceleratorTypes/c?alt=json&prettyPrint=false"},
+		{"list accelerator types", func() { c.ListAcceleratorTypes("a", "b", listOpts...) }, "/projects/a/zones/b/acceleratorTypes?alt=json&filter=foo&orderBy=foo&pageToken=&prettyPrint=false"},
 		{"get firewall rule", func() { c.GetFirewallRule("a", "b") }, "/projects/a/global/firewalls/b?alt=json&prettyPrint=false"},
 		{"list firewall rules", func() { c.ListFirewallRules("a", listOpts...) }, "/projects/a/global/firewalls?alt=json&filter=foo&orderBy=foo&pageToken=&prettyPrint=false"},
 		{"get zone", func() { c.GetZone("a", "b") }, "/projects/a/zones/b?alt=json&prettyPrint=false"},
 		{"list zones", func() { c.ListZones("a", listOpts...) }, "/projects/a/zones?alt=json&filter=foo&orderBy=foo&pageToken=&prettyPrint=false"},
 		{"get instance", func() { c.GetInstance("a", "b", "c") }, "/projects/a/zones/b/instances/c?alt=json&prettyPrint=false"},
 		{"aggregated list instances", func() { c.AggregatedListInstances("a", listOpts...) }, "/projects/a/aggregated/instances?alt=json&filter=foo&orderBy=foo&pageToken=&prettyPrint=false"},
+		{"aggregated list instances by zone", func() { c.AggregatedListInstancesByZone("a", listOpts...) }, "/projects/a/aggregated/instances?alt=json&filter=foo&orderBy=foo&pageToken=&prettyPrint=false"},
+		{"aggregated list disks by zone", func() { c.AggregatedListDisksByZone("a", listOpts...) }, "/projects/a/aggregated/disks?alt=json&filter=foo&orderBy=foo&pageToken=&prettyPrint=false"},
 		{"list instances", func() { c.ListInstances("a", "b", listOpts...) }, "/projects/a/zones/b/instances?alt=json&filter=foo&orderBy=foo&pageToken=&prettyPrint=false"},
 		{"get image from family", func() { c.GetImageFromFamily("a", "b") }, "/projects/a/global/images/family/b?alt=json&prettyPrint=false"},
 		{"get image", func() { c.GetImage("a", "b") }, "/projects/a/global/images/b?alt=json&prettyPrint=false"},
@@ -81,7 +102,78 @@ func TestTestClient(t *testing.T) {
 		{"get subnetwork", func() { c.GetSubnetwork("a", "b", "c") }, "/projects/a/regions/b/subnetworks/c?alt=json&prettyPrint=false"},
 		{"aggregated list subnetworks", func() { c.AggregatedListSubnetworks("a", listOpts...) }, "/projects/a/aggregated/subnetworks?alt=json&filter=foo&orderBy=foo&pageToken=&prettyPrint=false"},
 		{"list subnetworks", func() { c.ListSubnetworks("a", "b", listOpts...) }, "/projects/a/regions/b/subnetworks?alt=json&filter=foo&orderBy=foo&pageToken=&prettyPrint=false"},
+		{"delete zone operation", func() { c.DeleteOperation("a", "projects/a/zones/b/operations/c") }, "/projects/a/zones/b/operations/c?alt=json&prettyPrint=false"},
+		{"delete region operation", func() { c.DeleteOperation("a", "projects/a/regions/b/operations/c") }, "/projects/a/regions/b/operations/c?alt=json&prettyPrint=false"},
+		{"delete global operation", func() { c.DeleteOperation("a", "projects/a/global/operations/c") }, "/projects/a/global/operations/c?alt=json&prettyPrint=false"},
+		{"set snapshot labels", func() { c.SetSnapshotLabels("a", "b", nil, "") }, "/projects/a/global/snapshots/b/setLabels?alt=json&prettyPrint=false"},
+		{"list zone operations", func() { c.ListZoneOperations("a", "b", listOpts...) }, "/projects/a/zones/b/operations?alt=json&filter=foo&orderBy=foo&pageToken=&prettyPrint=false"},
+		{"list region operations", func() { c.ListRegionOperations("a", "b", listOpts...) }, "/projects/a/regions/b/operations?alt=json&filter=foo&orderBy=foo&pageToken=&prettyPrint=false"},
+		{"list global operations", func() { c.ListGlobalOperations("a", listOpts...) }, "/projects/a/global/operations?alt=json&filter=foo&orderBy=foo&pageToken=&prettyPrint=false"},
+		{"get instance referrers", func() { c.GetInstanceReferrers("a", "b", "c") }, "/projects/a/zones/b/instances/c/referrers?alt=json&pageToken=&prettyPrint=false"},
+		{"add security policy rule", func() { c.AddSecurityPolicyRule("a", "b", nil) }, "/projects/a/global/securityPolicies/b/addRule?alt=json&prettyPrint=false"},
+		{"patch security policy rule", func() { c.PatchSecurityPolicyRule("a", "b", 10, nil) }, "/projects/a/global/securityPolicies/b/patchRule?alt=json&prettyPrint=false&priority=10"},
+		{"get security policy", func() { c.GetSecurityPolicy("a", "b") }, "/projects/a/global/securityPolicies/b?alt=json&prettyPrint=false"},
+		{"get packet mirroring", func() { c.GetPacketMirroring("a", "b", "c") }, "/projects/a/regions/b/packetMirrorings/c?alt=json&prettyPrint=false"},
+		{"get reservation", func() { c.GetReservation("a", "b", "c") }, "/projects/a/zones/b/reservations/c?alt=json&prettyPrint=false"},
+		{"set usage export bucket", func() { c.SetUsageExportBucket("a", nil) }, "/projects/a/setUsageExportBucket?alt=json&prettyPrint=false"},
+		{"get xpn host", func() { c.GetXpnHost("a") }, "/projects/a/getXpnHost?alt=json&prettyPrint=false"},
+		{"list xpn hosts", func() { c.ListXpnHosts("a", "b") }, "/projects/a/listXpnHosts?alt=json&pageToken=&prettyPrint=false"},
+		{"enable xpn resource", func() { c.EnableXpnResource("a", nil) }, "/projects/a/enableXpnResource?alt=json&prettyPrint=false"},
+		{"disable xpn resource", func() { c.DisableXpnResource("a", nil) }, "/projects/a/disableXpnResource?alt=json&prettyPrint=false"},
+		{"get effective firewalls", func() { c.GetEffectiveFirewalls("a", "b", "c", "d") }, "/projects/a/zones/b/instances/c/getEffectiveFirewalls?alt=json&networkInterface=d&prettyPrint=false"},
+		{"create disk from snapshot", func() { c.CreateDiskFromSnapshot("a", "b", "c", "projects/d/global/snapshots/e", 0, "") }, "/projects/d/global/snapshots/e?alt=json&prettyPrint=false"},
+		{"list instances by status", func() { c.ListInstancesByStatus("a", "b", StatusRunning) }, "/projects/a/zones/b/instances?alt=json&filter=status+%3D+RUNNING&pageToken=&prettyPrint=false"},
+		{"get screenshot", func() { c.GetScreenshot("a", "b", "c") }, "/projects/a/zones/b/instances/c/screenshot?alt=json&prettyPrint=false"},
+		{"clone disk reencrypt", func() { c.CloneDiskReencrypt("a", "b", "c", "d", nil) }, "/projects/a/zones/b/disks/c?alt=json&prettyPrint=false"},
+		{"get operations for target", func() { c.GetOperationsForTarget("a", "global", "projects/a/global/images/b") }, "/projects/a/global/operations?alt=json&filter=targetLink+eq+%22.%2A%2Fprojects%2Fa%2Fglobal%2Fimages%2Fb%22&pageToken=&prettyPrint=false"},
+		{"deprecate image family", func() { c.DeprecateImageFamily("a", "b", 1, nil) }, "/projects/a/global/images?alt=json&filter=family+%3D+b&pageToken=&prettyPrint=false"},
+		{"perform maintenance", func() { c.PerformMaintenance("a", "b", "c") }, "/projects/a/zones/b/instances/c/performMaintenance?alt=json&prettyPrint=false"},
+		{"get backend service health", func() { c.GetBackendServiceHealth("a", "b", "c") }, "/projects/a/global/backendServices/b/getHealth?alt=json&prettyPrint=false"},
+		{"get region backend service health", func() { c.GetRegionBackendServiceHealth("a", "b", "c", "d") }, "/projects/a/regions/b/backendServices/c/getHealth?alt=json&prettyPrint=false"},
+		{"set instance name", func() { c.SetInstanceName("a", "b", "c", "d") }, "/projects/a/zones/b/instances/c/setName?alt=json&prettyPrint=false"},
+		{"list unattached disks", func() { c.ListUnattachedDisks("a", "b") }, "/projects/a/zones/b/disks?alt=json&pageToken=&prettyPrint=false"},
+		{"delete unattached disks", func() { c.DeleteUnattachedDisks("a", "b") }, "/projects/a/zones/b/disks?alt=json&pageToken=&prettyPrint=false"},
+		{"create image from encrypted disk", func() {
+			c.CreateImageFromEncryptedDisk("a", &compute.Image{SourceDisk: "zones/b/disks/c"}, nil)
+		}, "/projects/a/zones/b/disks/c?alt=json&prettyPrint=false"},
+		{"update instance", func() { c.UpdateInstance("a", "b", &compute.Instance{Name: "c"}) }, "/projects/a/zones/b/instances/c?alt=json&prettyPrint=false"},
+		{"pick zone", func() { c.PickZone("a", "b", "c") }, "/projects/a/zones?alt=json&filter=status+%3D+UP&pageToken=&prettyPrint=false"},
+		{"get instance iam policy", func() { c.GetInstanceIamPolicy("a", "b", "c") }, "/projects/a/zones/b/instances/c/getIamPolicy?alt=json&prettyPrint=false"},
+		{"set instance iam policy", func() { c.SetInstanceIamPolicy("a", "b", "c", &compute.Policy{}) }, "/projects/a/zones/b/instances/c/setIamPolicy?alt=json&prettyPrint=false"},
+		{"test instance iam permissions", func() { c.TestInstanceIamPermissions("a", "b", "c", nil) }, "/projects/a/zones/b/instances/c/testIamPermissions?alt=json&prettyPrint=false"},
+		{"copy image to location", func() { c.CopyImageToLocation("a", "b", "c", "d", []string{"eu"}) }, "/projects/c/global/images?alt=json&prettyPrint=false"},
+		{"get firewall policy", func() { c.GetFirewallPolicy("1") }, "/locations/global/firewallPolicies/1?alt=json&prettyPrint=false"},
+		{"add firewall policy association", func() { c.AddFirewallPolicyAssociation("1", &compute.FirewallPolicyAssociation{}) }, "/locations/global/firewallPolicies/1/addAssociation?alt=json&prettyPrint=false"},
+		{"add firewall policy rule", func() { c.AddFirewallPolicyRule("1", &compute.FirewallPolicyRule{}) }, "/locations/global/firewallPolicies/1/addRule?alt=json&prettyPrint=false"},
+		{"send diagnostic interrupt", func() { c.SendDiagnosticInterrupt("a", "b", "c") }, "/projects/a/zones/b/instances/c/sendDiagnosticInterrupt?alt=json&prettyPrint=false"},
+		{"create firewall rule beta", func() { c.CreateFirewallRuleBeta("a", &computeBeta.Firewall{Name: "b"}) }, "/projects/a/global/firewalls?alt=json&prettyPrint=false"},
+		{"patch firewall rule beta", func() { c.PatchFirewallRuleBeta("a", "b", &computeBeta.Firewall{}) }, "/projects/a/global/firewalls/b?alt=json&prettyPrint=false"},
+		{"get firewall rule beta", func() { c.GetFirewallRuleBeta("a", "b") }, "/projects/a/global/firewalls/b?alt=json&prettyPrint=false"},
+		{"wait for backend service healthy", func() { c.WaitForBackendServiceHealthy("a", "b", "c", "d", 1, time.Second) }, "/projects/a/regions/b/backendServices/c/getHealth?alt=json&prettyPrint=false"},
+		{"get instance template", func() { c.GetInstanceTemplate("a", "b") }, "/projects/a/global/instanceTemplates/b?alt=json&prettyPrint=false"},
+		{"get instance group manager", func() { c.GetInstanceGroupManager("a", "b", "c") }, "/projects/a/zones/b/instanceGroupManagers/c?alt=json&prettyPrint=false"},
+		{"get region instance group manager", func() { c.GetRegionInstanceGroupManager("a", "b", "c") }, "/projects/a/regions/b/instanceGroupManagers/c?alt=json&prettyPrint=false"},
+		{"wait for instance group manager stable", func() { c.WaitForInstanceGroupManagerStable("a", "b", "c", time.Second) }, "/projects/a/zones/b/instanceGroupManagers/c?alt=json&prettyPrint=false"},
+		{"wait for region instance group manager stable", func() { c.WaitForRegionInstanceGroupManagerStable("a", "b", "c", time.Second) }, "/projects/a/regions/b/instanceGroupManagers/c?alt=json&prettyPrint=false"},
+		{"get forwarding rule", func() { c.GetForwardingRule("a", "b", "c") }, "/projects/a/regions/b/forwardingRules/c?alt=json&prettyPrint=false"},
+		{"get firewall rule", func() { c.GetFirewallRule("a", "b") }, "/projects/a/global/firewalls/b?alt=json&prettyPrint=false"},
+		{"get target instance", func() { c.GetTargetInstance("a", "b", "c") }, "/projects/a/zones/b/targetInstances/c?alt=json&prettyPrint=false"},
+		{"get backend service", func() { c.GetBackendService("a", "b") }, "/projects/a/global/backendServices/b?alt=json&prettyPrint=false"},
+		{"get health check", func() { c.GetHealthCheck("a", "b") }, "/projects/a/global/healthChecks/b?alt=json&prettyPrint=false"},
+		{"get url map", func() { c.GetURLMap("a", "b") }, "/projects/a/global/urlMaps/b?alt=json&prettyPrint=false"},
+		{"get target http proxy", func() { c.GetTargetHTTPProxy("a", "b") }, "/projects/a/global/targetHttpProxies/b?alt=json&prettyPrint=false"},
+		{"get network endpoint group", func() { c.GetNetworkEndpointGroup("a", "b", "c") }, "/projects/a/zones/b/networkEndpointGroups/c?alt=json&prettyPrint=false"},
 		{"get region", func() { c.GetRegion("a", "b") }, "/projects/a/regions/b?alt=json&prettyPrint=false"},
+		{"set region ssl certificates", func() { c.SetRegionSSLCertificates("a", "b", "c", nil) }, "/projects/a/regions/b/targetHttpsProxies/c/setSslCertificates?alt=json&prettyPrint=false"},
+		{"get region autoscaler", func() { c.GetRegionAutoscaler("a", "b", "c") }, "/projects/a/regions/b/autoscalers/c?alt=json&prettyPrint=false"},
+		{"list region autoscalers", func() { c.ListRegionAutoscalers("a", "b", listOpts...) }, "/projects/a/regions/b/autoscalers?alt=json&filter=foo&orderBy=foo&pageToken=&prettyPrint=false"},
+		{"attach network endpoints", func() { c.AttachNetworkEndpoints("a", "b", "c", nil) }, "/projects/a/zones/b/networkEndpointGroups/c/attachNetworkEndpoints?alt=json&prettyPrint=false"},
+		{"detach network endpoints", func() { c.DetachNetworkEndpoints("a", "b", "c", nil) }, "/projects/a/zones/b/networkEndpointGroups/c/detachNetworkEndpoints?alt=json&prettyPrint=false"},
+		{"attach region network endpoints", func() { c.AttachRegionNetworkEndpoints("a", "b", "c", nil) }, "/projects/a/regions/b/networkEndpointGroups/c/attachNetworkEndpoints?alt=json&prettyPrint=false"},
+		{"detach region network endpoints", func() { c.DetachRegionNetworkEndpoints("a", "b", "c", nil) }, "/projects/a/regions/b/networkEndpointGroups/c/detachNetworkEndpoints?alt=json&prettyPrint=false"},
+		{"validate region url map", func() { c.ValidateRegionURLMap("a", "b", &compute.UrlMap{Name: "c"}) }, "/projects/a/regions/b/urlMaps/c/validate?alt=json&prettyPrint=false"},
+		{"validate url map", func() { c.ValidateURLMap("a", &compute.UrlMap{Name: "b"}) }, "/projects/a/global/urlMaps/b/validate?alt=json&prettyPrint=false"},
+		{"list regions", func() { c.ListRegions("a", listOpts...) }, "/projects/a/regions?alt=json&filter=foo&orderBy=foo&pageToken=&prettyPrint=false"},
 		{"get disk", func() { c.GetDisk("a", "b", "c") }, "/projects/a/zones/b/disks/c?alt=json&prettyPrint=false"},
 		{"aggregated list disks", func() { c.AggregatedListDisks("a", listOpts...) }, "/projects/a/aggregated/disks?alt=json&filter=foo&orderBy=foo&pageToken=&prettyPrint=false"},
 		{"list disks", func() { c.ListDisks("a", "b", listOpts...) }, "/projects/a/zones/b/disks?alt=json&filter=foo&orderBy=foo&pageToken=&prettyPrint=false"},
@@ -91,13 +183,17 @@ func TestTestClient(t *testing.T) {
 		{"set project metadata", func() { c.SetCommonInstanceMetadata("a", nil) }, "/projects/a/setCommonInstanceMetadata?alt=json&prettyPrint=false"},
 		{"zone operation wait", func() { c.zoneOperationsWait("a", "b", "c") }, "/projects/a/zones/b/operations/c/wait?alt=json&prettyPrint=false"},
 		{"region operation wait", func() { c.regionOperationsWait("a", "b", "c") }, "/projects/a/regions/b/operations/c/wait?alt=json&prettyPrint=false"},
+		{"beta zone operation wait", func() { c.zoneOperationsWaitBeta("a", "b", "c") }, "/projects/a/zones/b/operations/c/wait?alt=json&prettyPrint=false"},
+		{"beta global operation wait", func() { c.globalOperationsWaitBeta("a", "b") }, "/projects/a/global/operations/b/wait?alt=json&prettyPrint=false"},
+		{"alpha zone operation wait", func() { c.zoneOperationsWaitAlpha("a", "b", "c") }, "/projects/a/zones/b/operations/c/wait?alt=json&prettyPrint=false"},
+		{"alpha global operation wait", func() { c.globalOperationsWaitAlpha("a", "b") }, "/projects/a/global/operations/b/wait?alt=json&prettyPrint=false"},
 		{"global operation wait", func() { c.globalOperationsWait("a", "b") }, "/projects/a/global/operations/b/wait?alt=json&prettyPrint=false"},
 		{"get guest attributes", func() { c.GetGuestAttributes("a", "b", "c", "d", "e") }, "/projects/a/zones/b/instances/c/getGuestAttributes?alt=json&prettyPrint=false&queryPath=d&variableKey=e"},
 		{"create machine image", func() { c.CreateMachineImage("a", &compute.MachineImage{}) }, "/projects/a/global/machineImages?alt=json&prettyPrint=false"},
 		{"get machine image", func() { c.GetMachineImage("a", "b") }, "/projects/a/global/machineImages/b?alt=json&prettyPrint=false"},
 		{"list machine images", func() { c.ListMachineImages("a", listOpts...) }, "/projects/a/global/machineImages?alt=json&filter=foo&orderBy=foo&pageToken=&prettyPrint=false"},
 		{"delete machine image", func() { c.DeleteMachineImage("a", "b") }, "/projects/a/global/machineImages/b?alt=json&prettyPrint=false"},
-		{"aggregated list forwarding rule", func() { c.AggregatedListForwardingRules("a", listOpts...) }, "/projects/a/aggregated/forwardingRules?alt=json&pageToken=&prettyPrint=false"},
+		{"aggregated list forwarding rule", func() { c.AggregatedListForwardingRules("a", listOpts...) }, "/projects/a/aggregated/forwardingRules?alt=json&filter=foo&orderBy=foo&pageToken=&prettyPrint=false"},
 		{"delete network", func() { c.DeleteNetwork("a", "b") }, "/projects/a/global/networks/b?alt=json&prettyPrint=false"},
 	}
 
@@ -128,6 +224,10 @@ func TestTestClient(t *testing.T) {
 		fakeCalled = true
 		return nil, nil
 	}
+	c.RetryContextFn = func(_ context.Context, _ func(_ ...googleapi.CallOption) (*compute.Operation, error), _ ...googleapi.CallOption) (op *compute.Operation, err error) {
+		fakeCalled = true
+		return nil, nil
+	}
 	c.AttachDiskFn = func(_, _, _ string, _ *compute.AttachedDisk) error { fakeCalled = true; return nil }
 	c.DetachDiskFn = func(_, _, _, _ string) error { fakeCalled = true; return nil }
 	c.ResizeDiskFn = func(_, _, _ string, _ *compute.DisksResizeRequest) error { fakeCalled = true; return nil }
@@ -135,10 +235,22 @@ func TestTestClient(t *testing.T) {
 	c.CreateFirewallRuleFn = func(_ string, _ *compute.Firewall) error { fakeCalled = true; return nil }
 	c.CreateImageFn = func(_ string, _ *compute.Image) error { fakeCalled = true; return nil }
 	c.CreateInstanceFn = func(_, _ string, _ *compute.Instance) error { fakeCalled = true; return nil }
+	c.UpdateNetworkInterfaceFn = func(_, _, _, _ string, _ *compute.NetworkInterface) error { fakeCalled = true; return nil }
+	c.GetShieldedInstanceIdentityFn = func(_, _, _ string) (*compute.ShieldedInstanceIdentity, error) {
+		fakeCalled = true
+		return nil, nil
+	}
+	c.SetShieldedInstanceIntegrityPolicyFn = func(_, _, _ string, _ *compute.ShieldedInstanceIntegrityPolicy) error {
+		fakeCalled = true
+		return nil
+	}
+	c.SetDeletionProtectionFn = func(_, _, _ string, _ bool) error { fakeCalled = true; return nil }
+	c.BulkInsertInstanceFn = func(_, _ string, _ *compute.BulkInsertInstanceResource) error { fakeCalled = true; return nil }
 	c.CreateNetworkFn = func(_ string, _ *compute.Network) error { fakeCalled = true; return nil }
 	c.CreateSubnetworkFn = func(_, _ string, _ *compute.Subnetwork) error { fakeCalled = true; return nil }
 	c.StartInstanceFn = func(_, _, _ string) error { fakeCalled = true; return nil }
 	c.StopInstanceFn = func(_, _, _ string) error { fakeCalled = true; return nil }
+	c.StopInstanceWithOptionsFn = func(_, _, _ string, _ bool) error { fakeCalled = true; return nil }
 	c.DeleteDiskFn = func(_, _, _ string) error { fakeCalled = true; return nil }
 	c.DeleteFirewallRuleFn = func(_, _ string) error { fakeCalled = true; return nil }
 	c.DeleteImageFn = func(_, _ string) error { fakeCalled = true; return nil }
@@ -200,12 +312,119 @@ func TestTestClient(t *testing.T) {
 		fakeCalled = true
 		return nil, nil
 	}
+	c.AggregatedListInstancesByZoneFn = func(_ string, _ ...ListCallOption) (map[string][]*compute.Instance, error) {
+		fakeCalled = true
+		return nil, nil
+	}
+	c.AggregatedListDisksByZoneFn = func(_ string, _ ...ListCallOption) (map[string][]*compute.Disk, error) {
+		fakeCalled = true
+		return nil, nil
+	}
+	c.DeleteOperationFn = func(_, _ string) error { fakeCalled = true; return nil }
+	c.SetSnapshotLabelsFn = func(_, _ string, _ map[string]string, _ string) error { fakeCalled = true; return nil }
+	c.ListZoneOperationsFn = func(_, _ string, _ ...ListCallOption) ([]*compute.Operation, error) {
+		fakeCalled = true
+		return nil, nil
+	}
+	c.ListRegionOperationsFn = func(_, _ string, _ ...ListCallOption) ([]*compute.Operation, error) {
+		fakeCalled = true
+		return nil, nil
+	}
+	c.ListGlobalOperationsFn = func(_ string, _ ...ListCallOption) ([]*compute.Operation, error) { fakeCalled = true; return nil, nil }
+	c.GetInstanceReferrersFn = func(_, _, _ string) ([]*compute.Reference, error) { fakeCalled = true; return nil, nil }
+	c.AddSecurityPolicyRuleFn = func(_, _ string, _ *compute.SecurityPolicyRule) error { fakeCalled = true; return nil }
+	c.PatchSecurityPolicyRuleFn = func(_, _ string, _ int64, _ *compute.SecurityPolicyRule) error { fakeCalled = true; return nil }
+	c.GetSecurityPolicyFn = func(_, _ string) (*compute.SecurityPolicy, error) { fakeCalled = true; return nil, nil }
+	c.GetPacketMirroringFn = func(_, _, _ string) (*compute.PacketMirroring, error) { fakeCalled = true; return nil, nil }
+	c.GetReservationFn = func(_, _, _ string) (*compute.Reservation, error) { fakeCalled = true; return nil, nil }
+	c.CreateInstanceAndGetFn = func(_, _ string, _ *compute.Instance) (*compute.Instance, error) { fakeCalled = true; return nil, nil }
+	c.SetUsageExportBucketFn = func(_ string, _ *compute.UsageExportLocation) error { fakeCalled = true; return nil }
+	c.GetXpnHostFn = func(_ string) (*compute.Project, error) { fakeCalled = true; return nil, nil }
+	c.ListXpnHostsFn = func(_, _ string) ([]*compute.Project, error) { fakeCalled = true; return nil, nil }
+	c.EnableXpnResourceFn = func(_ string, _ *compute.ProjectsEnableXpnResourceRequest) error { fakeCalled = true; return nil }
+	c.DisableXpnResourceFn = func(_ string, _ *compute.ProjectsDisableXpnResourceRequest) error { fakeCalled = true; return nil }
+	c.GetEffectiveFirewallsFn = func(_, _, _, _ string) (*compute.InstancesGetEffectiveFirewallsResponse, error) {
+		fakeCalled = true
+		return nil, nil
+	}
+	c.CreateDiskFromSnapshotFn = func(_, _, _, _ string, _ int64, _ string) error { fakeCalled = true; return nil }
+	c.ListInstancesByStatusFn = func(_, _, _ string) ([]*compute.Instance, error) { fakeCalled = true; return nil, nil }
+	c.GetScreenshotFn = func(_, _, _ string) (*compute.Screenshot, error) { fakeCalled = true; return nil, nil }
+	c.CloneDiskReencryptFn = func(_, _, _, _ string, _ *compute.CustomerEncryptionKey) error { fakeCalled = true; return nil }
+	c.GetOperationsForTargetFn = func(_, _, _ string) ([]*compute.Operation, error) { fakeCalled = true; return nil, nil }
+	c.DeprecateImageFamilyFn = func(_, _ string, _ int, _ *compute.DeprecationStatus) error { fakeCalled = true; return nil }
+	c.PerformMaintenanceFn = func(_, _, _ string) error { fakeCalled = true; return nil }
+	c.GetBackendServiceHealthFn = func(_, _, _ string) (*compute.BackendServiceGroupHealth, error) { fakeCalled = true; return nil, nil }
+	c.GetRegionBackendServiceHealthFn = func(_, _, _, _ string) (*compute.BackendServiceGroupHealth, error) {
+		fakeCalled = true
+		return nil, nil
+	}
+	c.SetInstanceNameFn = func(_, _, _, _ string) error { fakeCalled = true; return nil }
+	c.ListUnattachedDisksFn = func(_, _ string) ([]*compute.Disk, error) { fakeCalled = true; return nil, nil }
+	c.DeleteUnattachedDisksFn = func(_, _ string) error { fakeCalled = true; return nil }
+	c.CreateImageFromEncryptedDiskFn = func(_ string, _ *compute.Image, _ *compute.CustomerEncryptionKey) error {
+		fakeCalled = true
+		return nil
+	}
+	c.UpdateInstanceFn = func(_, _ string, _ *compute.Instance, _ ...string) error { fakeCalled = true; return nil }
+	c.PickZoneFn = func(_, _, _ string) (string, error) { fakeCalled = true; return "", nil }
+	c.GetInstanceIamPolicyFn = func(_, _, _ string) (*compute.Policy, error) { fakeCalled = true; return nil, nil }
+	c.SetInstanceIamPolicyFn = func(_, _, _ string, _ *compute.Policy) error { fakeCalled = true; return nil }
+	c.TestInstanceIamPermissionsFn = func(_, _, _ string, _ []string) ([]string, error) { fakeCalled = true; return nil, nil }
+	c.CopyImageToLocationFn = func(_, _, _, _ string, _ []string) error { fakeCalled = true; return nil }
+	c.GetFirewallPolicyFn = func(_ string) (*compute.FirewallPolicy, error) { fakeCalled = true; return nil, nil }
+	c.AddFirewallPolicyAssociationFn = func(_ string, _ *compute.FirewallPolicyAssociation) error { fakeCalled = true; return nil }
+	c.AddFirewallPolicyRuleFn = func(_ string, _ *compute.FirewallPolicyRule) error { fakeCalled = true; return nil }
+	c.SendDiagnosticInterruptFn = func(_, _, _ string) error { fakeCalled = true; return nil }
+	c.CreateFirewallRuleBetaFn = func(_ string, _ *computeBeta.Firewall) error { fakeCalled = true; return nil }
+	c.PatchFirewallRuleBetaFn = func(_, _ string, _ *computeBeta.Firewall) error { fakeCalled = true; return nil }
+	c.GetFirewallRuleBetaFn = func(_, _ string) (*computeBeta.Firewall, error) { fakeCalled = true; return nil, nil }
+	c.WaitForBackendServiceHealthyFn = func(_, _, _, _ string, _ int, _ time.Duration) error { fakeCalled = true; return nil }
+	c.GetInstanceTemplateFn = func(_, _ string) (*compute.InstanceTemplate, error) { fakeCalled = true; return nil, nil }
+	c.GetInstanceGroupManagerFn = func(_, _, _ string) (*compute.InstanceGroupManager, error) { fakeCalled = true; return nil, nil }
+	c.GetRegionInstanceGroupManagerFn = func(_, _, _ string) (*compute.InstanceGroupManager, error) { fakeCalled = true; return nil, nil }
+	c.WaitForInstanceGroupManagerStableFn = func(_, _, _ string, _ time.Duration) error { fakeCalled = true; return nil }
+	c.WaitForRegionInstanceGroupManagerStableFn = func(_, _, _ string, _ time.Duration) error { fakeCalled = true; return nil }
+	c.GetForwardingRuleFn = func(_, _, _ string) (*compute.ForwardingRule, error) { fakeCalled = true; return nil, nil }
+	c.GetTargetInstanceFn = func(_, _, _ string) (*compute.TargetInstance, error) { fakeCalled = true; return nil, nil }
+	c.GetBackendServiceFn = func(_, _ string) (*compute.BackendService, error) { fakeCalled = true; return nil, nil }
+	c.GetHealthCheckFn = func(_, _ string) (*compute.HealthCheck, error) { fakeCalled = true; return nil, nil }
+	c.GetURLMapFn = func(_, _ string) (*compute.UrlMap, error) { fakeCalled = true; return nil, nil }
+	c.GetTargetHTTPProxyFn = func(_, _ string) (*compute.TargetHttpProxy, error) { fakeCalled = true; return nil, nil }
+	c.GetNetworkEndpointGroupFn = func(_, _, _ string) (*compute.NetworkEndpointGroup, error) { fakeCalled = true; return nil, nil }
 	c.GetRegionFn = func(_, _ string) (*compute.Region, error) { fakeCalled = true; return nil, nil }
+	c.SetRegionSSLCertificatesFn = func(_, _, _ string, _ []string) error { fakeCalled = true; return nil }
+	c.GetRegionAutoscalerFn = func(_, _, _ string) (*compute.Autoscaler, error) { fakeCalled = true; return nil, nil }
+	c.ListRegionAutoscalersFn = func(_, _ string, _ ...ListCallOption) ([]*compute.Autoscaler, error) {
+		fakeCalled = true
+		return nil, nil
+	}
+	c.AttachNetworkEndpointsFn = func(_, _, _ string, _ []*compute.NetworkEndpoint) error { fakeCalled = true; return nil }
+	c.DetachNetworkEndpointsFn = func(_, _, _ string, _ []*compute.NetworkEndpoint) error { fakeCalled = true; return nil }
+	c.AttachRegionNetworkEndpointsFn = func(_, _, _ string, _ []*compute.NetworkEndpoint) error { fakeCalled = true; return nil }
+	c.DetachRegionNetworkEndpointsFn = func(_, _, _ string, _ []*compute.NetworkEndpoint) error { fakeCalled = true; return nil }
+	c.ValidateRegionURLMapFn = func(_, _ string, _ *compute.UrlMap) (*compute.UrlMapsValidateResponse, error) {
+		fakeCalled = true
+		return nil, nil
+	}
+	c.ValidateURLMapFn = func(_ string, _ *compute.UrlMap) (*compute.UrlMapsValidateResponse, error) {
+		fakeCalled = true
+		return nil, nil
+	}
+	c.ListRegionsFn = func(_ string, _ ...ListCallOption) ([]*compute.Region, error) {
+		fakeCalled = true
+		return nil, nil
+	}
 	c.GetMachineTypeFn = func(_, _, _ string) (*compute.MachineType, error) { fakeCalled = true; return nil, nil }
 	c.ListMachineTypesFn = func(_, _ string, _ ...ListCallOption) ([]*compute.MachineType, error) {
 		fakeCalled = true
 		return nil, nil
 	}
+	c.GetAcceleratorTypeFn = func(_, _, _ string) (*compute.AcceleratorType, error) { fakeCalled = true; return nil, nil }
+	c.ListAcceleratorTypesFn = func(_, _ string, _ ...ListCallOption) ([]*compute.AcceleratorType, error) {
+		fakeCalled = true
+		return nil, nil
+	}
 	c.InstanceStatusFn = func(_, _, _ string) (string, error) { fakeCalled = true; return "", nil }
 	c.InstanceStoppedFn = func(_, _, _ string) (bool, error) { fakeCalled = true; return false, nil }
 	c.SetInstanceMetadataFn = func(_, _, _ string, _ *compute.Metadata) error { fakeCalled = true; return nil }
@@ -213,6 +432,10 @@ func TestTestClient(t *testing.T) {
 	c.zoneOperationsWaitFn = func(_, _, _ string) error { fakeCalled = true; return nil }
 	c.regionOperationsWaitFn = func(_, _, _ string) error { fakeCalled = true; return nil }
 	c.globalOperationsWaitFn = func(_, _ string) error { fakeCalled = true; return nil }
+	c.zoneOperationsWaitBetaFn = func(_, _, _ string) error { fakeCalled = true; return nil }
+	c.globalOperationsWaitBetaFn = func(_, _ string) error { fakeCalled = true; return nil }
+	c.zoneOperationsWaitAlphaFn = func(_, _, _ string) error { fakeCalled = true; return nil }
+	c.globalOperationsWaitAlphaFn = func(_, _ string) error { fakeCalled = true; return nil }
 	c.GetGuestAttributesFn = func(_, _, _, _, _ string) (*compute.GuestAttributes, error) { fakeCalled = true; return nil, nil }
 	c.CreateMachineImageFn = func(_ string, _ *compute.MachineImage) error { fakeCalled = true; return nil }
 	c.GetMachineImageFn = func(_, _ string) (*compute.MachineImage, error) { fakeCalled = true; return nil, nil }
@@ -229,3 +452,33 @@ func TestTestClient(t *testing.T) {
 	wantRealCalled = false
 	runTests()
 }
+
+func TestTestClientRetryContextOptions(t *testing.T) {
+	_, c, err := NewTestClient(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	opt := googleapi.QuotaUser("u")
+
+	var got []googleapi.CallOption
+	f := func(opts ...googleapi.CallOption) (*compute.Operation, error) {
+		got = opts
+		return &compute.Operation{}, nil
+	}
+	if _, err := c.RetryContext(context.Background(), f, opt); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 1 || got[0] != opt {
+		t.Errorf("real implementation: f got options %v, want [%v]", got, opt)
+	}
+
+	got = nil
+	c.RetryContextFn = func(_ context.Context, _ func(...googleapi.CallOption) (*compute.Operation, error), opts ...googleapi.CallOption) (*compute.Operation, error) {
+		got = opts
+		return nil, nil
+	}
+	c.RetryContext(context.Background(), f, opt)
+	if len(got) != 1 || got[0] != opt {
+		t.Errorf("override: RetryContextFn got options %v, want [%v]", got, opt)
+	}
+}
@@ -0,0 +1,271 @@
+//  Copyright 2020 Google Inc. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package compute
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// errRequestTimeout is returned when a single HTTP request takes longer than
+// the client's request timeout.
+var errRequestTimeout = errors.New("request timed out")
+
+// WithRequestTimeout limits how long each individual HTTP request to the API
+// may take, including reading the response. Timed out requests are retried
+// like other transient errors. This does not limit how long an operation may
+// be waited on.
+func WithRequestTimeout(timeout time.Duration) Option {
+	return func(c *client) {
+		c.requestTimeout = timeout
+	}
+}
+
+// WithConcurrencyLimit limits the number of mutating API requests, such as
+// inserts and deletes, that may be in flight at once to n. Requests that only
+// read, including operation waits, are not limited.
+func WithConcurrencyLimit(n int) Option {
+	return func(c *client) {
+		c.concurrencyLimit = n
+	}
+}
+
+// WithRegionalRateLimit limits mutating API requests, such as inserts and
+// deletes, to qps requests per second for each project and region, as write
+// quota is enforced per region. Zonal requests count against their region and
+// global requests share a single bucket per project. A qps of zero or less
+// disables the limit.
+func WithRegionalRateLimit(qps float64) Option {
+	return func(c *client) {
+		c.regionalQPS = qps
+	}
+}
+
+// WithRequestRecorder writes a trace of every HTTP request the client sends to
+// w: the request line, the request headers and the response status. The
+// Authorization, Proxy-Authorization and Cookie headers are redacted.
+func WithRequestRecorder(w io.Writer) Option {
+	return func(c *client) {
+		c.recorder = w
+	}
+}
+
+// wrapHTTPClient returns a copy of hc whose transport applies the client's
+// per-request behavior, or hc itself if there is none.
+func (c *client) wrapHTTPClient(hc *http.Client) *http.Client {
+	if c.requestTimeout <= 0 && c.retryPolicy.FailureThreshold <= 0 && c.concurrencyLimit <= 0 && c.recorder == nil && c.regionalQPS <= 0 {
+		return hc
+	}
+	rt := hc.Transport
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	if c.recorder != nil {
+		rt = &recordTransport{base: rt, w: c.recorder}
+	}
+	if c.requestTimeout > 0 {
+		rt = &timeoutTransport{base: rt, timeout: c.requestTimeout}
+	}
+	if c.retryPolicy.FailureThreshold > 0 {
+		rt = &circuitBreaker{base: rt, policy: c.retryPolicy, now: c.clock.Now}
+	}
+	if c.concurrencyLimit > 0 {
+		rt = &limitTransport{base: rt, sem: make(chan struct{}, c.concurrencyLimit)}
+	}
+	if c.regionalQPS > 0 {
+		rt = &rateLimitTransport{base: rt, interval: time.Duration(float64(time.Second) / c.regionalQPS), clock: c.clock, next: map[string]time.Time{}}
+	}
+	whc := *hc
+	whc.Transport = rt
+	return &whc
+}
+
+// redactedHeaders are the request headers whose values recordTransport does
+// not write.
+var redactedHeaders = map[string]bool{"Authorization": true, "Proxy-Authorization": true, "Cookie": true}
+
+// recordTransport writes a trace of each request and its response status.
+type recordTransport struct {
+	base http.RoundTripper
+
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (t *recordTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s\n", req.Method, req.URL)
+	keys := make([]string, 0, len(req.Header))
+	for k := range req.Header {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		v := strings.Join(req.Header[k], ", ")
+		if redactedHeaders[http.CanonicalHeaderKey(k)] {
+			v = "REDACTED"
+		}
+		fmt.Fprintf(&b, "%s: %s\n", k, v)
+	}
+	if err != nil {
+		fmt.Fprintf(&b, "error: %v\n\n", err)
+	} else {
+		fmt.Fprintf(&b, "%s\n\n", resp.Status)
+	}
+
+	t.mu.Lock()
+	io.WriteString(t.w, b.String())
+	t.mu.Unlock()
+	return resp, err
+}
+
+// limitTransport limits the number of mutating requests in flight.
+type limitTransport struct {
+	base http.RoundTripper
+	sem  chan struct{}
+}
+
+func (t *limitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !isMutatingRequest(req) {
+		return t.base.RoundTrip(req)
+	}
+	select {
+	case t.sem <- struct{}{}:
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
+	}
+	defer func() { <-t.sem }()
+	return t.base.RoundTrip(req)
+}
+
+// rateLimitTransport spaces out mutating requests to each project and region
+// by interval. A request whose context is done while it waits gives its slot
+// back if no later request has been scheduled after it.
+type rateLimitTransport struct {
+	base     http.RoundTripper
+	interval time.Duration
+	clock    Clock
+
+	mu   sync.Mutex
+	next map[string]time.Time
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !isMutatingRequest(req) {
+		return t.base.RoundTrip(req)
+	}
+	key := rateLimitKey(req.URL.Path)
+	t.mu.Lock()
+	now := t.clock.Now()
+	at := t.next[key]
+	if at.Before(now) {
+		at = now
+	}
+	t.next[key] = at.Add(t.interval)
+	t.mu.Unlock()
+
+	if wait := at.Sub(now); wait > 0 {
+		if err := sleepContext(req.Context(), t.clock, wait); err != nil {
+			t.mu.Lock()
+			if t.next[key].Equal(at.Add(t.interval)) {
+				t.next[key] = at
+			}
+			t.mu.Unlock()
+			return nil, err
+		}
+	}
+	return t.base.RoundTrip(req)
+}
+
+var scopedPathRegex = regexp.MustCompile(`/projects/([^/]+)(?:/(zones|regions)/([^/]+))?`)
+
+// rateLimitKey returns the project and region that a request path is scoped
+// to, e.g. "p/us-central1" for a path in zone us-central1-a, or "p/global" for
+// global and project-level paths.
+func rateLimitKey(path string) string {
+	m := scopedPathRegex.FindStringSubmatch(path)
+	switch {
+	case m == nil:
+		return ""
+	case m[2] == "zones":
+		region := m[3]
+		if i := strings.LastIndex(region, "-"); i != -1 {
+			region = region[:i]
+		}
+		return m[1] + "/" + region
+	case m[2] == "regions":
+		return m[1] + "/" + m[3]
+	}
+	return m[1] + "/global"
+}
+
+// isMutatingRequest reports whether req may change a resource. Operation
+// waits are POST requests but only read.
+func isMutatingRequest(req *http.Request) bool {
+	if req.Method == http.MethodGet || req.Method == http.MethodHead {
+		return false
+	}
+	return !(strings.HasSuffix(req.URL.Path, "/wait") && strings.Contains(req.URL.Path, "/operations/"))
+}
+
+// timeoutTransport applies a deadline to each request it sends.
+type timeoutTransport struct {
+	base    http.RoundTripper
+	timeout time.Duration
+}
+
+func (t *timeoutTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, cancel := context.WithTimeout(req.Context(), t.timeout)
+	resp, err := t.base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		if ctx.Err() == context.DeadlineExceeded && req.Context().Err() == nil {
+			return nil, errRequestTimeout
+		}
+		return nil, err
+	}
+	resp.Body = &timeoutBody{ReadCloser: resp.Body, ctx: ctx, parent: req.Context(), cancel: cancel}
+	return resp, nil
+}
+
+// timeoutBody releases the request deadline once the response body is closed.
+type timeoutBody struct {
+	io.ReadCloser
+	ctx, parent context.Context
+	cancel      context.CancelFunc
+}
+
+func (b *timeoutBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if err != nil && err != io.EOF && b.ctx.Err() == context.DeadlineExceeded && b.parent.Err() == nil {
+		err = errRequestTimeout
+	}
+	return n, err
+}
+
+func (b *timeoutBody) Close() error {
+	defer b.cancel()
+	return b.ReadCloser.Close()
+}
@@ -0,0 +1,266 @@
+//  Copyright 2020 Google Inc. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package compute
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/api/option"
+)
+
+func TestRequestTimeout(t *testing.T) {
+	block := make(chan struct{})
+	defer close(block)
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("slow") != "" {
+			select {
+			case <-block:
+			case <-r.Context().Done():
+			}
+			return
+		}
+		fmt.Fprint(w, `{}`)
+	}))
+	defer svr.Close()
+
+	hc := &http.Client{}
+	_, err := NewClientWithOptions(context.Background(), []option.ClientOption{option.WithEndpoint(svr.URL), option.WithHTTPClient(hc)}, WithRequestTimeout(50*time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hc.Transport != nil {
+		t.Error("WithRequestTimeout modified the HTTP client passed in")
+	}
+	ttc := &http.Client{Transport: &timeoutTransport{base: http.DefaultTransport, timeout: 50 * time.Millisecond}}
+	resp, err := ttc.Get(svr.URL)
+	if err != nil {
+		t.Fatalf("unexpected error for fast request: %v", err)
+	}
+	resp.Body.Close()
+
+	_, err = ttc.Get(svr.URL + "?slow=true")
+	if !errors.Is(err, errRequestTimeout) {
+		t.Errorf("want errRequestTimeout for slow request, got: %v", err)
+	}
+}
+
+func TestShouldRetryRequestTimeout(t *testing.T) {
+	err := fmt.Errorf("Get \"url\": %w", errRequestTimeout)
+	if !shouldRetryWithWait(http.DefaultTransport, err, 0) {
+		t.Error("want request timeouts to be retried")
+	}
+}
+
+func TestLimitTransport(t *testing.T) {
+	const requests = 10
+	var mu sync.Mutex
+	var inFlight, maxInFlight int
+	arrived := make(chan struct{}, requests)
+	release := make(chan struct{})
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mu.Unlock()
+		arrived <- struct{}{}
+		<-release
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+	}))
+	defer svr.Close()
+
+	hc := &http.Client{Transport: &limitTransport{base: http.DefaultTransport, sem: make(chan struct{}, 2)}}
+	var wg sync.WaitGroup
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := hc.Post(svr.URL+"/projects/p/zones/z/instances", "application/json", nil)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			resp.Body.Close()
+		}()
+	}
+
+	// Requests are held at the server until released, so a new one can only
+	// arrive once an earlier one has finished and freed its slot.
+	<-arrived
+	<-arrived
+	for i := 2; i < requests; i++ {
+		release <- struct{}{}
+		<-arrived
+	}
+	release <- struct{}{}
+	release <- struct{}{}
+	wg.Wait()
+	if maxInFlight != 2 {
+		t.Errorf("got %d mutating requests in flight, want 2", maxInFlight)
+	}
+}
+
+func TestIsMutatingRequest(t *testing.T) {
+	tests := []struct {
+		method, path string
+		want         bool
+	}{
+		{"GET", "/projects/p/zones/z/instances/i", false},
+		{"POST", "/projects/p/zones/z/instances", true},
+		{"DELETE", "/projects/p/zones/z/instances/i", true},
+		{"PATCH", "/projects/p/global/backendServices/b", true},
+		{"POST", "/projects/p/zones/z/operations/op/wait", false},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, "https://compute.googleapis.com"+tt.path, nil)
+		if got := isMutatingRequest(req); got != tt.want {
+			t.Errorf("%s %s: got %t, want %t", tt.method, tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestRequestRecorder(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer svr.Close()
+
+	var buf bytes.Buffer
+	c := &client{}
+	WithRequestRecorder(&buf)(c)
+	hc := c.wrapHTTPClient(&http.Client{})
+	req, err := http.NewRequest("GET", svr.URL+"/projects/p/zones/z/instances/i", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer secret-token")
+	req.Header.Set("X-Goog-Api-Client", "gl-go")
+	resp, err := hc.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	got := buf.String()
+	if strings.Contains(got, "secret-token") {
+		t.Errorf("recorded trace contains credentials:\n%s", got)
+	}
+	for _, want := range []string{"GET " + svr.URL + "/projects/p/zones/z/instances/i\n", "Authorization: REDACTED\n", "X-Goog-Api-Client: gl-go\n", "404 Not Found\n"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("recorded trace missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestRateLimitKey(t *testing.T) {
+	tests := []struct {
+		path, want string
+	}{
+		{"/compute/v1/projects/p/zones/us-central1-a/instances", "p/us-central1"},
+		{"/compute/v1/projects/p/regions/us-central1/addresses/a", "p/us-central1"},
+		{"/compute/v1/projects/p/global/images", "p/global"},
+		{"/compute/v1/projects/p/setCommonInstanceMetadata", "p/global"},
+		{"/batch", ""},
+	}
+	for _, tt := range tests {
+		if got := rateLimitKey(tt.path); got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestRateLimitTransport(t *testing.T) {
+	var sent []string
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sent = append(sent, r.Method+" "+r.URL.Path)
+	}))
+	defer svr.Close()
+
+	interval := time.Second
+	clk := NewFakeClock(time.Now())
+	hc := &http.Client{Transport: &rateLimitTransport{base: http.DefaultTransport, interval: interval, clock: clk, next: map[string]time.Time{}}}
+	for _, path := range []string{
+		"/projects/p/zones/us-central1-a/instances",
+		"/projects/p/zones/us-central1-b/disks",
+		"/projects/p/regions/europe-west1/addresses",
+		"/projects/p/regions/us-central1/addresses",
+	} {
+		resp, err := hc.Post(svr.URL+path, "application/json", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+	}
+	// us-central1 has 3 writes, so the second and third each wait an interval.
+	// The europe-west1 write has its own bucket and is sent at once.
+	if slept := clk.Slept(); slept != 2*interval {
+		t.Errorf("writes waited %s, want %s", slept, 2*interval)
+	}
+
+	// Reads are not limited.
+	resp, err := hc.Get(svr.URL + "/projects/p/zones/us-central1-a/instances/i")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if slept := clk.Slept(); slept != 2*interval {
+		t.Errorf("read request waited %s", slept-2*interval)
+	}
+	if len(sent) != 5 {
+		t.Errorf("got %d requests, want 5: %q", len(sent), sent)
+	}
+}
+
+func TestRateLimitTransportCancelled(t *testing.T) {
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer svr.Close()
+
+	rt := &rateLimitTransport{base: http.DefaultTransport, interval: time.Hour, clock: stuckClock{}, next: map[string]time.Time{}}
+	hc := &http.Client{Transport: rt}
+	url := svr.URL + "/projects/p/zones/us-central1-a/instances"
+	resp, err := hc.Post(url, "application/json", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	next := rt.next["p/us-central1"]
+
+	// The second write waits on a clock that never fires until its context
+	// is done, and then gives its slot back.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := hc.Do(req); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("got error %v, want %v", err, context.DeadlineExceeded)
+	}
+	if got := rt.next["p/us-central1"]; !got.Equal(next) {
+		t.Errorf("next slot is %s after cancellation, want %s", got, next)
+	}
+}
@@ -0,0 +1,96 @@
+//  Copyright 2020 Google Inc. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package compute
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// The functions below build the partial resource URLs that the API accepts
+// wherever a resource references another, e.g. a disk's SourceImage.
+
+// ImageURL returns the partial URL of a GCE Image.
+func ImageURL(project, image string) string {
+	return fmt.Sprintf("projects/%s/global/images/%s", project, image)
+}
+
+// ImageFamilyURL returns the partial URL of the latest GCE Image in a family.
+func ImageFamilyURL(project, family string) string {
+	return fmt.Sprintf("projects/%s/global/images/family/%s", project, family)
+}
+
+// SnapshotURL returns the partial URL of a GCE Snapshot.
+func SnapshotURL(project, snapshot string) string {
+	return fmt.Sprintf("projects/%s/global/snapshots/%s", project, snapshot)
+}
+
+// NetworkURL returns the partial URL of a GCE Network.
+func NetworkURL(project, network string) string {
+	return fmt.Sprintf("projects/%s/global/networks/%s", project, network)
+}
+
+// SubnetworkURL returns the partial URL of a GCE Subnetwork.
+func SubnetworkURL(project, region, subnetwork string) string {
+	return fmt.Sprintf("projects/%s/regions/%s/subnetworks/%s", project, region, subnetwork)
+}
+
+// MachineTypeURL returns the partial URL of a GCE MachineType.
+func MachineTypeURL(project, zone, machineType string) string {
+	return fmt.Sprintf("projects/%s/zones/%s/machineTypes/%s", project, zone, machineType)
+}
+
+// DiskTypeURL returns the partial URL of a GCE DiskType.
+func DiskTypeURL(project, zone, diskType string) string {
+	return fmt.Sprintf("projects/%s/zones/%s/diskTypes/%s", project, zone, diskType)
+}
+
+// DiskURL returns the partial URL of a GCE Disk.
+func DiskURL(project, zone, disk string) string {
+	return fmt.Sprintf("projects/%s/zones/%s/disks/%s", project, zone, disk)
+}
+
+// InstanceURL returns the partial URL of a GCE Instance.
+func InstanceURL(project, zone, instance string) string {
+	return fmt.Sprintf("projects/%s/zones/%s/instances/%s", project, zone, instance)
+}
+
+// AcceleratorTypeURL returns the partial URL of a GCE AcceleratorType.
+func AcceleratorTypeURL(project, zone, acceleratorType string) string {
+	return fmt.Sprintf("projects/%s/zones/%s/acceleratorTypes/%s", project, zone, acceleratorType)
+}
+
+// resourceURLRegex matches full and partial resource URLs. The project may be
+// left out of partial URLs, which are then relative to a project given
+// elsewhere.
+var resourceURLRegex = regexp.MustCompile(`^(?:https://[^/]+/compute/[^/]+/)?(?:projects/([^/]+)/)?(?:(zones|regions)/([^/]+)|(global))/([^/]+)/([^/]+|family/[^/]+)$`)
+
+// ParseResourceURL splits the full or partial URL of a GCE resource, e.g.
+// "https://www.googleapis.com/compute/v1/projects/p/zones/z/disks/d" or
+// "zones/z/disks/d", into its parts. scope is "zones", "regions" or "global",
+// and location is the zone or region, or empty for global resources. project
+// is empty if a partial URL does not name one. The name of an image family
+// URL is "family/<family>".
+func ParseResourceURL(selfLink string) (project, scope, location, resourceType, name string, err error) {
+	m := resourceURLRegex.FindStringSubmatch(selfLink)
+	if m == nil {
+		return "", "", "", "", "", fmt.Errorf("%q is not a zonal, regional or global resource URL", selfLink)
+	}
+	scope, location = m[2], m[3]
+	if m[4] != "" {
+		scope = m[4]
+	}
+	return m[1], scope, location, m[5], m[6], nil
+}
@@ -0,0 +1,72 @@
+//  Copyright 2020 Google Inc. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package compute
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestURLs(t *testing.T) {
+	tests := []struct {
+		got, want string
+	}{
+		{ImageURL("p", "i"), "projects/p/global/images/i"},
+		{ImageFamilyURL("p", "f"), "projects/p/global/images/family/f"},
+		{SnapshotURL("p", "s"), "projects/p/global/snapshots/s"},
+		{NetworkURL("p", "n"), "projects/p/global/networks/n"},
+		{SubnetworkURL("p", "r", "s"), "projects/p/regions/r/subnetworks/s"},
+		{MachineTypeURL("p", "z", "m"), "projects/p/zones/z/machineTypes/m"},
+		{DiskTypeURL("p", "z", "d"), "projects/p/zones/z/diskTypes/d"},
+		{DiskURL("p", "z", "d"), "projects/p/zones/z/disks/d"},
+		{InstanceURL("p", "z", "i"), "projects/p/zones/z/instances/i"},
+		{AcceleratorTypeURL("p", "z", "a"), "projects/p/zones/z/acceleratorTypes/a"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("want %q, got %q", tt.want, tt.got)
+		}
+	}
+}
+
+func TestParseResourceURL(t *testing.T) {
+	tests := []struct {
+		url                                          string
+		project, scope, location, resourceType, name string
+		wantErr                                      bool
+	}{
+		{"https://www.googleapis.com/compute/v1/projects/p/zones/z/instances/i", "p", "zones", "z", "instances", "i", false},
+		{"https://compute.googleapis.com/compute/beta/projects/p/regions/r/subnetworks/s", "p", "regions", "r", "subnetworks", "s", false},
+		{"projects/p/global/images/i", "p", "global", "", "images", "i", false},
+		{"projects/p/global/images/family/f", "p", "global", "", "images", "family/f", false},
+		{"zones/z/disks/d", "", "zones", "z", "disks", "d", false},
+		{"global/networks/n", "", "global", "", "networks", "n", false},
+		{"projects/p", "", "", "", "", "", true},
+		{"projects/p/zones/z", "", "", "", "", "", true},
+		{"projects/p/zones/z/instances/i/extra/x", "", "", "", "", "", true},
+		{"disks/d", "", "", "", "", "", true},
+	}
+	for _, tt := range tests {
+		project, scope, location, resourceType, name, err := ParseResourceURL(tt.url)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%q: got error %v, want error %t", tt.url, err, tt.wantErr)
+		}
+		got := []string{project, scope, location, resourceType, name}
+		want := []string{tt.project, tt.scope, tt.location, tt.resourceType, tt.name}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%q: got %q, want %q", tt.url, got, want)
+		}
+	}
+}
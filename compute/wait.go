@@ -0,0 +1,74 @@
+//  Copyright 2020 Google Inc. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package compute
+
+import (
+	"strings"
+	"time"
+)
+
+// WaitConfig sets how long the client waits for an operation to finish,
+// chosen by the type of resource the operation acts on. Timeout applies to
+// resource types without a timeout of their own. A zero timeout waits
+// indefinitely.
+type WaitConfig struct {
+	Timeout         time.Duration
+	ImageTimeout    time.Duration
+	InstanceTimeout time.Duration
+	DiskTimeout     time.Duration
+	SnapshotTimeout time.Duration
+}
+
+// DefaultWaitConfig gives image and snapshot operations, which can take many
+// minutes, more time than instance and disk operations.
+var DefaultWaitConfig = WaitConfig{
+	Timeout:         10 * time.Minute,
+	ImageTimeout:    60 * time.Minute,
+	InstanceTimeout: 15 * time.Minute,
+	DiskTimeout:     10 * time.Minute,
+	SnapshotTimeout: 60 * time.Minute,
+}
+
+// WithWaitConfig limits how long the client waits on operations, such as
+// those started by the Create methods, according to wc. Without it operations
+// are waited on until they finish. WaitForOperationWithConfig overrides wc
+// for a single wait.
+func WithWaitConfig(wc WaitConfig) Option {
+	return func(c *client) {
+		c.waitConfig = &wc
+	}
+}
+
+// timeout returns the wait timeout for an operation on the resource at
+// targetLink.
+func (wc *WaitConfig) timeout(targetLink string) time.Duration {
+	var d time.Duration
+	if parts := strings.Split(targetLink, "/"); len(parts) >= 2 {
+		switch parts[len(parts)-2] {
+		case "images", "machineImages":
+			d = wc.ImageTimeout
+		case "instances":
+			d = wc.InstanceTimeout
+		case "disks":
+			d = wc.DiskTimeout
+		case "snapshots":
+			d = wc.SnapshotTimeout
+		}
+	}
+	if d == 0 {
+		d = wc.Timeout
+	}
+	return d
+}
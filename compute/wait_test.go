@@ -0,0 +1,98 @@
+//  Copyright 2020 Google Inc. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package compute
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/api/compute/v1"
+)
+
+func TestWaitConfigTimeout(t *testing.T) {
+	wc := &WaitConfig{Timeout: time.Minute, ImageTimeout: time.Hour, DiskTimeout: 2 * time.Minute}
+	tests := []struct {
+		targetLink string
+		want       time.Duration
+	}{
+		{"https://www.googleapis.com/compute/v1/projects/p/global/images/im", time.Hour},
+		{"projects/p/global/machineImages/mi", time.Hour},
+		{"projects/p/zones/z/disks/d", 2 * time.Minute},
+		{"projects/p/regions/r/disks/d", 2 * time.Minute},
+		{"projects/p/zones/z/instances/i", time.Minute},
+		{"projects/p/global/networks/n", time.Minute},
+		{"", time.Minute},
+	}
+	for _, tt := range tests {
+		if got := wc.timeout(tt.targetLink); got != tt.want {
+			t.Errorf("%q: got %s, want %s", tt.targetLink, got, tt.want)
+		}
+	}
+}
+
+func TestOperationsWaitTimeout(t *testing.T) {
+	c := &client{clock: NewFakeClock(time.Now())}
+	WithWaitConfig(WaitConfig{ImageTimeout: time.Hour, DiskTimeout: 5 * time.Second})(c)
+
+	var polls int
+	err := c.operationsWaitHelper("p", "op", operationPollInterval, func() (*compute.Operation, error) {
+		polls++
+		return &compute.Operation{Name: "op", Status: OpStatusRunning, TargetLink: "projects/p/zones/z/disks/d"}, nil
+	})
+	if err == nil || !strings.Contains(err.Error(), "not done after") {
+		t.Errorf("want timeout error, got %v", err)
+	}
+	// One poll at the start and one after each of five 1s sleeps.
+	if polls != 6 {
+		t.Errorf("got %d polls, want 6", polls)
+	}
+
+	polls = 0
+	err = c.operationsWaitHelper("p", "op", operationPollInterval, func() (*compute.Operation, error) {
+		polls++
+		if polls == 1 {
+			return &compute.Operation{Name: "op", Status: OpStatusRunning, TargetLink: "projects/p/global/images/im"}, nil
+		}
+		return &compute.Operation{Name: "op", Status: OpStatusDone, TargetLink: "projects/p/global/images/im"}, nil
+	})
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestWaitForOperationWithConfig(t *testing.T) {
+	svr, c, err := NewTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" && r.URL.Path == fmt.Sprintf("/projects/%s/zones/%s/operations/op", testProject, testZone) {
+			fmt.Fprintf(w, `{"Name":"op","Status":"RUNNING","TargetLink":"projects/%s/zones/%s/disks/d"}`, testProject, testZone)
+			return
+		}
+		w.WriteHeader(500)
+		fmt.Fprintln(w, "URL and Method not recognized:", r.Method, r.URL)
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer svr.Close()
+	WithWaitConfig(WaitConfig{DiskTimeout: time.Hour})(&c.client)
+
+	selfLink := fmt.Sprintf("projects/%s/zones/%s/operations/op", testProject, testZone)
+	err = c.WaitForOperationWithConfig(testProject, selfLink, WaitConfig{DiskTimeout: 5 * time.Second})
+	if err == nil || !strings.Contains(err.Error(), "not done after 5s") {
+		t.Errorf("want timeout error after 5s, got %v", err)
+	}
+}
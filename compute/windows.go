@@ -0,0 +1,146 @@
+//  Copyright 2020 Google Inc. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package compute
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"google.golang.org/api/compute/v1"
+)
+
+const (
+	// windowsKeysMetadataKey is the instance metadata key the Windows guest
+	// agent watches for password reset requests.
+	windowsKeysMetadataKey = "windows-keys"
+	// windowsPasswordSerialPort is the serial port the Windows guest agent
+	// writes the encrypted password to.
+	windowsPasswordSerialPort = 4
+)
+
+var (
+	// windowsPasswordPollInterval is how often ResetWindowsPassword reads the
+	// serial port for the agent's response.
+	windowsPasswordPollInterval = 5 * time.Second
+	// windowsPasswordTimeout is how long ResetWindowsPassword waits for the
+	// agent's response.
+	windowsPasswordTimeout = 5 * time.Minute
+)
+
+// windowsKey is a password reset request as read by the Windows guest agent.
+type windowsKey struct {
+	UserName string `json:"userName"`
+	Modulus  string `json:"modulus"`
+	Exponent string `json:"exponent"`
+	Email    string `json:"email"`
+	ExpireOn string `json:"expireOn"`
+}
+
+// windowsPasswordResponse is the Windows guest agent's response to a
+// password reset request, written to serial port 4.
+type windowsPasswordResponse struct {
+	Modulus           string `json:"modulus"`
+	EncryptedPassword string `json:"encryptedPassword"`
+	ErrorMessage      string `json:"errorMessage"`
+}
+
+// ResetWindowsPassword resets the password of username on a Windows GCE
+// instance, creating the user if needed, and returns the new password. It
+// follows the Windows guest agent protocol: a one-off RSA public key is added
+// to the instance's windows-keys metadata, and the agent writes the new
+// password, encrypted with that key, to serial port 4.
+func (c *client) ResetWindowsPassword(project, zone, instance, username string) (string, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return "", fmt.Errorf("error generating key: %v", err)
+	}
+	modulus := base64.StdEncoding.EncodeToString(key.N.Bytes())
+	wk, err := json.Marshal(windowsKey{
+		UserName: username,
+		Modulus:  modulus,
+		Exponent: base64.StdEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		ExpireOn: c.clock.Now().Add(windowsPasswordTimeout).UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	i, err := c.i.GetInstance(project, zone, instance)
+	if err != nil {
+		return "", err
+	}
+	md := i.Metadata
+	if md == nil {
+		md = &compute.Metadata{}
+	}
+	found := false
+	for _, item := range md.Items {
+		if item.Key != windowsKeysMetadataKey {
+			continue
+		}
+		v := string(wk)
+		if item.Value != nil && *item.Value != "" {
+			v = *item.Value + "\n" + v
+		}
+		item.Value = &v
+		found = true
+	}
+	if !found {
+		v := string(wk)
+		md.Items = append(md.Items, &compute.MetadataItems{Key: windowsKeysMetadataKey, Value: &v})
+	}
+	if err := c.i.SetInstanceMetadata(project, zone, instance, md); err != nil {
+		return "", err
+	}
+
+	deadline := c.clock.Now().Add(windowsPasswordTimeout)
+	var start int64
+	for {
+		sp, err := c.i.GetSerialPortOutput(project, zone, instance, windowsPasswordSerialPort, start)
+		if err != nil {
+			return "", err
+		}
+		start = sp.Next
+		for _, line := range strings.Split(sp.Contents, "\n") {
+			var resp windowsPasswordResponse
+			if err := json.Unmarshal([]byte(strings.TrimSpace(line)), &resp); err != nil || resp.Modulus != modulus {
+				continue
+			}
+			if resp.ErrorMessage != "" {
+				return "", fmt.Errorf("error resetting password for %q on instance %q: %s", username, instance, resp.ErrorMessage)
+			}
+			ct, err := base64.StdEncoding.DecodeString(resp.EncryptedPassword)
+			if err != nil {
+				return "", fmt.Errorf("error decoding encrypted password: %v", err)
+			}
+			pw, err := rsa.DecryptOAEP(sha1.New(), rand.Reader, key, ct, nil)
+			if err != nil {
+				return "", fmt.Errorf("error decrypting password: %v", err)
+			}
+			return string(pw), nil
+		}
+		if c.clock.Now().After(deadline) {
+			return "", fmt.Errorf("timed out after %s waiting for the password of %q on instance %q", windowsPasswordTimeout, username, instance)
+		}
+		c.clock.Sleep(windowsPasswordPollInterval)
+	}
+}
@@ -0,0 +1,81 @@
+//  Copyright 2020 Google Inc. All Rights Reserved.
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package compute
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/compute/v1"
+)
+
+func TestResetWindowsPassword(t *testing.T) {
+	_, c, err := NewTestClient(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	old := "old-key"
+	c.GetInstanceFn = func(_, _, _ string) (*compute.Instance, error) {
+		return &compute.Instance{Metadata: &compute.Metadata{Fingerprint: "fp", Items: []*compute.MetadataItems{{Key: windowsKeysMetadataKey, Value: &old}}}}, nil
+	}
+	var wk windowsKey
+	c.SetInstanceMetadataFn = func(_, _, _ string, md *compute.Metadata) error {
+		if md.Fingerprint != "fp" {
+			t.Errorf("metadata fingerprint not passed through: %q", md.Fingerprint)
+		}
+		keys := strings.Split(*md.Items[0].Value, "\n")
+		if len(keys) != 2 || keys[0] != old {
+			t.Fatalf("want new key appended to existing keys, got %q", keys)
+		}
+		return json.Unmarshal([]byte(keys[1]), &wk)
+	}
+	polls := 0
+	c.GetSerialPortOutputFn = func(_, _, _ string, port, _ int64) (*compute.SerialPortOutput, error) {
+		if port != windowsPasswordSerialPort {
+			t.Errorf("read serial port %d, want %d", port, windowsPasswordSerialPort)
+		}
+		polls++
+		if polls == 1 {
+			return &compute.SerialPortOutput{Contents: "booting\n", Next: 8}, nil
+		}
+		n, _ := base64.StdEncoding.DecodeString(wk.Modulus)
+		e, _ := base64.StdEncoding.DecodeString(wk.Exponent)
+		pub := &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+		ct, err := rsa.EncryptOAEP(sha1.New(), rand.Reader, pub, []byte("s3cret"), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp := fmt.Sprintf(`{"modulus":%q,"encryptedPassword":%q}`, wk.Modulus, base64.StdEncoding.EncodeToString(ct))
+		return &compute.SerialPortOutput{Contents: `{"modulus":"other"}` + "\n" + resp + "\n"}, nil
+	}
+
+	got, err := c.ResetWindowsPassword(testProject, testZone, testInstance, "admin")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "s3cret" {
+		t.Errorf("got password %q, want %q", got, "s3cret")
+	}
+	if wk.UserName != "admin" {
+		t.Errorf("got user name %q, want %q", wk.UserName, "admin")
+	}
+}
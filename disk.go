@@ -18,14 +18,12 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"net/http"
 	"regexp"
 	"strconv"
 	"strings"
 
 	daisyCompute "github.com/GoogleCloudPlatform/compute-daisy/compute"
 	"google.golang.org/api/compute/v1"
-	"google.golang.org/api/googleapi"
 )
 
 var (
@@ -151,7 +149,7 @@ func (dr *diskRegistry) init() {
 func (dr *diskRegistry) deleteFn(res *Resource) DError {
 	m := NamedSubexp(diskURLRgx, res.link)
 	err := dr.w.ComputeClient.DeleteDisk(m["project"], m["zone"], m["disk"])
-	if gErr, ok := err.(*googleapi.Error); ok && gErr.Code == http.StatusNotFound {
+	if daisyCompute.IsNotFound(err) {
 		return typedErr(resourceDNEError, "failed to delete disk", err)
 	}
 	return newErr("failed to delete disk", err)
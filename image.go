@@ -17,8 +17,8 @@ package daisy
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"net/http"
 	"path"
 	"regexp"
 	"strings"
@@ -53,7 +53,7 @@ func (w *Workflow) imageExists(project, family, image string) (bool, DError) {
 
 		img, err := w.ComputeClient.GetImageFromFamily(project, family)
 		if err != nil {
-			if apiErr, ok := err.(*googleapi.Error); ok && apiErr.Code == http.StatusNotFound {
+			if daisyCompute.IsNotFound(err) {
 				return false, nil
 			}
 			return false, typedErr(apiError, "failed to get image from family", err)
@@ -458,8 +458,8 @@ func (ib *ImageBase) validate(ctx context.Context, ii ImageInterface, licenses [
 func isGoogleAPIForbiddenError(err DError) bool {
 	dErrConcrete, isDErrConcrete := err.(*dErrImpl)
 	if isDErrConcrete && len(dErrConcrete.errs) > 0 {
-		gAPIErr, isGAPIErr := dErrConcrete.errs[0].(*googleapi.Error)
-		if isGAPIErr && gAPIErr.Code == 403 {
+		var gAPIErr *googleapi.Error
+		if errors.As(dErrConcrete.errs[0], &gAPIErr) && gAPIErr.Code == 403 {
 			return true
 		}
 	}
@@ -480,7 +480,7 @@ func newImageRegistry(w *Workflow) *imageRegistry {
 func (ir *imageRegistry) deleteFn(res *Resource) DError {
 	m := NamedSubexp(imageURLRgx, res.link)
 	err := ir.w.ComputeClient.DeleteImage(m["project"], m["image"])
-	if gErr, ok := err.(*googleapi.Error); ok && gErr.Code == http.StatusNotFound {
+	if daisyCompute.IsNotFound(err) {
 		return typedErr(resourceDNEError, "failed to delete image", err)
 	}
 	return newErr("failed to delete image", err)
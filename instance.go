@@ -19,7 +19,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"math/rand"
-	"net/http"
 	"path"
 	"regexp"
 	"strings"
@@ -28,7 +27,6 @@ import (
 	daisyCompute "github.com/GoogleCloudPlatform/compute-daisy/compute"
 	computeBeta "google.golang.org/api/compute/v0.beta"
 	"google.golang.org/api/compute/v1"
-	"google.golang.org/api/googleapi"
 )
 
 const (
@@ -820,17 +818,25 @@ var SleepFn = time.Sleep
 
 func (ir *instanceRegistry) deleteFn(res *Resource) DError {
 	m := NamedSubexp(instanceURLRgx, res.link)
+	var ci *compute.Instance
 	for i := 1; i < 4; i++ {
-		if _, err := ir.w.ComputeClient.GetInstance(m["project"], m["zone"], m["instance"]); err != nil {
+		var err error
+		if ci, err = ir.w.ComputeClient.GetInstance(m["project"], m["zone"], m["instance"]); err != nil {
 			// Can't remove an instance that was not even yet created!
 			// However as the command was already submitted, wait.
 			SleepFn((time.Duration(rand.Intn(1000))*time.Millisecond + 1*time.Second) * time.Duration(i))
 			continue
 		}
 	}
+	// Deletion protection has to be cleared before the instance can be deleted.
+	if ci != nil && ci.DeletionProtection {
+		if err := ir.w.ComputeClient.SetDeletionProtection(m["project"], m["zone"], m["instance"], false); err != nil {
+			return newErr("failed to clear deletion protection on instance", err)
+		}
+	}
 	// Proceed to instance deletion
 	err := ir.w.ComputeClient.DeleteInstance(m["project"], m["zone"], m["instance"])
-	if gErr, ok := err.(*googleapi.Error); ok && gErr.Code == http.StatusNotFound {
+	if daisyCompute.IsNotFound(err) {
 		return typedErr(resourceDNEError, "failed to delete instance", err)
 	}
 	return newErr("failed to delete instance", err)
@@ -839,7 +845,7 @@ func (ir *instanceRegistry) deleteFn(res *Resource) DError {
 func (ir *instanceRegistry) startFn(res *Resource) DError {
 	m := NamedSubexp(instanceURLRgx, res.link)
 	err := ir.w.ComputeClient.StartInstance(m["project"], m["zone"], m["instance"])
-	if gErr, ok := err.(*googleapi.Error); ok && gErr.Code == http.StatusNotFound {
+	if daisyCompute.IsNotFound(err) {
 		return typedErr(resourceDNEError, "failed to start instance", err)
 	}
 	return newErr("failed to start instance", err)
@@ -848,7 +854,7 @@ func (ir *instanceRegistry) startFn(res *Resource) DError {
 func (ir *instanceRegistry) stopFn(res *Resource) DError {
 	m := NamedSubexp(instanceURLRgx, res.link)
 	err := ir.w.ComputeClient.StopInstance(m["project"], m["zone"], m["instance"])
-	if gErr, ok := err.(*googleapi.Error); ok && gErr.Code == http.StatusNotFound {
+	if daisyCompute.IsNotFound(err) {
 		return typedErr(resourceDNEError, "failed to stop instance", err)
 	}
 	return newErr("failed to stop instance", err)
@@ -24,6 +24,7 @@ import (
 	"strconv"
 	"testing"
 
+	daisyCompute "github.com/GoogleCloudPlatform/compute-daisy/compute"
 	computeBeta "google.golang.org/api/compute/v0.beta"
 	"google.golang.org/api/compute/v1"
 )
@@ -794,3 +795,34 @@ func TestInstanceValidateNetworks(t *testing.T) {
 		assertTest(tt.shouldErr, tt.ciBeta.validateNetworks(s), tt.desc+" beta")
 	}
 }
+
+func TestInstanceRegistryDeleteFnDeletionProtection(t *testing.T) {
+	for _, protected := range []bool{true, false} {
+		w := testWorkflow()
+		tc := w.ComputeClient.(*daisyCompute.TestClient)
+		var calls []string
+		tc.GetInstanceFn = func(_, _, _ string) (*compute.Instance, error) {
+			return &compute.Instance{DeletionProtection: protected}, nil
+		}
+		tc.SetDeletionProtectionFn = func(_, _, _ string, enabled bool) error {
+			calls = append(calls, fmt.Sprintf("setDeletionProtection %t", enabled))
+			return nil
+		}
+		tc.DeleteInstanceFn = func(_, _, _ string) error {
+			calls = append(calls, "delete")
+			return nil
+		}
+
+		res := &Resource{link: fmt.Sprintf("projects/%s/zones/%s/instances/foo", testProject, testZone)}
+		if err := w.instances.deleteFn(res); err != nil {
+			t.Errorf("protected=%t: unexpected error: %v", protected, err)
+		}
+		want := []string{"delete"}
+		if protected {
+			want = []string{"setDeletionProtection false", "delete"}
+		}
+		if !reflect.DeepEqual(calls, want) {
+			t.Errorf("protected=%t: want calls %v, got %v", protected, want, calls)
+		}
+	}
+}
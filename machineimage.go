@@ -18,12 +18,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"net/http"
 	"regexp"
 
 	daisyCompute "github.com/GoogleCloudPlatform/compute-daisy/compute"
 	"google.golang.org/api/compute/v1"
-	"google.golang.org/api/googleapi"
 )
 
 var (
@@ -103,7 +101,7 @@ func newMachineImageRegistry(w *Workflow) *machineImageRegistry {
 func (ir *machineImageRegistry) deleteFn(res *Resource) DError {
 	m := NamedSubexp(machineImageURLRgx, res.link)
 	err := ir.w.ComputeClient.DeleteMachineImage(m["project"], m["machineImage"])
-	if gErr, ok := err.(*googleapi.Error); ok && gErr.Code == http.StatusNotFound {
+	if daisyCompute.IsNotFound(err) {
 		return typedErr(resourceDNEError, "failed to delete machine image", err)
 	}
 	return newErr("failed to delete machine image", err)
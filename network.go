@@ -19,12 +19,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"net"
-	"net/http"
 	"regexp"
 
 	daisyCompute "github.com/GoogleCloudPlatform/compute-daisy/compute"
 	"google.golang.org/api/compute/v1"
-	"google.golang.org/api/googleapi"
 )
 
 var (
@@ -104,7 +102,7 @@ func newNetworkRegistry(w *Workflow) *networkRegistry {
 func (nr *networkRegistry) deleteFn(res *Resource) DError {
 	m := NamedSubexp(networkURLRegex, res.link)
 	err := nr.w.ComputeClient.DeleteNetwork(m["project"], m["network"])
-	if gErr, ok := err.(*googleapi.Error); ok && gErr.Code == http.StatusNotFound {
+	if daisyCompute.IsNotFound(err) {
 		return typedErr(resourceDNEError, "failed to delete network", err)
 	}
 	return newErr("failed to delete network", err)
@@ -19,7 +19,7 @@ import (
 	"encoding/json"
 	"sync"
 
-	"google.golang.org/api/googleapi"
+	daisyCompute "github.com/GoogleCloudPlatform/compute-daisy/compute"
 )
 
 // CreateImages is a Daisy CreateImages workflow step.
@@ -126,7 +126,7 @@ func (ci *CreateImages) run(ctx context.Context, s *Step) DError {
 		if overwrite {
 			// Just try to delete it, a 404 here indicates the image doesn't exist.
 			if err := ci.delete(w.ComputeClient); err != nil {
-				if apiErr, ok := err.(*googleapi.Error); !ok || apiErr.Code != 404 {
+				if !daisyCompute.IsNotFound(err) {
 					e <- Errf("error deleting existing image: %v", err)
 					return
 				}
@@ -18,6 +18,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"path"
@@ -25,6 +26,7 @@ import (
 	"sync"
 	"time"
 
+	daisyCompute "github.com/GoogleCloudPlatform/compute-daisy/compute"
 	"google.golang.org/api/googleapi"
 )
 
@@ -74,7 +76,7 @@ Loop:
 				numErr++
 				status, sErr := w.ComputeClient.InstanceStatus(path.Base(ib.Project), path.Base(ii.getZone()), ii.getName())
 				switch status {
-				case "TERMINATED", "STOPPED", "STOPPING":
+				case daisyCompute.StatusTerminated, daisyCompute.StatusStopped, daisyCompute.StatusStopping:
 					// Instance is stopped or stopping.
 					if sErr == nil {
 						break Loop
@@ -163,7 +165,7 @@ func (ci *CreateInstances) run(ctx context.Context, s *Step) DError {
 		// Just try to delete it, a 404 here indicates the instance doesn't exist.
 		if ib.OverWrite {
 			if err := ii.delete(w.ComputeClient, true); err != nil {
-				if apiErr, ok := err.(*googleapi.Error); !ok || apiErr.Code != 404 {
+				if !daisyCompute.IsNotFound(err) {
 					eChan <- Errf("error deleting existing instance: %v", err)
 					return
 				}
@@ -241,7 +243,8 @@ func (ci *CreateInstances) instanceUsesBetaFeatures() bool {
 }
 
 func isExternalIPDeniedByOrganizationPolicy(err error) bool {
-	if gErr, ok := err.(*googleapi.Error); ok && gErr.Code == http.StatusPreconditionFailed {
+	var gErr *googleapi.Error
+	if errors.As(err, &gErr) && gErr.Code == http.StatusPreconditionFailed {
 		return strings.Contains(gErr.Message, "constraints/compute.vmExternalIpAccess")
 	}
 	return false
@@ -18,7 +18,7 @@ import (
 	"context"
 	"sync"
 
-	"google.golang.org/api/googleapi"
+	daisyCompute "github.com/GoogleCloudPlatform/compute-daisy/compute"
 )
 
 // CreateMachineImages is a Daisy workflow step for creating machine images.
@@ -61,7 +61,7 @@ func (c *CreateMachineImages) run(ctx context.Context, s *Step) DError {
 			if mi.OverWrite {
 				// Just try to delete it, a 404 here indicates the machine image doesn't exist.
 				if err := w.ComputeClient.DeleteMachineImage(mi.Project, mi.Name); err != nil {
-					if apiErr, ok := err.(*googleapi.Error); !ok || apiErr.Code != 404 {
+					if !daisyCompute.IsNotFound(err) {
 						eChan <- Errf("error deleting existing machine image: %v", err)
 						return
 					}
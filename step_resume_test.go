@@ -114,9 +114,11 @@ func TestResumeValidateError(t *testing.T) {
 
 func TestResumeRun(t *testing.T) {
 	svr, c, err := daisyCompute.NewTestClient(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if r.Method == "POST" && r.URL.String() == fmt.Sprintf("/projects/%s/zones/%s/instances/%s/resume?alt=json&prettyPrint=false", testProject, testZone, testInstance) {
-			fmt.Fprint(w, `{}`)
-		} else if r.Method == "POST" && r.URL.String() == fmt.Sprintf("/projects/%s/zones/%s/operations//wait?alt=json&prettyPrint=false", testProject, testZone) {
+		if r.Method == "GET" && r.URL.String() == fmt.Sprintf("/projects/%s/zones/%s/instances/%s?alt=json&prettyPrint=false", testProject, testZone, testInstance) {
+			fmt.Fprint(w, `{"Status": "SUSPENDED"}`)
+		} else if r.Method == "POST" && r.URL.String() == fmt.Sprintf("/projects/%s/zones/%s/instances/%s/resume?alt=json&prettyPrint=false", testProject, testZone, testInstance) {
+			fmt.Fprint(w, `{"Name":"op"}`)
+		} else if r.Method == "POST" && r.URL.String() == fmt.Sprintf("/projects/%s/zones/%s/operations/op/wait?alt=json&prettyPrint=false", testProject, testZone) {
 			fmt.Fprint(w, `{"Status": "DONE"}`)
 		} else {
 			w.WriteHeader(500)
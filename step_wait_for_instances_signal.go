@@ -23,7 +23,7 @@ import (
 	"sync"
 	"time"
 
-	"google.golang.org/api/googleapi"
+	daisyCompute "github.com/GoogleCloudPlatform/compute-daisy/compute"
 )
 
 const (
@@ -180,7 +180,7 @@ func waitForSerialOutput(s *Step, project, zone, name string, so *SerialOutput,
 				}
 
 				// Wait until machine restarts to evaluate SerialOutput.
-				if status == "TERMINATED" || status == "STOPPED" || status == "STOPPING" {
+				if status == daisyCompute.StatusTerminated || status == daisyCompute.StatusStopped || status == daisyCompute.StatusStopping {
 					continue
 				}
 
@@ -262,7 +262,7 @@ func waitForGuestAttribute(s *Step, project, zone, name string, ga *GuestAttribu
 		case <-tick:
 			resp, err := w.ComputeClient.GetGuestAttributes(project, zone, name, "", varkey)
 			if err != nil {
-				if apiErr, ok := err.(*googleapi.Error); ok && apiErr.Code == 404 {
+				if daisyCompute.IsNotFound(err) {
 					// 404 is OK, that means the key isn't present yet. Retry until timeout.
 					continue
 				}
@@ -275,7 +275,7 @@ func waitForGuestAttribute(s *Step, project, zone, name string, ga *GuestAttribu
 				}
 
 				// Wait until machine restarts to get Guest Attributes
-				if status == "TERMINATED" || status == "STOPPED" || status == "STOPPING" {
+				if status == daisyCompute.StatusTerminated || status == daisyCompute.StatusStopped || status == daisyCompute.StatusStopping {
 					continue
 				}
 
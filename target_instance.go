@@ -18,12 +18,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"net/http"
 	"regexp"
 
 	daisyCompute "github.com/GoogleCloudPlatform/compute-daisy/compute"
 	"google.golang.org/api/compute/v1"
-	"google.golang.org/api/googleapi"
 )
 
 var (
@@ -96,7 +94,7 @@ func newTargetInstanceRegistry(w *Workflow) *targetInstanceRegistry {
 func (tir *targetInstanceRegistry) deleteFn(res *Resource) DError {
 	m := NamedSubexp(targetInstanceURLRegex, res.link)
 	err := tir.w.ComputeClient.DeleteTargetInstance(m["project"], m["zone"], m["targetInstance"])
-	if gErr, ok := err.(*googleapi.Error); ok && gErr.Code == http.StatusNotFound {
+	if daisyCompute.IsNotFound(err) {
 		return typedErr(resourceDNEError, "failed to delete target instance", err)
 	}
 	return newErr("failed to delete target instance", err)
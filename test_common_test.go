@@ -137,7 +137,7 @@ func newTestGCEClient() (*daisyCompute.TestClient, error) {
 		} else if r.Method == "GET" && strings.Contains(r.URL.String(), "serialPort?alt=json&port=2") {
 			fmt.Fprintln(w, `{"Contents":"successfail","Start":"0"}`)
 		} else {
-			fmt.Fprintln(w, `{"Status":"DONE","SelfLink":"link"}`)
+			fmt.Fprintln(w, `{"Name":"op","Status":"DONE","SelfLink":"link"}`)
 		}
 	}))
 